@@ -1,14 +1,41 @@
 package cssparser
 
+import (
+	"go.baoshuo.dev/cssparser/css"
+)
+
 type allowedRuleType int
 
 const (
 	qualifiedRuleTypeStyle allowedRuleType = 1 << iota
 	qualifiedRuleTypeKeyframes
+	atRuleTypeCharset
+	atRuleTypeImport
+	atRuleTypeNamespace
 )
 
 func (t allowedRuleType) Has(ruleType allowedRuleType) bool {
 	return t&ruleType != 0
 }
 
-const topLevelAllowedRules = qualifiedRuleTypeStyle
+// afterRule returns the rules which are still allowed after the given rule
+// in a list of rules.
+//
+// @charset must be the first rule, @import rules must precede all other
+// rules except @charset and @layer statements, and @namespace rules must
+// follow them and precede all other rules.
+//
+// https://www.w3.org/TR/css-cascade-5/#at-import
+// https://www.w3.org/TR/css-namespaces-3/#syntax
+func (t allowedRuleType) afterRule(rule css.Rule) allowedRuleType {
+	switch rule.(type) {
+	case *css.CharsetRule, *css.ImportRule, *css.LayerStatementRule:
+		return t &^ atRuleTypeCharset
+	case *css.NamespaceRule:
+		return t &^ (atRuleTypeCharset | atRuleTypeImport)
+	}
+
+	return t &^ (atRuleTypeCharset | atRuleTypeImport | atRuleTypeNamespace)
+}
+
+const topLevelAllowedRules = qualifiedRuleTypeStyle | atRuleTypeCharset | atRuleTypeImport | atRuleTypeNamespace
@@ -0,0 +1,212 @@
+// Package bundler inlines the stylesheets imported with @import rules,
+// producing a single list of rules.
+package bundler
+
+import (
+	"fmt"
+	"io/fs"
+	"net/url"
+	"path"
+	"strings"
+
+	"go.baoshuo.dev/cssparser"
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// Bundler resolves and inlines @import rules, reading the stylesheets from
+// a file system.
+type Bundler struct {
+	fsys fs.FS
+}
+
+func NewBundler(fsys fs.FS) *Bundler {
+	return &Bundler{
+		fsys: fsys,
+	}
+}
+
+// Bundle parses the stylesheet with the given name and replaces each of
+// its @import rules with the rules of the imported stylesheet, recursively.
+//
+// Stylesheets are decoded to UTF-8 like with cssparser.NewParserFromBytes.
+// An imported stylesheet without a byte order mark or a @charset rule is
+// decoded with the encoding of the stylesheet importing it. The @charset
+// rules are dropped, since the bundle is in UTF-8, the default encoding.
+//
+// Imports are resolved relative to the importing stylesheet, or to the root
+// of the file system if their path starts with '/'. The layer, supports()
+// and media modifiers of an import are preserved by wrapping the inlined
+// rules in the equivalent @layer, @supports and @media rules.
+//
+// Imports of URLs with a scheme or a host can't be inlined. They are kept
+// as long as none of the imports leading to them has a modifier, and only
+// @layer statements come before them in the bundle, since they must stay
+// at its start; otherwise an error is returned. @namespace rules apply to
+// the stylesheet declaring them only, so an error is returned for imported
+// stylesheets with @namespace rules, and for @namespace rules after inlined
+// rules. An error is also returned for import cycles. Invalid rules are
+// dropped, like with cssparser.Parser.ParseStylesheet.
+func (b *Bundler) Bundle(name string) ([]css.Rule, error) {
+	return b.bundle(path.Clean(name), "", nil, false, &bundleState{})
+}
+
+// bundleState is the state shared by the stylesheets of a bundle.
+type bundleState struct {
+	// started is whether a rule which must come after the @import rules
+	// was added to the bundle.
+	started bool
+}
+
+// add appends rules to the rules of a stylesheet, keeping track of the
+// rules which must come after the @import rules.
+func (s *bundleState) add(result []css.Rule, rules ...css.Rule) []css.Rule {
+	for _, rule := range rules {
+		switch rule.(type) {
+		case *css.LayerStatementRule, *css.ImportRule:
+			// These can come before @import rules
+		default:
+			s.started = true
+		}
+	}
+	return append(result, rules...)
+}
+
+// bundle parses a stylesheet and inlines its imports.
+//
+// The stylesheet is decoded with the encoding of the importing stylesheet
+// as environment encoding, which is empty for the entry stylesheet. The
+// chain holds the names of the stylesheets being bundled, to detect
+// cycles. If conditional is true, the stylesheet is imported with a
+// modifier, so its external imports can't be kept.
+func (b *Bundler) bundle(
+	name string,
+	environmentEncoding string,
+	chain []string,
+	conditional bool,
+	state *bundleState,
+) ([]css.Rule, error) {
+	for _, imported := range chain {
+		if imported == name {
+			return nil, fmt.Errorf("import cycle: %s -> %s", strings.Join(chain, " -> "), name)
+		}
+	}
+	chain = append(chain, name)
+
+	data, err := fs.ReadFile(b.fsys, name)
+	if err != nil {
+		return nil, err
+	}
+
+	parser := cssparser.NewParserFromBytes(data, "", environmentEncoding)
+	rules := parser.ParseStylesheet()
+
+	result := make([]css.Rule, 0, len(rules))
+	for _, rule := range rules {
+		importRule, ok := rule.(*css.ImportRule)
+		if !ok {
+			switch {
+			case isCharsetRule(rule):
+				// The encoding it declares is the one the stylesheet was
+				// decoded from, not the one of the bundle
+				continue
+			case isNamespaceRule(rule) && len(chain) > 1:
+				return nil, fmt.Errorf("%s: can't inline a stylesheet with @namespace rules", name)
+			case isNamespaceRule(rule) && state.started:
+				return nil, fmt.Errorf("%s: can't keep @namespace rules after inlined rules", name)
+			}
+			result = state.add(result, rule)
+			continue
+		}
+
+		importedName, ok, err := resolveImport(name, importRule.URL)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+
+		if !ok {
+			if conditional || hasModifiers(importRule) {
+				return nil, fmt.Errorf("%s: can't inline conditional import of %q", name, importRule.URL)
+			}
+			if state.started {
+				// Moving it before the inlined rules would change the cascade
+				return nil, fmt.Errorf("%s: can't keep import of %q after inlined rules", name, importRule.URL)
+			}
+			result = state.add(result, rule)
+			continue
+		}
+
+		importedRules, err := b.bundle(importedName, parser.Encoding(), chain, conditional || hasModifiers(importRule), state)
+		if err != nil {
+			return nil, err
+		}
+
+		result = state.add(result, wrapImportedRules(importRule, importedRules)...)
+	}
+
+	return result, nil
+}
+
+// resolveImport resolves the URL of an import relative to the importing
+// stylesheet. Returns false if the URL can't be resolved in the file
+// system, i.e. it has a scheme or a host.
+func resolveImport(importer string, rawURL string) (string, bool, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", false, fmt.Errorf("invalid import url %q: %w", rawURL, err)
+	}
+
+	if u.Scheme != "" || u.Host != "" {
+		return "", false, nil
+	}
+
+	var name string
+	if strings.HasPrefix(u.Path, "/") {
+		name = path.Clean(strings.TrimPrefix(u.Path, "/"))
+	} else {
+		name = path.Join(path.Dir(importer), u.Path)
+	}
+
+	if !fs.ValidPath(name) {
+		return "", false, fmt.Errorf("import %q is outside of the file system", rawURL)
+	}
+
+	return name, true, nil
+}
+
+// wrapImportedRules wraps the rules of an imported stylesheet in the @media,
+// @supports and @layer rules equivalent to the modifiers of the import.
+func wrapImportedRules(importRule *css.ImportRule, rules []css.Rule) []css.Rule {
+	if importRule.Layer {
+		rules = []css.Rule{&css.LayerBlockRule{Name: importRule.LayerName, Rules: rules}}
+	}
+
+	if importRule.Supports != nil {
+		rules = []css.Rule{&css.SupportsRule{Condition: importRule.Supports, Rules: rules}}
+	}
+
+	if importRule.Media != nil && len(importRule.Media.Queries) > 0 {
+		rules = []css.Rule{&css.MediaRule{Queries: importRule.Media, Rules: rules}}
+	}
+
+	return rules
+}
+
+// hasModifiers checks if an import has a layer, supports() or media
+// modifier.
+func hasModifiers(importRule *css.ImportRule) bool {
+	return importRule.Layer ||
+		importRule.Supports != nil ||
+		(importRule.Media != nil && len(importRule.Media.Queries) > 0)
+}
+
+// isCharsetRule checks if a rule is a @charset rule.
+func isCharsetRule(rule css.Rule) bool {
+	_, ok := rule.(*css.CharsetRule)
+	return ok
+}
+
+// isNamespaceRule checks if a rule is a @namespace rule.
+func isNamespaceRule(rule css.Rule) bool {
+	_, ok := rule.(*css.NamespaceRule)
+	return ok
+}
@@ -0,0 +1,244 @@
+package bundler
+
+import (
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestBundler_Bundle(t *testing.T) {
+	testcases := []struct {
+		name        string
+		files       map[string]string
+		entry       string
+		expectError bool
+		expected    string
+	}{
+		{
+			name: "no imports",
+			files: map[string]string{
+				"main.css": "div { color: red; }",
+			},
+			entry:    "main.css",
+			expected: "div { color: red; }",
+		},
+		{
+			name: "relative import",
+			files: map[string]string{
+				"css/main.css":       `@import "base/reset.css"; div { color: red; }`,
+				"css/base/reset.css": "p { margin: 0; }",
+			},
+			entry:    "css/main.css",
+			expected: "p { margin: 0; } div { color: red; }",
+		},
+		{
+			name: "nested relative imports",
+			files: map[string]string{
+				"main.css": `@import url(a/a.css);`,
+				"a/a.css":  `@import "../b/b.css"; a { color: red; }`,
+				"b/b.css":  `b { color: blue; }`,
+			},
+			entry:    "main.css",
+			expected: "b { color: blue; } a { color: red; }",
+		},
+		{
+			name: "root-relative import",
+			files: map[string]string{
+				"pages/main.css": `@import "/shared.css";`,
+				"shared.css":     "p { margin: 0; }",
+			},
+			entry:    "pages/main.css",
+			expected: "p { margin: 0; }",
+		},
+		{
+			name: "import with media",
+			files: map[string]string{
+				"main.css":  `@import "print.css" print;`,
+				"print.css": "div { color: black; }",
+			},
+			entry:    "main.css",
+			expected: "@media print { div { color: black; } }",
+		},
+		{
+			name: "import with all modifiers",
+			files: map[string]string{
+				"main.css": `@import "a.css" layer(base) supports(display: grid) screen;`,
+				"a.css":    "div { display: grid; }",
+			},
+			entry:    "main.css",
+			expected: "@media screen { @supports (display: grid) { @layer base { div { display: grid; } } } }",
+		},
+		{
+			name: "anonymous layer",
+			files: map[string]string{
+				"main.css": `@import "a.css" layer;`,
+				"a.css":    "div { color: red; }",
+			},
+			entry:    "main.css",
+			expected: "@layer { div { color: red; } }",
+		},
+		{
+			name: "charset rules are dropped",
+			files: map[string]string{
+				"main.css": `@charset "utf-8"; @import "a.css";`,
+				"a.css":    `@charset "utf-8"; div { color: red; }`,
+			},
+			entry:    "main.css",
+			expected: `div { color: red; }`,
+		},
+		{
+			name: "imported sheets inherit the encoding",
+			files: map[string]string{
+				"main.css": "@charset \"iso-8859-1\"; @import \"a.css\"; b { font-family: Caf\xE9; }",
+				"a.css":    "a { font-family: Caf\xE9; }",
+			},
+			entry:    "main.css",
+			expected: `a { font-family: Café; } b { font-family: Café; }`,
+		},
+		{
+			name: "imported sheet with its own encoding",
+			files: map[string]string{
+				"main.css": "@charset \"iso-8859-1\"; @import \"a.css\"; b { font-family: Caf\xE9; }",
+				"a.css":    "@charset \"utf-8\"; a { font-family: Caf\xC3\xA9; }",
+			},
+			entry:    "main.css",
+			expected: `a { font-family: Café; } b { font-family: Café; }`,
+		},
+		{
+			name: "external imports are kept in place",
+			files: map[string]string{
+				"main.css": `@charset "utf-8"; @layer base; @import url(https://example.com/font.css); @import "a.css"; div { color: red; }`,
+				"a.css":    `@import "//example.com/b.css"; @layer theme; a { color: blue; }`,
+			},
+			entry:    "main.css",
+			expected: `@layer base; @import url("https://example.com/font.css"); @import url("//example.com/b.css"); @layer theme; a { color: blue; } div { color: red; }`,
+		},
+		{
+			name: "external import after inlined rules",
+			files: map[string]string{
+				"main.css": `@import "a.css"; @import url(https://example.com/font.css);`,
+				"a.css":    `a { color: blue; }`,
+			},
+			entry:       "main.css",
+			expectError: true,
+		},
+		{
+			name: "external import after an inlined layer",
+			files: map[string]string{
+				"main.css": `@import "a.css" layer(base); @import url(https://example.com/font.css);`,
+				"a.css":    ``,
+			},
+			entry:       "main.css",
+			expectError: true,
+		},
+		{
+			name: "namespace in the entry stylesheet",
+			files: map[string]string{
+				"main.css": `@import "a.css"; @namespace svg url(http://www.w3.org/2000/svg); svg|a { color: red; }`,
+				"a.css":    `@layer base;`,
+			},
+			entry:    "main.css",
+			expected: `@layer base; @namespace svg url("http://www.w3.org/2000/svg"); svg|a { color: red; }`,
+		},
+		{
+			name: "namespace after inlined rules",
+			files: map[string]string{
+				"main.css": `@import "a.css"; @namespace svg url(http://www.w3.org/2000/svg); svg|a { color: red; }`,
+				"a.css":    `a { color: blue; }`,
+			},
+			entry:       "main.css",
+			expectError: true,
+		},
+		{
+			name: "namespace in an imported stylesheet",
+			files: map[string]string{
+				"main.css": `@import "a.css" print;`,
+				"a.css":    `@namespace svg url(http://www.w3.org/2000/svg); svg|a { color: red; }`,
+			},
+			entry:       "main.css",
+			expectError: true,
+		},
+		{
+			name: "conditional external import",
+			files: map[string]string{
+				"main.css": `@import "a.css" print;`,
+				"a.css":    `@import "https://example.com/b.css";`,
+			},
+			entry:       "main.css",
+			expectError: true,
+		},
+		{
+			name: "import cycle",
+			files: map[string]string{
+				"a.css": `@import "b.css";`,
+				"b.css": `@import "./a.css";`,
+			},
+			entry:       "a.css",
+			expectError: true,
+		},
+		{
+			name: "self import",
+			files: map[string]string{
+				"a.css": `@import "a.css";`,
+			},
+			entry:       "a.css",
+			expectError: true,
+		},
+		{
+			name: "repeated import is not a cycle",
+			files: map[string]string{
+				"main.css": `@import "a.css"; @import "a.css" print;`,
+				"a.css":    "div { color: red; }",
+			},
+			entry:    "main.css",
+			expected: "div { color: red; } @media print { div { color: red; } }",
+		},
+		{
+			name: "missing file",
+			files: map[string]string{
+				"main.css": `@import "missing.css";`,
+			},
+			entry:       "main.css",
+			expectError: true,
+		},
+		{
+			name: "import outside of the file system",
+			files: map[string]string{
+				"main.css": `@import "../a.css";`,
+			},
+			entry:       "main.css",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			fsys := fstest.MapFS{}
+			for name, content := range tc.files {
+				fsys[name] = &fstest.MapFile{Data: []byte(content)}
+			}
+
+			rules, err := NewBundler(fsys).Bundle(tc.entry)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			ruleStrs := make([]string, 0, len(rules))
+			for _, rule := range rules {
+				ruleStrs = append(ruleStrs, rule.String())
+			}
+
+			if result := strings.Join(ruleStrs, " "); result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
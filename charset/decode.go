@@ -0,0 +1,126 @@
+package charset
+
+import (
+	"bytes"
+)
+
+// charsetPrefix is the byte sequence a stylesheet starts with when its
+// first rule is a @charset rule.
+var charsetPrefix = []byte(`@charset "`)
+
+// Decode decodes a stylesheet to UTF-8 and returns it, along with the
+// name of the encoding it was decoded from.
+//
+// The protocol encoding is the encoding given by the transport layer, e.g.
+// the charset parameter of a Content-Type header, and the environment
+// encoding is the encoding of the referring document or stylesheet. Both
+// are labels and may be empty. Labels of unknown or unsupported encodings
+// are ignored, and the first one which was considered is returned as the
+// third result, e.g. "shift_jis": the stylesheet is then decoded with the
+// next encoding found, which is likely wrong. It is empty otherwise.
+//
+// A byte order mark takes precedence over any other encoding information,
+// and is removed from the result.
+//
+// https://www.w3.org/TR/css-syntax-3/#input-byte-stream
+func Decode(data []byte, protocolEncoding, environmentEncoding string) (string, string, string) {
+	encoding, unsupported := fallbackEncoding(data, protocolEncoding, environmentEncoding)
+
+	// https://encoding.spec.whatwg.org/#bom-sniff
+	switch {
+	case bytes.HasPrefix(data, []byte{0xEF, 0xBB, 0xBF}):
+		encoding, unsupported, data = UTF8, "", data[3:]
+	case bytes.HasPrefix(data, []byte{0xFE, 0xFF}):
+		encoding, unsupported, data = UTF16BE, "", data[2:]
+	case bytes.HasPrefix(data, []byte{0xFF, 0xFE}):
+		encoding, unsupported, data = UTF16LE, "", data[2:]
+	}
+
+	switch encoding {
+	case UTF16BE:
+		return decodeUTF16(data, true), encoding, unsupported
+	case UTF16LE:
+		return decodeUTF16(data, false), encoding, unsupported
+	case Windows1252:
+		return decodeWindows1252(data), encoding, unsupported
+	default:
+		return decodeUTF8(data), UTF8, unsupported
+	}
+}
+
+// FallbackEncoding determines the encoding of a stylesheet without a byte
+// order mark, from the protocol encoding, a leading @charset rule, and the
+// environment encoding, in that order, defaulting to UTF-8.
+//
+// A @charset rule declaring a UTF-16 encoding can't be right, since the
+// rule itself was read as ASCII, so it is taken as UTF-8.
+//
+// https://www.w3.org/TR/css-syntax-3/#determine-the-fallback-encoding
+func FallbackEncoding(data []byte, protocolEncoding, environmentEncoding string) string {
+	encoding, _ := fallbackEncoding(data, protocolEncoding, environmentEncoding)
+	return encoding
+}
+
+// fallbackEncoding is FallbackEncoding, which also returns the first label
+// ignored because Lookup doesn't know it, or an empty string.
+func fallbackEncoding(data []byte, protocolEncoding, environmentEncoding string) (string, string) {
+	var unsupported string
+	lookup := func(label string) (string, bool) {
+		encoding, ok := Lookup(label)
+		if !ok && unsupported == "" {
+			unsupported = label
+		}
+		return encoding, ok
+	}
+
+	if protocolEncoding != "" {
+		if encoding, ok := lookup(protocolEncoding); ok {
+			return encoding, unsupported
+		}
+	}
+
+	if label, ok := sniffCharsetRule(data); ok {
+		if encoding, ok := lookup(label); ok {
+			if encoding == UTF16BE || encoding == UTF16LE {
+				return UTF8, unsupported
+			}
+			return encoding, unsupported
+		}
+	}
+
+	if environmentEncoding != "" {
+		if encoding, ok := lookup(environmentEncoding); ok {
+			return encoding, unsupported
+		}
+	}
+
+	return UTF8, unsupported
+}
+
+// sniffCharsetRule returns the label of the @charset rule the stylesheet
+// starts with, looking only at its first 1024 bytes. The rule must be
+// written exactly as '@charset "label";'.
+func sniffCharsetRule(data []byte) (string, bool) {
+	if len(data) > 1024 {
+		data = data[:1024]
+	}
+
+	if !bytes.HasPrefix(data, charsetPrefix) {
+		return "", false
+	}
+
+	rest := data[len(charsetPrefix):]
+	for i, b := range rest {
+		switch b {
+		case '"':
+			if i+1 < len(rest) && rest[i+1] == ';' {
+				return string(rest[:i]), true
+			}
+			return "", false
+		case ';':
+			return "", false
+		}
+	}
+
+	return "", false
+}
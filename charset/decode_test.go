@@ -0,0 +1,173 @@
+package charset
+
+import "testing"
+
+func TestDecode(t *testing.T) {
+	tests := []struct {
+		name                string
+		data                []byte
+		protocolEncoding    string
+		environmentEncoding string
+		expected            string
+		expectedEncoding    string
+		expectedUnsupported string
+	}{
+		{
+			name:             "default to utf-8",
+			data:             []byte("a { content: \"\xC3\xA9\"; }"),
+			expected:         "a { content: \"é\"; }",
+			expectedEncoding: UTF8,
+		},
+		{
+			name:             "invalid utf-8",
+			data:             []byte("a\xFFb"),
+			expected:         "a�b",
+			expectedEncoding: UTF8,
+		},
+		{
+			name:             "utf-8 bom",
+			data:             []byte("\xEF\xBB\xBFa{}"),
+			protocolEncoding: "latin1",
+			expected:         "a{}",
+			expectedEncoding: UTF8,
+		},
+		{
+			name:             "utf-16be bom",
+			data:             []byte{0xFE, 0xFF, 0x00, 'a', 0x00, '{', 0x00, '}'},
+			expected:         "a{}",
+			expectedEncoding: UTF16BE,
+		},
+		{
+			name:             "utf-16le bom",
+			data:             []byte{0xFF, 0xFE, 'a', 0x00, 0xE9, 0x00, 0x3D, 0xD8, 0x00, 0xDE},
+			expected:         "aé😀",
+			expectedEncoding: UTF16LE,
+		},
+		{
+			name:             "utf-16 odd byte and unpaired surrogate",
+			data:             []byte{0xFF, 0xFE, 0x3D, 0xD8, 'a', 0x00, 'b'},
+			expected:         "�a�",
+			expectedEncoding: UTF16LE,
+		},
+		{
+			name:             "protocol encoding",
+			data:             []byte("a { content: \"\xE9\x80\"; }"),
+			protocolEncoding: " ISO-8859-1 ",
+			expected:         "a { content: \"é€\"; }",
+			expectedEncoding: Windows1252,
+		},
+		{
+			name:             "protocol encoding over @charset",
+			data:             []byte("@charset \"utf-8\"; a { content: \"\xE9\"; }"),
+			protocolEncoding: "latin1",
+			expected:         "@charset \"utf-8\"; a { content: \"é\"; }",
+			expectedEncoding: Windows1252,
+		},
+		{
+			name:             "@charset",
+			data:             []byte("@charset \"windows-1252\"; a { content: \"\xE9\"; }"),
+			expected:         "@charset \"windows-1252\"; a { content: \"é\"; }",
+			expectedEncoding: Windows1252,
+		},
+		{
+			name:             "@charset with utf-16",
+			data:             []byte("@charset \"utf-16\"; a{}"),
+			expected:         "@charset \"utf-16\"; a{}",
+			expectedEncoding: UTF8,
+		},
+		{
+			name:                "@charset over environment encoding",
+			data:                []byte("@charset \"utf-8\"; \xC3\xA9"),
+			environmentEncoding: "latin1",
+			expected:            "@charset \"utf-8\"; é",
+			expectedEncoding:    UTF8,
+		},
+		{
+			name:                "unknown @charset falls back to environment encoding",
+			data:                []byte("@charset \"foo\"; \xE9"),
+			environmentEncoding: "latin1",
+			expected:            "@charset \"foo\"; é",
+			expectedEncoding:    Windows1252,
+			expectedUnsupported: "foo",
+		},
+		{
+			name:                "@charset must be written exactly",
+			data:                []byte("@charset 'latin1'; \xC3\xA9"),
+			environmentEncoding: "utf8",
+			expected:            "@charset 'latin1'; é",
+			expectedEncoding:    UTF8,
+		},
+		{
+			name:                "unknown protocol encoding",
+			data:                []byte("\xC3\xA9"),
+			protocolEncoding:    "shift_jis",
+			expected:            "é",
+			expectedEncoding:    UTF8,
+			expectedUnsupported: "shift_jis",
+		},
+		{
+			name:                "first unsupported encoding",
+			data:                []byte("@charset \"windows-1251\"; a{}"),
+			protocolEncoding:    "iso-8859-2",
+			environmentEncoding: "latin1",
+			expected:            "@charset \"windows-1251\"; a{}",
+			expectedEncoding:    Windows1252,
+			expectedUnsupported: "iso-8859-2",
+		},
+		{
+			name:             "unsupported encoding after a supported one",
+			data:             []byte("@charset \"windows-1251\"; a{}"),
+			protocolEncoding: "utf-8",
+			expected:         "@charset \"windows-1251\"; a{}",
+			expectedEncoding: UTF8,
+		},
+		{
+			name:             "unsupported encoding and a bom",
+			data:             []byte("\xEF\xBB\xBFa{}"),
+			protocolEncoding: "shift_jis",
+			expected:         "a{}",
+			expectedEncoding: UTF8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, encoding, unsupported := Decode(tt.data, tt.protocolEncoding, tt.environmentEncoding)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+			if encoding != tt.expectedEncoding {
+				t.Errorf("expected encoding %q, got %q", tt.expectedEncoding, encoding)
+			}
+			if unsupported != tt.expectedUnsupported {
+				t.Errorf("expected unsupported encoding %q, got %q", tt.expectedUnsupported, unsupported)
+			}
+		})
+	}
+}
+
+func TestSniffCharsetRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		expected string
+		ok       bool
+	}{
+		{"valid", `@charset "latin1"; a{}`, "latin1", true},
+		{"empty label", `@charset ""; a{}`, "", true},
+		{"missing semicolon", `@charset "latin1" ; a{}`, "", false},
+		{"semicolon in label", `@charset "lat;in1";`, "", false},
+		{"uppercase keyword", `@CHARSET "latin1";`, "", false},
+		{"leading whitespace", ` @charset "latin1";`, "", false},
+		{"unterminated", `@charset "latin1`, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			label, ok := sniffCharsetRule([]byte(tt.data))
+			if label != tt.expected || ok != tt.ok {
+				t.Errorf("expected (%q, %v), got (%q, %v)", tt.expected, tt.ok, label, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+// Package charset determines the character encoding of a stylesheet and
+// decodes it to UTF-8 before it is tokenized.
+//
+// https://www.w3.org/TR/css-syntax-3/#input-byte-stream
+package charset
+
+import (
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// The names of the supported encodings, as defined by the Encoding
+// Standard.
+const (
+	UTF8        = "utf-8"
+	UTF16BE     = "utf-16be"
+	UTF16LE     = "utf-16le"
+	Windows1252 = "windows-1252"
+)
+
+// labels maps the labels of the supported encodings to their names.
+//
+// The Latin-1 and ASCII labels map to windows-1252, which is a superset
+// of both.
+//
+// https://encoding.spec.whatwg.org/#names-and-labels
+var labels = map[string]string{
+	"unicode-1-1-utf-8": UTF8,
+	"unicode11utf8":     UTF8,
+	"unicode20utf8":     UTF8,
+	"utf-8":             UTF8,
+	"utf8":              UTF8,
+	"x-unicode20utf8":   UTF8,
+
+	"unicodefffe": UTF16BE,
+	"utf-16be":    UTF16BE,
+
+	"csunicode":       UTF16LE,
+	"iso-10646-ucs-2": UTF16LE,
+	"ucs-2":           UTF16LE,
+	"unicode":         UTF16LE,
+	"unicodefeff":     UTF16LE,
+	"utf-16":          UTF16LE,
+	"utf-16le":        UTF16LE,
+
+	"ansi_x3.4-1968":  Windows1252,
+	"ascii":           Windows1252,
+	"cp1252":          Windows1252,
+	"cp819":           Windows1252,
+	"csisolatin1":     Windows1252,
+	"ibm819":          Windows1252,
+	"iso-8859-1":      Windows1252,
+	"iso-ir-100":      Windows1252,
+	"iso8859-1":       Windows1252,
+	"iso88591":        Windows1252,
+	"iso_8859-1":      Windows1252,
+	"iso_8859-1:1987": Windows1252,
+	"l1":              Windows1252,
+	"latin1":          Windows1252,
+	"us-ascii":        Windows1252,
+	"windows-1252":    Windows1252,
+	"x-cp1252":        Windows1252,
+}
+
+// Lookup returns the name of the encoding with the given label, ignoring
+// ASCII case and surrounding whitespace. It returns false for unknown
+// labels and for encodings which are not supported.
+//
+// https://encoding.spec.whatwg.org/#concept-encoding-get
+func Lookup(label string) (string, bool) {
+	name, ok := labels[strings.ToLower(strings.Trim(label, "\t\n\f\r "))]
+	return name, ok
+}
+
+// windows1252 holds the code points of the bytes 0x80 to 0x9F in
+// windows-1252. The other bytes map to the code point of the same value.
+//
+// https://encoding.spec.whatwg.org/index-windows-1252.txt
+var windows1252 = [32]rune{
+	0x20AC, 0x0081, 0x201A, 0x0192, 0x201E, 0x2026, 0x2020, 0x2021,
+	0x02C6, 0x2030, 0x0160, 0x2039, 0x0152, 0x008D, 0x017D, 0x008F,
+	0x0090, 0x2018, 0x2019, 0x201C, 0x201D, 0x2022, 0x2013, 0x2014,
+	0x02DC, 0x2122, 0x0161, 0x203A, 0x0153, 0x009D, 0x017E, 0x0178,
+}
+
+// decodeUTF8 decodes UTF-8 bytes, replacing each invalid byte with
+// U+FFFD.
+func decodeUTF8(data []byte) string {
+	if utf8.Valid(data) {
+		return string(data)
+	}
+
+	var result strings.Builder
+	result.Grow(len(data))
+
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		result.WriteRune(r) // utf8.RuneError is U+FFFD
+		data = data[size:]
+	}
+
+	return result.String()
+}
+
+// decodeUTF16 decodes UTF-16 bytes of the given endianness. Unpaired
+// surrogates and a trailing odd byte are replaced with U+FFFD.
+func decodeUTF16(data []byte, bigEndian bool) string {
+	units := make([]uint16, 0, len(data)/2)
+	for i := 0; i+1 < len(data); i += 2 {
+		if bigEndian {
+			units = append(units, uint16(data[i])<<8|uint16(data[i+1]))
+		} else {
+			units = append(units, uint16(data[i+1])<<8|uint16(data[i]))
+		}
+	}
+
+	result := string(utf16.Decode(units))
+	if len(data)%2 != 0 {
+		result += string(utf8.RuneError)
+	}
+
+	return result
+}
+
+// decodeWindows1252 decodes windows-1252 bytes.
+func decodeWindows1252(data []byte) string {
+	var result strings.Builder
+	result.Grow(len(data))
+
+	for _, b := range data {
+		if b >= 0x80 && b <= 0x9F {
+			result.WriteRune(windows1252[b-0x80])
+		} else {
+			result.WriteRune(rune(b))
+		}
+	}
+
+	return result.String()
+}
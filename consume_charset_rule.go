@@ -0,0 +1,40 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// consumeCharsetRule consumes a @charset rule, e.g. '@charset "utf-8";'.
+//
+// The encoding it declares is only used when decoding the stylesheet from
+// bytes, see NewParserFromBytes; the rule is kept so that it survives a
+// round trip.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-syntax-3/#charset-rule
+func (p *Parser) consumeCharsetRule() (*css.CharsetRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	token := p.s.Peek()
+	if token.Type != csslexer.StringToken {
+		p.skipAtRule()
+		return nil, errors.New("expected string after @charset")
+	}
+	p.s.ConsumeIncludingWhitespace()
+
+	if p.s.Peek().Type != csslexer.SemicolonToken {
+		p.skipAtRule()
+		return nil, errors.New("expected ';' after @charset rule")
+	}
+	p.s.Consume()
+
+	return &css.CharsetRule{
+		Encoding: token.Value,
+	}, nil
+}
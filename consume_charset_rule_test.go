@@ -0,0 +1,166 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+func TestParser_ConsumeCharsetRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "valid rule",
+			input:        `@charset "utf-8"; a`,
+			expected:     `@charset "utf-8";`,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing semicolon",
+			input:        `@charset "utf-8" a { } b`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "encoding is not a string",
+			input:        `@charset utf-8; a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeCharsetRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestParser_CharsetRulePosition(t *testing.T) {
+	parser := NewParser(csslexer.NewInput(`@charset "utf-8"; div { } @charset "utf-8"; @media print { @charset "utf-8"; a { } }`))
+
+	rules := parser.ParseStylesheet()
+
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(rules))
+	}
+	if _, ok := rules[0].(*css.CharsetRule); !ok {
+		t.Errorf("expected first rule to be *css.CharsetRule, got %T", rules[0])
+	}
+	if result := rules[2].String(); result != "@media print { a { } }" {
+		t.Errorf("expected misplaced @charset to be dropped from @media, got %q", result)
+	}
+
+	diagnostics := parser.Diagnostics()
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %d", len(diagnostics))
+	}
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Text != `@charset "utf-8";` {
+			t.Errorf("expected misplaced @charset to be reported, got %q", diagnostic.Text)
+		}
+	}
+}
+
+func TestNewParserFromBytes(t *testing.T) {
+	testcases := []struct {
+		name                string
+		data                []byte
+		protocolEncoding    string
+		environmentEncoding string
+		expected            string
+		expectedEncoding    string
+		expectedUnsupported string
+	}{
+		{
+			name:             "utf-8",
+			data:             []byte("div { font-family: Caf\xC3\xA9; }"),
+			expected:         "div { font-family: Café; }",
+			expectedEncoding: "utf-8",
+		},
+		{
+			name:             "@charset",
+			data:             []byte("@charset \"latin1\"; div { font-family: Caf\xE9; }"),
+			expected:         "@charset \"latin1\"; div { font-family: Café; }",
+			expectedEncoding: "windows-1252",
+		},
+		{
+			name:                "environment encoding",
+			data:                []byte("div { font-family: Caf\xE9; }"),
+			environmentEncoding: "iso-8859-1",
+			expected:            "div { font-family: Café; }",
+			expectedEncoding:    "windows-1252",
+		},
+		{
+			name:             "utf-16le with bom",
+			data:             []byte{0xFF, 0xFE, 'a', 0, ' ', 0, '{', 0, ' ', 0, '}', 0},
+			protocolEncoding: "latin1",
+			expected:         "a { }",
+			expectedEncoding: "utf-16le",
+		},
+		{
+			name:                "unsupported encoding",
+			data:                []byte("@charset \"shift_jis\"; a { }"),
+			expected:            "@charset \"shift_jis\"; a { }",
+			expectedEncoding:    "utf-8",
+			expectedUnsupported: "shift_jis",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			parser := NewParserFromBytes(tc.data, tc.protocolEncoding, tc.environmentEncoding)
+
+			if encoding := parser.Encoding(); encoding != tc.expectedEncoding {
+				t.Errorf("expected encoding %q, got %q", tc.expectedEncoding, encoding)
+			}
+			if unsupported := parser.UnsupportedEncoding(); unsupported != tc.expectedUnsupported {
+				t.Errorf("expected unsupported encoding %q, got %q", tc.expectedUnsupported, unsupported)
+			}
+
+			rules := parser.ParseStylesheet()
+
+			result := ""
+			for i, rule := range rules {
+				if i > 0 {
+					result += " "
+				}
+				result += rule.String()
+			}
+
+			if result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
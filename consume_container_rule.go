@@ -0,0 +1,53 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/container"
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+// consumeContainerRule consumes a @container rule.
+//
+// If nested is true, the rule is inside a style rule and its block is
+// parsed like the contents of a style rule. A rule with an invalid prelude
+// is consumed entirely and an error is returned.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-conditional-5/#container-rule
+func (p *Parser) consumeContainerRule(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	nested bool,
+) (*css.ContainerRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	queries, err := container.ConsumeContainerQueryList(p.s)
+	if err == nil && p.s.Peek().Type != csslexer.LeftBraceToken {
+		err = errors.New("expected '{' after container query list")
+	}
+
+	if err != nil {
+		// Drop the whole rule, including its block
+		p.skipAtRule()
+		return nil, err
+	}
+
+	rule := &css.ContainerRule{
+		Queries: queries,
+	}
+
+	declarations, rules, err := p.consumeGroupRuleBlock(nestingType, parentRuleForNesting, nested)
+	if err != nil {
+		return nil, err
+	}
+	rule.Declarations = declarations
+	rule.Rules = rules
+
+	return rule, nil
+}
@@ -0,0 +1,122 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+func TestParser_ConsumeContainerRule(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		input                string
+		nestingType          nesting.NestingTypeType
+		nested               bool
+		expectError          bool
+		expected             string
+		expectedDeclarations int
+		expectedRules        int
+		expectedNext         csslexer.TokenType
+	}{
+		{
+			name:          "top-level rule",
+			input:         "@container card (min-width: 400px) { div { color: red; } .a { margin: 0; } }",
+			expected:      "@container card (min-width: 400px) { div { color: red; } .a { margin: 0; } }",
+			expectedRules: 2,
+			expectedNext:  csslexer.EOFToken,
+		},
+		{
+			name:         "query list",
+			input:        "@container sidebar, style(--compact: 1) { }",
+			expected:     "@container sidebar, style(--compact: 1) { }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:                 "nested declarations",
+			input:                "@container (width > 400px) { color: red; div { margin: 0; } }",
+			nestingType:          nesting.NestingTypeNesting,
+			nested:               true,
+			expected:             "@container (width > 400px) { color: red; div { margin: 0; } }",
+			expectedDeclarations: 1,
+			expectedRules:        1,
+			expectedNext:         csslexer.EOFToken,
+		},
+		{
+			name:         "empty prelude",
+			input:        "@container { div { color: red; } } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid prelude",
+			input:        "@container none { div { color: red; } } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@container card; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeContainerRule(tc.nestingType, nil, tc.nested)
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if len(rule.Declarations) != tc.expectedDeclarations {
+				t.Errorf("expected %d declarations, got %d", tc.expectedDeclarations, len(rule.Declarations))
+			}
+
+			if len(rule.Rules) != tc.expectedRules {
+				t.Errorf("expected %d rules, got %d", tc.expectedRules, len(rule.Rules))
+			}
+		})
+	}
+}
+
+func TestParser_NestedContainerRule(t *testing.T) {
+	input := csslexer.NewInput("div { color: red; @container (width > 400px) { color: blue; } }")
+	parser := NewParser(input)
+
+	rules := parser.ParseStylesheet()
+
+	if len(rules) != 1 {
+		t.Fatalf("expected 1 rule, got %d", len(rules))
+	}
+
+	styleRule, ok := rules[0].(*css.StyleRule)
+	if !ok || len(styleRule.Rules) != 1 {
+		t.Fatalf("expected the nested @container rule to be kept")
+	}
+	if _, ok := styleRule.Rules[0].(*css.ContainerRule); !ok {
+		t.Errorf("expected *css.ContainerRule, got %T", styleRule.Rules[0])
+	}
+}
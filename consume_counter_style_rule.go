@@ -0,0 +1,64 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/counterstyle"
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeCounterStyleRule consumes a @counter-style rule, which defines a
+// custom counter style, e.g. "@counter-style thumbs { system: cyclic;
+// symbols: '👍'; }".
+//
+// The rule is dropped with an error if its name can't be used, or if its
+// descriptors don't define a counter style.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#the-counter-style-rule
+func (p *Parser) consumeCounterStyleRule() (*css.CounterStyleRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	if p.s.Peek().Type != csslexer.IdentToken {
+		p.skipAtRule()
+		return nil, errors.New("expected counter style name after @counter-style")
+	}
+
+	name, ok := counterstyle.ParseCounterStyleName(p.s.ConsumeComponentValue())
+	if !ok || counterstyle.IsNonOverridableCounterStyleName(name) {
+		p.skipAtRule()
+		return nil, errors.New("invalid counter style name")
+	}
+	p.s.ConsumeWhitespace()
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @counter-style name")
+	}
+
+	// The descriptors of a dropped rule are not reported on their own
+	diagnostics := len(p.diagnostics)
+
+	var rule *css.CounterStyleRule
+	var ruleErr error
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		// The error of an invalid rule is reported after the block is
+		// consumed, so that parsing resumes after the rule
+		rule, ruleErr = counterstyle.ConsumeCounterStyleDescriptors(ts, name, p.reportDroppedDescriptor)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ruleErr != nil {
+		p.diagnostics = p.diagnostics[:diagnostics]
+		return nil, ruleErr
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,95 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumeCounterStyleRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "cyclic",
+			input:        `@counter-style thumbs { system: cyclic; symbols: "👍"; suffix: " "; }`,
+			expected:     `@counter-style thumbs { system: cyclic; symbols: "👍"; suffix: " "; }`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "predefined name is lowercased",
+			input:        "@counter-style Lower-Roman { system: extends upper-roman; } a",
+			expected:     "@counter-style lower-roman { system: extends upper-roman; }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid last entry",
+			input:        "@counter-style x { symbols: a; bogus } a",
+			expected:     "@counter-style x { symbols: a; }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing symbols",
+			input:        "@counter-style x { system: numeric; symbols: a; } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "none",
+			input:        "@counter-style None { symbols: a; } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "non-overridable name",
+			input:        "@counter-style DECIMAL { symbols: a; } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "string name",
+			input:        `@counter-style "x" { symbols: a; } a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@counter-style x; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeCounterStyleRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/fontface"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeFontFaceRule consumes a @font-face rule.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-face-rule
+func (p *Parser) consumeFontFaceRule() (*css.FontFaceRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @font-face")
+	}
+
+	var rule *css.FontFaceRule
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		rule = fontface.ConsumeFontFaceDescriptors(ts, p.reportDroppedDescriptor)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
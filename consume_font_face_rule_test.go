@@ -0,0 +1,89 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumeFontFaceRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "descriptors",
+			input:        `@font-face { font-family: "Foo"; src: url(foo.woff2) format("woff2"), local(Foo); font-display: swap; }`,
+			expected:     `@font-face { font-family: "Foo"; src: url("foo.woff2") format("woff2"), local("Foo"); font-display: swap; }`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "invalid descriptors are dropped",
+			input:        "@font-face { color: red; font-weight: heavy; unicode-range: U+0-7F }",
+			expected:     "@font-face { unicode-range: U+0-7F; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "nested rules are dropped",
+			input:        "@font-face { a { color: red; } font-display: block; } a",
+			expected:     "@font-face { font-display: block; }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid last descriptor",
+			input:        "@font-face { font-family: a; bogus } b",
+			expected:     "@font-face { font-family: a; }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid last entry",
+			input:        "@font-face { font-display: swap; 12px } b",
+			expected:     "@font-face { font-display: swap; }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "non-empty prelude",
+			input:        "@font-face foo { font-display: swap; } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@font-face; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeFontFaceRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
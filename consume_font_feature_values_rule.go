@@ -0,0 +1,51 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/fontface"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeFontFeatureValuesRule consumes a @font-feature-values rule, e.g.
+// "@font-feature-values Font One { @styleset { nice-style: 12; } }".
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-feature-values
+func (p *Parser) consumeFontFeatureValuesRule() (*css.FontFeatureValuesRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	var prelude []*css.ComponentValue
+	for !p.s.AtEnd() &&
+		p.s.Peek().Type != csslexer.LeftBraceToken &&
+		p.s.Peek().Type != csslexer.SemicolonToken {
+		prelude = append(prelude, p.s.ConsumeComponentValue())
+	}
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @font-feature-values prelude")
+	}
+
+	familyNames, ok := fontface.ParseFamilyNameList(prelude)
+	if !ok {
+		p.skipAtRule()
+		return nil, errors.New("invalid font family names in @font-feature-values prelude")
+	}
+
+	var rule *css.FontFeatureValuesRule
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		rule = fontface.ConsumeFontFeatureValues(ts, familyNames, p.reportDroppedDescriptor)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
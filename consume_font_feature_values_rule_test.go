@@ -0,0 +1,83 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumeFontFeatureValuesRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "blocks",
+			input:        `@font-feature-values Font One, "Font Two" { @swash { fancy: 1; } @styleset { nice-style: 12 14; } }`,
+			expected:     `@font-feature-values "Font One", "Font Two" { @swash { fancy: 1; } @styleset { nice-style: 12 14; } }`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "invalid values are dropped",
+			input:        "@font-feature-values Foo { @swash { a: 1 2; b: 3 } @bar { c: 1 } } a",
+			expected:     `@font-feature-values "Foo" { @swash { b: 3; } }`,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid last entries",
+			input:        "@font-feature-values a { @swash { x: 1; bogus } bogus } a",
+			expected:     `@font-feature-values "a" { @swash { x: 1; } }`,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "generic family",
+			input:        "@font-feature-values serif { @swash { a: 1 } } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "empty prelude",
+			input:        "@font-feature-values { @swash { a: 1 } } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@font-feature-values Foo; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeFontFeatureValuesRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
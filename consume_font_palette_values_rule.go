@@ -0,0 +1,47 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/fontface"
+	"go.baoshuo.dev/cssparser/token_stream"
+	"go.baoshuo.dev/cssparser/variable"
+)
+
+// consumeFontPaletteValuesRule consumes a @font-palette-values rule, e.g.
+// "@font-palette-values --warm { font-family: Bixa; override-colors: 0
+// red; }".
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-palette-values
+func (p *Parser) consumeFontPaletteValuesRule() (*css.FontPaletteValuesRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	token := p.s.Peek()
+	if !variable.IsValidVariableName(token) {
+		p.skipAtRule()
+		return nil, errors.New("expected dashed-ident after @font-palette-values")
+	}
+	p.s.ConsumeIncludingWhitespace()
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @font-palette-values name")
+	}
+
+	var rule *css.FontPaletteValuesRule
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		rule = fontface.ConsumeFontPaletteValuesDescriptors(ts, token.Value, p.reportDroppedDescriptor)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
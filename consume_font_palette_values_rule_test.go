@@ -0,0 +1,77 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumeFontPaletteValuesRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "descriptors",
+			input:        "@font-palette-values --warm { font-family: Bixa; base-palette: 1; override-colors: 0 red, 1 blue; }",
+			expected:     "@font-palette-values --warm { font-family: Bixa; base-palette: 1; override-colors: 0 red, 1 blue; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "invalid descriptors are dropped",
+			input:        "@font-palette-values --warm { color: red; override-colors: 0 currentcolor; } a",
+			expected:     "@font-palette-values --warm { }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid last entry",
+			input:        "@font-palette-values --x { font-family: a; bogus } a",
+			expected:     "@font-palette-values --x { font-family: a; }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "name is not a dashed-ident",
+			input:        "@font-palette-values warm { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@font-palette-values --warm; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeFontPaletteValuesRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,200 @@
+package cssparser
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/property"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeFunctionRule consumes a @function rule, e.g. "@function
+// --double(--x <number>) returns <number> { result: calc(var(--x) * 2); }".
+//
+// The body is parsed with NestingTypeFunction: only custom properties and
+// the result descriptor are kept, and conditional group rules are the only
+// rules allowed in it.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://drafts.csswg.org/css-mixins-1/#function-rule
+func (p *Parser) consumeFunctionRule() (*css.FunctionRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	// @function <function-token> <function-parameter>#? ) [ returns <css-type> ]?
+	token := p.s.Peek()
+	if !isDashedFunctionName(token) {
+		p.skipAtRule()
+		return nil, errors.New("expected dashed function name after @function")
+	}
+
+	parameters, err := parseFunctionParameterList(p.s.ConsumeComponentValue().Children)
+	if err != nil {
+		p.skipAtRule()
+		return nil, err
+	}
+	p.s.ConsumeWhitespace()
+
+	rule := &css.FunctionRule{
+		Name:       token.Value,
+		Parameters: parameters,
+	}
+
+	if next := p.s.Peek(); next.Type == csslexer.IdentToken && strings.EqualFold(next.Value, "returns") {
+		p.s.ConsumeIncludingWhitespace()
+
+		var values []*css.ComponentValue
+		for !p.s.AtEnd() {
+			if t := p.s.Peek().Type; t == csslexer.LeftBraceToken || t == csslexer.SemicolonToken {
+				break
+			}
+			values = append(values, p.s.ConsumeComponentValue())
+		}
+
+		returnType, err := parseCSSType(values)
+		if err != nil {
+			p.skipAtRule()
+			return nil, err
+		}
+		rule.ReturnType = returnType
+	}
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @function prelude")
+	}
+
+	err = p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		declarations, rules, err := p.consumeBlockContents(nesting.NestingTypeFunction, nil)
+		if err != nil {
+			return err
+		}
+		rule.Declarations = declarations
+		rule.Rules = rules
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// isDashedFunctionName checks if a token is a function token whose name is
+// a dashed-ident, e.g. "--double(".
+func isDashedFunctionName(token csslexer.Token) bool {
+	return token.Type == csslexer.FunctionToken &&
+		len(token.Value) >= 3 && strings.HasPrefix(token.Value, "--")
+}
+
+// isFunctionBodyDescriptor checks if a declaration is allowed in the body
+// of a @function rule, i.e. it is a local custom property or the result
+// descriptor.
+//
+// https://drafts.csswg.org/css-mixins-1/#function-body
+func isFunctionBodyDescriptor(decl *css.Declaration) bool {
+	return decl.IsCustomProperty() || strings.EqualFold(decl.Property, "result")
+}
+
+// parseFunctionParameterList parses the contents of the parentheses of a
+// @function or @mixin prelude:
+//
+//	<function-parameter>#?
+//	<function-parameter> = <custom-property-name> <css-type>? [ : <default-value> ]?
+//
+// An empty list returns an empty, non-nil slice.
+//
+// https://drafts.csswg.org/css-mixins-1/#typedef-function-parameter
+func parseFunctionParameterList(values []*css.ComponentValue) ([]*css.FunctionParameter, error) {
+	parameters := []*css.FunctionParameter{}
+
+	if len(css.TrimComponentValueList(values)) == 0 {
+		return parameters, nil
+	}
+
+	for _, item := range css.SplitComponentValueList(values) {
+		if len(item) == 0 {
+			return nil, errors.New("empty function parameter")
+		}
+
+		name := item[0]
+		if name.Type != css.ComponentValueTypePreservedToken ||
+			name.Token.Type != csslexer.IdentToken ||
+			len(name.Token.Value) < 3 || !strings.HasPrefix(name.Token.Value, "--") {
+			return nil, errors.New("expected custom property name as function parameter")
+		}
+
+		for _, parameter := range parameters {
+			if parameter.Name == name.Token.Value {
+				return nil, errors.New("duplicate function parameter: " + parameter.Name)
+			}
+		}
+
+		parameter := &css.FunctionParameter{Name: name.Token.Value}
+
+		rest := item[1:]
+		typeValues := rest
+		for i, value := range rest {
+			if value.Type == css.ComponentValueTypePreservedToken && value.Token.Type == csslexer.ColonToken {
+				typeValues = rest[:i]
+
+				defaultValue := css.TrimComponentValueList(rest[i+1:])
+				if len(defaultValue) == 0 {
+					return nil, errors.New("empty default value for function parameter " + parameter.Name)
+				}
+				parameter.DefaultValue = defaultValue
+				break
+			}
+		}
+
+		if typeValues = css.TrimComponentValueList(typeValues); len(typeValues) > 0 {
+			parameterType, err := parseCSSType(typeValues)
+			if err != nil {
+				return nil, err
+			}
+			parameter.Type = parameterType
+		}
+
+		parameters = append(parameters, parameter)
+	}
+
+	return parameters, nil
+}
+
+// parseCSSType parses a <css-type>, which is either a single syntax
+// component, e.g. "<length>+", or a type() function holding any syntax
+// string, e.g. "type(<length> | auto)".
+//
+// https://drafts.csswg.org/css-mixins-1/#typedef-css-type
+func parseCSSType(values []*css.ComponentValue) (*css.PropertySyntax, error) {
+	values = css.TrimComponentValueList(values)
+	if len(values) == 0 {
+		return nil, errors.New("expected type")
+	}
+
+	if len(values) == 1 && values[0].Type == css.ComponentValueTypeFunction &&
+		strings.EqualFold(values[0].Token.Value, "type") {
+		return property.ParseSyntax(css.SerializeComponentValueList(values[0].Children))
+	}
+
+	for _, value := range values {
+		if value.IsWhitespace() {
+			return nil, errors.New("unexpected whitespace in type")
+		}
+	}
+
+	syntax, err := property.ParseSyntax(css.SerializeComponentValueList(values))
+	if err != nil {
+		return nil, err
+	}
+	if len(syntax.Components) != 1 {
+		return nil, errors.New("expected a single syntax component, use type() for other syntaxes")
+	}
+
+	return syntax, nil
+}
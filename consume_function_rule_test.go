@@ -0,0 +1,130 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumeFunctionRule(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		input                string
+		expectError          bool
+		expected             string
+		expectedParameters   int
+		expectedDeclarations int
+		expectedRules        int
+	}{
+		{
+			name:                 "parameters and return type",
+			input:                "@function --double(--x <number>) returns <number> { result: calc(var(--x) * 2); }",
+			expected:             "@function --double(--x <number>) returns <number> { result: calc(var(--x) * 2); }",
+			expectedParameters:   1,
+			expectedDeclarations: 1,
+		},
+		{
+			name:                 "no parameters",
+			input:                "@function --pi() { result: 3.14159; }",
+			expected:             "@function --pi() { result: 3.14159; }",
+			expectedDeclarations: 1,
+		},
+		{
+			name:                 "default values and type()",
+			input:                "@function --size(--a, --b <length>: 10px, --c type(<length> | auto): auto) returns type(<length>+) { result: var(--a); }",
+			expected:             "@function --size(--a, --b <length>: 10px, --c type(<length> | auto): auto) returns <length>+ { result: var(--a); }",
+			expectedParameters:   3,
+			expectedDeclarations: 1,
+		},
+		{
+			name:                 "local custom properties and conditional rules",
+			input:                "@function --m(--x) { --y: 1; result: var(--y); @media (width > 100px) { result: 2; } }",
+			expected:             "@function --m(--x) { --y: 1; result: var(--y); @media (width > 100px) { result: 2; } }",
+			expectedParameters:   1,
+			expectedDeclarations: 2,
+			expectedRules:        1,
+		},
+		{
+			name:                 "other declarations and rules are dropped",
+			input:                "@function --m() { color: red; div { color: blue; } @layer a { } @supports (display: grid) { width: 1px; --z: 2; } result: 1; }",
+			expected:             "@function --m() { result: 1; @supports (display: grid) { --z: 2; } }",
+			expectedDeclarations: 1,
+			expectedRules:        1,
+		},
+		{
+			name:        "name is not a dashed-ident",
+			input:       "@function double(--x) { result: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "missing parentheses",
+			input:       "@function --double { result: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "parameter is not a custom property name",
+			input:       "@function --double(x) { result: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "duplicate parameters",
+			input:       "@function --double(--x, --x) { result: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "invalid parameter type",
+			input:       "@function --double(--x <foo>) { result: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "multiple alternatives without type()",
+			input:       "@function --double() returns <length> | auto { result: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "missing block",
+			input:       "@function --double();",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeFunctionRule()
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				if next := parser.s.Peek(); next.Type != csslexer.EOFToken {
+					t.Errorf("expected EOF, got %v", next.Type)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if len(rule.Parameters) != tc.expectedParameters {
+				t.Errorf("expected %d parameters, got %d", tc.expectedParameters, len(rule.Parameters))
+			}
+
+			if len(rule.Declarations) != tc.expectedDeclarations {
+				t.Errorf("expected %d declarations, got %d", tc.expectedDeclarations, len(rule.Declarations))
+			}
+
+			if len(rule.Rules) != tc.expectedRules {
+				t.Errorf("expected %d rules, got %d", tc.expectedRules, len(rule.Rules))
+			}
+		})
+	}
+}
@@ -0,0 +1,149 @@
+package cssparser
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/media"
+	"go.baoshuo.dev/cssparser/supports"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeImportRule consumes an @import rule. An invalid rule is consumed
+// entirely and an error is returned.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-cascade-5/#at-import
+func (p *Parser) consumeImportRule() (*css.ImportRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	rule, err := p.consumeImportPrelude()
+	if err == nil && !p.s.AtEnd() && p.s.Peek().Type != csslexer.SemicolonToken {
+		err = errors.New("expected ';' after @import rule")
+	}
+
+	if err != nil {
+		p.skipAtRule()
+		return nil, err
+	}
+
+	if p.s.Peek().Type == csslexer.SemicolonToken {
+		p.s.Consume()
+	}
+
+	return rule, nil
+}
+
+// consumeImportPrelude consumes the prelude of an @import rule:
+//
+//	[ <url> | <string> ] [ layer | layer(<layer-name>) ]?
+//	[ supports( [ <supports-condition> | <declaration> ] ) ]?
+//	<media-query-list>?
+func (p *Parser) consumeImportPrelude() (*css.ImportRule, error) {
+	url, err := p.consumeURLOrString()
+	if err != nil {
+		return nil, err
+	}
+	p.s.ConsumeWhitespace()
+
+	rule := &css.ImportRule{
+		URL: url,
+	}
+
+	token := p.s.Peek()
+	if token.Type == csslexer.IdentToken && strings.EqualFold(token.Value, "layer") {
+		rule.Layer = true
+		p.s.ConsumeIncludingWhitespace()
+	} else if token.Type == csslexer.FunctionToken && strings.EqualFold(token.Value, "layer") {
+		err := p.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+			ts.ConsumeWhitespace()
+
+			name, err := p.consumeLayerName()
+			if err != nil {
+				return err
+			}
+
+			ts.ConsumeWhitespace()
+			if !ts.AtEnd() {
+				return errors.New("unexpected tokens after layer name")
+			}
+
+			rule.Layer = true
+			rule.LayerName = name
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.s.ConsumeWhitespace()
+	}
+
+	token = p.s.Peek()
+	if token.Type == csslexer.FunctionToken && strings.EqualFold(token.Value, "supports") {
+		err := p.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+			condition, err := supports.ConsumeImportSupportsCondition(ts)
+			if err != nil {
+				return err
+			}
+
+			rule.Supports = condition
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		p.s.ConsumeWhitespace()
+	}
+
+	rule.Media = media.ConsumeMediaQueryList(p.s)
+
+	return rule, nil
+}
+
+// consumeURLOrString consumes a <url> or a <string> and returns the URL.
+//
+// https://www.w3.org/TR/css-values-4/#urls
+func (p *Parser) consumeURLOrString() (string, error) {
+	token := p.s.Peek()
+
+	switch token.Type {
+	case csslexer.UrlToken, csslexer.StringToken:
+		p.s.Consume()
+		return token.Value, nil
+
+	case csslexer.FunctionToken:
+		if !strings.EqualFold(token.Value, "url") {
+			break
+		}
+
+		// url() with a quoted string is a function token, not an url token
+		var url string
+		err := p.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+			ts.ConsumeWhitespace()
+
+			token := ts.Peek()
+			if token.Type != csslexer.StringToken {
+				return errors.New("expected string in url()")
+			}
+			ts.ConsumeIncludingWhitespace()
+
+			if !ts.AtEnd() {
+				return errors.New("unexpected tokens in url()")
+			}
+
+			url = token.Value
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		return url, nil
+	}
+
+	return "", errors.New("expected url or string")
+}
@@ -0,0 +1,192 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumeImportRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "url token",
+			input:        "@import url(foo.css); a",
+			expected:     `@import url("foo.css");`,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "url function with string",
+			input:        `@import url( "foo.css" );`,
+			expected:     `@import url("foo.css");`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "string",
+			input:        `@import 'foo.css';`,
+			expected:     `@import url("foo.css");`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "media query list",
+			input:        `@import "foo.css" screen and (min-width: 100px), print;`,
+			expected:     `@import url("foo.css") screen and (min-width: 100px), print;`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "anonymous layer",
+			input:        `@import "foo.css" LAYER;`,
+			expected:     `@import url("foo.css") layer;`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "named layer",
+			input:        `@import "foo.css" layer(framework.base);`,
+			expected:     `@import url("foo.css") layer(framework.base);`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "supports declaration",
+			input:        `@import "foo.css" supports(display: grid);`,
+			expected:     `@import url("foo.css") supports(display: grid);`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "supports condition",
+			input:        `@import "foo.css" supports((display: grid) and selector(a > b));`,
+			expected:     `@import url("foo.css") supports((display: grid) and selector(a > b));`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "all modifiers",
+			input:        `@import url(foo.css) layer(base) supports(not (display: grid)) screen;`,
+			expected:     `@import url("foo.css") layer(base) supports(not (display: grid)) screen;`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "ended by EOF",
+			input:        `@import "foo.css" print`,
+			expected:     `@import url("foo.css") print;`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "missing url",
+			input:        "@import screen; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "layer name with whitespace",
+			input:        `@import "foo.css" layer(framework. base); a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "CSS-wide keyword as layer name",
+			input:        `@import "foo.css" layer(initial); a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid supports condition",
+			input:        `@import "foo.css" supports(display grid); a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "block",
+			input:        `@import "foo.css" { a { } } a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeImportRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestParser_ImportRuleOrder(t *testing.T) {
+	testcases := []struct {
+		name             string
+		input            string
+		expectDiagnostic bool
+		expectedRules    int
+	}{
+		{
+			name:          "imports first",
+			input:         `@import "a.css"; @import "b.css"; div { color: red; }`,
+			expectedRules: 3,
+		},
+		{
+			name:          "after @charset and @layer statements",
+			input:         `@charset "utf-8"; @layer base, theme; @import "a.css" layer(base); div { color: red; }`,
+			expectedRules: 4,
+		},
+		{
+			name:             "after a style rule",
+			input:            `div { color: red; } @import "a.css";`,
+			expectDiagnostic: true,
+			expectedRules:    1,
+		},
+		{
+			name:             "after a @layer block",
+			input:            `@layer base { } @import "a.css";`,
+			expectDiagnostic: true,
+			expectedRules:    1,
+		},
+		{
+			name:             "inside @media",
+			input:            `@media screen { @import "a.css"; }`,
+			expectDiagnostic: true,
+			expectedRules:    1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rules := parser.ParseStylesheet()
+
+			if diagnostics := parser.Diagnostics(); (len(diagnostics) > 0) != tc.expectDiagnostic {
+				t.Errorf("expected diagnostic %v, got %v", tc.expectDiagnostic, diagnostics)
+			}
+
+			if len(rules) != tc.expectedRules {
+				t.Errorf("expected %d rules, got %d", tc.expectedRules, len(rules))
+			}
+		})
+	}
+}
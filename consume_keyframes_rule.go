@@ -0,0 +1,190 @@
+package cssparser
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/numeric"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeKeyframesRule consumes a @keyframes rule, or a @-webkit-keyframes
+// rule if prefixed is true.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-animations-1/#keyframes
+func (p *Parser) consumeKeyframesRule(prefixed bool) (*css.KeyframesRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	rule := &css.KeyframesRule{
+		Prefixed: prefixed,
+	}
+
+	// <keyframes-name> = <custom-ident> | <string>
+	token := p.s.Peek()
+	switch {
+	case token.Type == csslexer.IdentToken && css.IsValidKeyframesName(token.Value):
+		rule.Name = token.Value
+	case token.Type == csslexer.StringToken:
+		rule.Name = token.Value
+	default:
+		p.skipAtRule()
+		return nil, errors.New("invalid @keyframes name")
+	}
+	p.s.ConsumeIncludingWhitespace()
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @keyframes name")
+	}
+
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		keyframes := p.consumeRuleList(qualifiedRuleTypeKeyframes, false, nesting.NestingTypeNone, nil)
+		for _, keyframe := range keyframes {
+			rule.Keyframes = append(rule.Keyframes, keyframe.(*css.StyleRule))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// consumeKeyframeStyleRule consumes a keyframe rule inside a @keyframes
+// rule. Declarations with !important and at-rules are ignored.
+//
+// https://www.w3.org/TR/css-animations-1/#keyframes
+func (p *Parser) consumeKeyframeStyleRule() (*css.StyleRule, error) {
+	selectors, err := p.consumeKeyframeSelectorList()
+	if err != nil {
+		// Drop the whole keyframe rule, including its block
+		p.s.SkipUntil(csslexer.LeftBraceToken)
+		if p.s.Peek().Type == csslexer.LeftBraceToken {
+			_ = p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error { return nil })
+		}
+		return nil, err
+	}
+
+	rule := &css.StyleRule{
+		Type:              css.StyleRuleTypeKeyframe,
+		KeyframeSelectors: selectors,
+	}
+
+	err = p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		defer p.sortDiagnostics(len(p.diagnostics))
+
+		declarations, rules, err := p.consumeBlockContents(nesting.NestingTypeNone, nil)
+		if err != nil {
+			return err
+		}
+		p.reportDroppedRules(rules, errors.New("rule not allowed in keyframe"))
+
+		for _, decl := range declarations {
+			if decl.Important {
+				p.reportDroppedDeclaration(decl, errors.New("!important not allowed in keyframe"))
+				continue
+			}
+			rule.Declarations = append(rule.Declarations, decl)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// consumeKeyframeSelectorList consumes a comma-separated list of keyframe
+// selectors, stopping before the '{' of the keyframe block.
+//
+// https://drafts.csswg.org/css-animations-2/#typedef-keyframe-selector
+func (p *Parser) consumeKeyframeSelectorList() ([]*css.KeyframeSelector, error) {
+	var selectors []*css.KeyframeSelector
+
+	for {
+		p.s.ConsumeWhitespace()
+
+		selector, err := p.consumeKeyframeSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+
+		p.s.ConsumeWhitespace()
+
+		switch p.s.Peek().Type {
+		case csslexer.CommaToken:
+			p.s.Consume()
+		case csslexer.LeftBraceToken:
+			return selectors, nil
+		default:
+			return nil, errors.New("expected ',' or '{' after keyframe selector")
+		}
+	}
+}
+
+// consumeKeyframeSelector consumes a single keyframe selector:
+//
+//	from | to | <percentage [0,100]> | <timeline-range-name> <percentage>
+func (p *Parser) consumeKeyframeSelector() (*css.KeyframeSelector, error) {
+	selector := &css.KeyframeSelector{}
+	start := p.s.PeekSpan().Start
+
+	token := p.s.Peek()
+	if token.Type == csslexer.IdentToken {
+		name := strings.ToLower(token.Value)
+		p.s.ConsumeIncludingWhitespace()
+
+		switch {
+		case name == "from":
+			selector.SetSpan(css.Span{Start: start, End: p.s.PositionBeforeWhitespace()})
+			return selector, nil
+		case name == "to":
+			selector.Percentage = 100
+			selector.SetSpan(css.Span{Start: start, End: p.s.PositionBeforeWhitespace()})
+			return selector, nil
+		case isTimelineRangeName(name):
+			selector.RangeName = name
+		default:
+			return nil, errors.New("invalid keyframe selector")
+		}
+
+		token = p.s.Peek()
+	}
+
+	percentage, ok := numeric.ParsePercentage(token)
+	if !ok {
+		return nil, errors.New("expected percentage in keyframe selector")
+	}
+	if selector.RangeName == "" && (percentage < 0 || percentage > 100) {
+		return nil, errors.New("keyframe selector out of range")
+	}
+	p.s.Consume()
+
+	selector.Percentage = percentage
+	selector.SetSpan(css.Span{Start: start, End: p.s.Position()})
+
+	return selector, nil
+}
+
+// isTimelineRangeName checks if a lowercased identifier is a named timeline
+// range.
+//
+// https://drafts.csswg.org/scroll-animations-1/#named-ranges
+func isTimelineRangeName(name string) bool {
+	switch name {
+	case "cover", "contain", "entry", "exit", "entry-crossing", "exit-crossing":
+		return true
+	default:
+		return false
+	}
+}
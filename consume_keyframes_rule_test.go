@@ -0,0 +1,217 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+func TestParser_ConsumeKeyframesRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "percentages",
+			input:        "@keyframes fade { 0% { opacity: 0; } 50.5% { opacity: 0.5; } 100% { opacity: 1; } }",
+			expected:     "@keyframes fade { 0% { opacity: 0; } 50.5% { opacity: 0.5; } 100% { opacity: 1; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "from and to",
+			input:        "@keyframes fade { FROM { opacity: 0; } to { opacity: 1; } }",
+			expected:     "@keyframes fade { 0% { opacity: 0; } 100% { opacity: 1; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "selector list",
+			input:        "@keyframes pulse { from, 50%, to { opacity: 1; } 25%,75% { opacity: 0; } }",
+			expected:     "@keyframes pulse { 0%, 50%, 100% { opacity: 1; } 25%, 75% { opacity: 0; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "timeline ranges",
+			input:        "@keyframes reveal { entry 0% { opacity: 0; } ENTRY 20% { opacity: 1; } exit-crossing 100% { opacity: 0; } }",
+			expected:     "@keyframes reveal { entry 0% { opacity: 0; } entry 20% { opacity: 1; } exit-crossing 100% { opacity: 0; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "important declarations are dropped",
+			input:        "@keyframes fade { from { opacity: 0 !important; color: red; } }",
+			expected:     "@keyframes fade { 0% { color: red; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "webkit prefix",
+			input:        "@-webkit-keyframes fade { to { opacity: 1; } }",
+			expected:     "@-webkit-keyframes fade { 100% { opacity: 1; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "string name",
+			input:        `@keyframes "my animation" { }`,
+			expected:     `@keyframes my\ animation { }`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "reserved name as string",
+			input:        `@keyframes "none" { }`,
+			expected:     `@keyframes "none" { }`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "string name which is a valid identifier",
+			input:        `@keyframes "fade" { }`,
+			expected:     `@keyframes fade { }`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "reserved name",
+			input:        "@keyframes none { from { opacity: 0; } } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "CSS-wide keyword as name",
+			input:        "@keyframes inherit { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "default as name",
+			input:        "@keyframes DEFAULT { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing name",
+			input:        "@keyframes { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@keyframes fade; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "percentage out of range",
+			input:        "@keyframes fade { 120% { opacity: 0; } } a",
+			expected:     "@keyframes fade { }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "nested at-rule",
+			input:        "@keyframes fade { @media print { } } a",
+			expected:     "@keyframes fade { }",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeKeyframesRule(tc.input[1] == '-')
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestParser_ConsumeKeyframeStyleRule(t *testing.T) {
+	testcases := []struct {
+		name        string
+		input       string
+		expectError bool
+		expected    *css.StyleRule
+	}{
+		{
+			name:  "timeline range",
+			input: "entry 20%, to { opacity: 1; }",
+			expected: &css.StyleRule{
+				Type: css.StyleRuleTypeKeyframe,
+				KeyframeSelectors: []*css.KeyframeSelector{
+					{RangeName: "entry", Percentage: 20},
+					{Percentage: 100},
+				},
+				Declarations: []*css.Declaration{
+					{Property: "opacity", Value: "1"},
+				},
+			},
+		},
+		{
+			name:        "unknown range name",
+			input:       "foo 20% { opacity: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "range name without percentage",
+			input:       "entry { opacity: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "number instead of percentage",
+			input:       "50 { opacity: 1; }",
+			expectError: true,
+		},
+		{
+			name:        "trailing comma",
+			input:       "from, { opacity: 1; }",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeKeyframeStyleRule()
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				if next := parser.s.Peek(); next.Type != csslexer.EOFToken {
+					t.Errorf("expected the whole rule to be consumed, got %v", next.Type)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if !rule.Equals(tc.expected) {
+				t.Errorf("rule mismatch:\nexpected: %+v\ngot: %+v", tc.expected, rule)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+// consumeLayerRule consumes a @layer rule, which is either a statement
+// declaring one or more layers, e.g. "@layer a, b.c;", or a block holding
+// the rules of a layer, e.g. "@layer a { ... }". The name of a block may
+// be omitted, which creates an anonymous layer.
+//
+// If nested is true, the rule is inside a style rule and its block is
+// parsed like the contents of a style rule.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-cascade-5/#layering
+func (p *Parser) consumeLayerRule(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	nested bool,
+) (css.AtRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	var names []css.LayerName
+	for p.s.Peek().Type != csslexer.LeftBraceToken {
+		name, err := p.consumeLayerName()
+		if err != nil {
+			p.skipAtRule()
+			return nil, err
+		}
+		names = append(names, name)
+		p.s.ConsumeWhitespace()
+
+		if p.s.Peek().Type != csslexer.CommaToken {
+			break
+		}
+		p.s.ConsumeIncludingWhitespace()
+	}
+
+	switch token := p.s.Peek(); {
+	case token.Type == csslexer.LeftBraceToken:
+		if len(names) > 1 {
+			p.skipAtRule()
+			return nil, errors.New("@layer block can't have more than one name")
+		}
+
+		rule := &css.LayerBlockRule{}
+		if len(names) == 1 {
+			rule.Name = names[0]
+		}
+
+		declarations, rules, err := p.consumeGroupRuleBlock(nestingType, parentRuleForNesting, nested)
+		if err != nil {
+			return nil, err
+		}
+		rule.Declarations = declarations
+		rule.Rules = rules
+
+		return rule, nil
+
+	case token.Type == csslexer.SemicolonToken || p.s.AtEnd():
+		if token.Type == csslexer.SemicolonToken {
+			p.s.Consume()
+		}
+		return &css.LayerStatementRule{Names: names}, nil
+
+	default:
+		p.skipAtRule()
+		return nil, errors.New("unexpected tokens in @layer prelude")
+	}
+}
+
+// consumeLayerName consumes a dotted cascade layer name, e.g.
+// "framework.base". No whitespace is allowed around the dots, and the
+// CSS-wide keywords are not allowed as identifiers.
+//
+// https://www.w3.org/TR/css-cascade-5/#typedef-layer-name
+func (p *Parser) consumeLayerName() (css.LayerName, error) {
+	var name css.LayerName
+
+	for {
+		token := p.s.Peek()
+		if token.Type != csslexer.IdentToken {
+			return nil, errors.New("expected identifier in layer name")
+		}
+		if css.IsCSSWideKeyword(token.Value) {
+			return nil, errors.New("CSS-wide keyword in layer name")
+		}
+		p.s.Consume()
+		name = append(name, token.Value)
+
+		next := p.s.Peek()
+		if next.Type != csslexer.DelimiterToken || next.Value != "." {
+			return name, nil
+		}
+		p.s.Consume()
+	}
+}
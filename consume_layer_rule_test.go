@@ -0,0 +1,136 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+func TestParser_ConsumeLayerRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "statement",
+			input:        "@layer base;",
+			expected:     "@layer base;",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "statement with multiple names",
+			input:        "@layer reset , framework.base,theme ; a",
+			expected:     "@layer reset, framework.base, theme;",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "statement ended by EOF",
+			input:        "@layer base",
+			expected:     "@layer base;",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "block",
+			input:        "@layer framework.base { div { color: red; } }",
+			expected:     "@layer framework.base { div { color: red; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "anonymous block",
+			input:        "@layer { @layer a { } }",
+			expected:     "@layer { @layer a { } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "block with multiple names",
+			input:        "@layer a, b { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "statement without name",
+			input:        "@layer; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "whitespace around dot",
+			input:        "@layer a . b; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "CSS-wide keyword",
+			input:        "@layer initial { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "trailing comma",
+			input:        "@layer a, ; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeLayerRule(nesting.NestingTypeNone, nil, false)
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestParser_LayerOrder(t *testing.T) {
+	input := csslexer.NewInput(`
+		@import "framework.css" layer(framework);
+		@layer framework, app;
+		@layer app.components { }
+		@media print { @layer print { } }
+		div { @layer app.nested { color: red; } }
+		@layer framework.base { }
+	`)
+	parser := NewParser(input)
+
+	rules := parser.ParseStylesheet()
+
+	expected := []string{"framework.base", "framework", "app.components", "app.nested", "app", "print"}
+
+	order := css.LayerOrder(rules)
+	if len(order) != len(expected) {
+		t.Fatalf("expected %d layers, got %d", len(expected), len(order))
+	}
+	for i, name := range order {
+		if name.String() != expected[i] {
+			t.Errorf("expected layer %d to be %q, got %q", i, expected[i], name.String())
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/media"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+// consumeMediaRule consumes a @media rule.
+//
+// If nested is true, the rule is inside a style rule and its block is
+// parsed like the contents of a style rule.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-conditional-3/#at-media
+func (p *Parser) consumeMediaRule(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	nested bool,
+) (*css.MediaRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	rule := &css.MediaRule{
+		Queries: media.ConsumeMediaQueryList(p.s),
+	}
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		if p.s.Peek().Type == csslexer.SemicolonToken {
+			p.s.Consume()
+		}
+		return nil, errors.New("expected '{' after media query list")
+	}
+
+	declarations, rules, err := p.consumeGroupRuleBlock(nestingType, parentRuleForNesting, nested)
+	if err != nil {
+		return nil, err
+	}
+	rule.Declarations = declarations
+	rule.Rules = rules
+
+	return rule, nil
+}
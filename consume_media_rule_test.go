@@ -0,0 +1,99 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+func TestParser_ConsumeMediaRule(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		input                string
+		nestingType          nesting.NestingTypeType
+		nested               bool
+		expectError          bool
+		expected             string
+		expectedDeclarations int
+		expectedRules        int
+	}{
+		{
+			name:          "top-level rule",
+			input:         "@media screen and (min-width: 100px) { div { color: red; } .a { margin: 0; } }",
+			expected:      "@media screen and (min-width: 100px) { div { color: red; } .a { margin: 0; } }",
+			expectedRules: 2,
+		},
+		{
+			name:     "empty query list",
+			input:    "@media { }",
+			expected: "@media { }",
+		},
+		{
+			name:          "invalid query",
+			input:         "@media (color) or screen { div { color: red; } }",
+			expected:      "@media not all { div { color: red; } }",
+			expectedRules: 1,
+		},
+		{
+			name:          "nested @media",
+			input:         "@media print { div { color: red; } }",
+			expected:      "@media print { div { color: red; } }",
+			expectedRules: 1,
+		},
+		{
+			name:                 "nested declarations",
+			input:                "@media (hover) { color: red; div { margin: 0; } }",
+			nestingType:          nesting.NestingTypeNesting,
+			nested:               true,
+			expected:             "@media (hover) { color: red; div { margin: 0; } }",
+			expectedDeclarations: 1,
+			expectedRules:        1,
+		},
+		{
+			name:        "missing block",
+			input:       "@media screen;",
+			expectError: true,
+		},
+		{
+			name:          "block closed by EOF",
+			input:         "@media screen { div { color: red; }",
+			expected:      "@media screen { div { color: red; } }",
+			expectedRules: 1,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeMediaRule(tc.nestingType, nil, tc.nested)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if len(rule.Declarations) != tc.expectedDeclarations {
+				t.Errorf("expected %d declarations, got %d", tc.expectedDeclarations, len(rule.Declarations))
+			}
+
+			if len(rule.Rules) != tc.expectedRules {
+				t.Errorf("expected %d rules, got %d", tc.expectedRules, len(rule.Rules))
+			}
+		})
+	}
+}
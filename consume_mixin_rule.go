@@ -0,0 +1,112 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/token_stream"
+	"go.baoshuo.dev/cssparser/variable"
+)
+
+// consumeMixinRule consumes a @mixin rule, e.g. "@mixin --gap(--size) {
+// gap: var(--size); }". The body is parsed like the contents of a style
+// rule.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://drafts.csswg.org/css-mixins-1/#mixin-rule
+func (p *Parser) consumeMixinRule() (*css.MixinRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	rule := &css.MixinRule{}
+
+	// @mixin [ <dashed-ident> | <function-token> <mixin-parameter>#? ) ]
+	switch token := p.s.Peek(); {
+	case variable.IsValidVariableName(token):
+		rule.Name = token.Value
+		p.s.ConsumeIncludingWhitespace()
+
+	case isDashedFunctionName(token):
+		parameters, err := parseFunctionParameterList(p.s.ConsumeComponentValue().Children)
+		if err != nil {
+			p.skipAtRule()
+			return nil, err
+		}
+		rule.Name = token.Value
+		rule.Parameters = parameters
+		p.s.ConsumeWhitespace()
+
+	default:
+		p.skipAtRule()
+		return nil, errors.New("expected dashed-ident after @mixin")
+	}
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @mixin prelude")
+	}
+
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		declarations, rules, err := p.consumeBlockContents(nesting.NestingTypeNesting, nil)
+		if err != nil {
+			return err
+		}
+		rule.Declarations = declarations
+		rule.Rules = rules
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// consumeApplyRule consumes an @apply rule, e.g. "@apply --gap(1em);",
+// which is only valid inside a style rule or a mixin.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://drafts.csswg.org/css-mixins-1/#apply-rule
+func (p *Parser) consumeApplyRule() (*css.ApplyRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	rule := &css.ApplyRule{}
+
+	// @apply [ <dashed-ident> | <dashed-function> ] ;
+	switch token := p.s.Peek(); {
+	case variable.IsValidVariableName(token):
+		rule.Name = token.Value
+		p.s.ConsumeIncludingWhitespace()
+
+	case isDashedFunctionName(token):
+		rule.Name = token.Value
+		rule.Arguments = [][]*css.ComponentValue{}
+
+		arguments := p.s.ConsumeComponentValue().Children
+		if len(css.TrimComponentValueList(arguments)) > 0 {
+			rule.Arguments = css.SplitComponentValueList(arguments)
+		}
+		p.s.ConsumeWhitespace()
+
+	default:
+		p.skipAtRule()
+		return nil, errors.New("expected dashed-ident after @apply")
+	}
+
+	if !p.s.AtEnd() && p.s.Peek().Type != csslexer.SemicolonToken {
+		p.skipAtRule()
+		return nil, errors.New("expected ';' after @apply rule")
+	}
+
+	if p.s.Peek().Type == csslexer.SemicolonToken {
+		p.s.Consume()
+	}
+
+	return rule, nil
+}
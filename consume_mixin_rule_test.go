@@ -0,0 +1,193 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+func TestParser_ConsumeMixinRule(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		input                string
+		expectError          bool
+		expected             string
+		expectedParameters   bool
+		expectedDeclarations int
+		expectedRules        int
+	}{
+		{
+			name:                 "without parameters",
+			input:                "@mixin --center { display: grid; place-items: center; }",
+			expected:             "@mixin --center { display: grid; place-items: center; }",
+			expectedDeclarations: 2,
+		},
+		{
+			name:                 "with parameters",
+			input:                "@mixin --gap(--size <length>: 1em) { gap: var(--size); }",
+			expected:             "@mixin --gap(--size <length>: 1em) { gap: var(--size); }",
+			expectedParameters:   true,
+			expectedDeclarations: 1,
+		},
+		{
+			name:                 "nested rules and @apply",
+			input:                "@mixin --m() { color: red; div { color: blue; } @apply --other; }",
+			expected:             "@mixin --m() { color: red; div { color: blue; } @apply --other; }",
+			expectedParameters:   true,
+			expectedDeclarations: 1,
+			expectedRules:        2,
+		},
+		{
+			name:        "name is not a dashed-ident",
+			input:       "@mixin center { display: grid; }",
+			expectError: true,
+		},
+		{
+			name:        "missing block",
+			input:       "@mixin --center;",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeMixinRule()
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				if next := parser.s.Peek(); next.Type != csslexer.EOFToken {
+					t.Errorf("expected EOF, got %v", next.Type)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if (rule.Parameters != nil) != tc.expectedParameters {
+				t.Errorf("expected parameters %v, got %v", tc.expectedParameters, rule.Parameters != nil)
+			}
+
+			if len(rule.Declarations) != tc.expectedDeclarations {
+				t.Errorf("expected %d declarations, got %d", tc.expectedDeclarations, len(rule.Declarations))
+			}
+
+			if len(rule.Rules) != tc.expectedRules {
+				t.Errorf("expected %d rules, got %d", tc.expectedRules, len(rule.Rules))
+			}
+		})
+	}
+}
+
+func TestParser_ConsumeApplyRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "without arguments",
+			input:        "@apply --center; a",
+			expected:     "@apply --center;",
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "with arguments",
+			input:        "@apply --gap(1em, calc(1px + 2px));",
+			expected:     "@apply --gap(1em, calc(1px + 2px));",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "empty arguments",
+			input:        "@apply --gap();",
+			expected:     "@apply --gap();",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "without semicolon at the end",
+			input:        "@apply --center",
+			expected:     "@apply --center;",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "name is not a dashed-ident",
+			input:        "@apply center; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "trailing tokens",
+			input:        "@apply --center foo; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeApplyRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestParser_ApplyRuleInStyleRule(t *testing.T) {
+	parser := NewParser(csslexer.NewInput("color: red; @apply --center; width: 0"))
+
+	declarations, rules, err := parser.consumeBlockContents(nesting.NestingTypeNesting, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(declarations) != 1 {
+		t.Errorf("expected 1 declaration, got %d", len(declarations))
+	}
+
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+	if _, ok := rules[0].(*css.ApplyRule); !ok {
+		t.Errorf("expected *css.ApplyRule, got %T", rules[0])
+	}
+	if _, ok := rules[1].(*css.NestedDeclarationsRule); !ok {
+		t.Errorf("expected the declaration after @apply to be *css.NestedDeclarationsRule, got %T", rules[1])
+	}
+}
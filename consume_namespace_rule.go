@@ -0,0 +1,53 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// consumeNamespaceRule consumes a @namespace rule and declares its prefix,
+// so that it can be used by the selectors which follow. An invalid rule is
+// consumed entirely and an error is returned.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-namespaces-3/#syntax
+func (p *Parser) consumeNamespaceRule() (*css.NamespaceRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	rule := &css.NamespaceRule{}
+
+	// @namespace <namespace-prefix>? [ <string> | <url> ] ;
+	if token := p.s.Peek(); token.Type == csslexer.IdentToken {
+		rule.Prefix = token.Value
+		p.s.ConsumeIncludingWhitespace()
+	}
+
+	uri, err := p.consumeURLOrString()
+	if err == nil {
+		p.s.ConsumeWhitespace()
+		if !p.s.AtEnd() && p.s.Peek().Type != csslexer.SemicolonToken {
+			err = errors.New("expected ';' after @namespace rule")
+		}
+	}
+
+	if err != nil {
+		p.skipAtRule()
+		return nil, err
+	}
+
+	if p.s.Peek().Type == csslexer.SemicolonToken {
+		p.s.Consume()
+	}
+
+	rule.URI = uri
+
+	// A later declaration of the same prefix replaces the earlier one.
+	p.namespaces[rule.Prefix] = rule.URI
+
+	return rule, nil
+}
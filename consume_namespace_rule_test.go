@@ -0,0 +1,214 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+func TestParser_ConsumeNamespaceRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     *css.NamespaceRule
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "default namespace with url",
+			input:        "@namespace url(http://www.w3.org/1999/xhtml);",
+			expected:     &css.NamespaceRule{URI: "http://www.w3.org/1999/xhtml"},
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "prefix with string",
+			input:        `@namespace svg "http://www.w3.org/2000/svg"; a`,
+			expected:     &css.NamespaceRule{Prefix: "svg", URI: "http://www.w3.org/2000/svg"},
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "prefix with quoted url",
+			input:        `@namespace svg url( "http://www.w3.org/2000/svg" ) ;`,
+			expected:     &css.NamespaceRule{Prefix: "svg", URI: "http://www.w3.org/2000/svg"},
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "ended by EOF",
+			input:        `@namespace "urn:x"`,
+			expected:     &css.NamespaceRule{URI: "urn:x"},
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "missing URI",
+			input:        "@namespace svg; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "extra tokens",
+			input:        `@namespace svg "urn:x" "urn:y"; a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "block",
+			input:        `@namespace svg "urn:x" { } a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeNamespaceRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				if len(parser.namespaces) != 0 {
+					t.Errorf("expected no declared namespace, got %v", parser.namespaces)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if !rule.Equals(tc.expected) {
+				t.Errorf("expected %v, got %v", tc.expected, rule)
+			}
+
+			if uri, ok := parser.namespaces[tc.expected.Prefix]; !ok || uri != tc.expected.URI {
+				t.Errorf("expected prefix %q to be declared as %q, got %q", tc.expected.Prefix, tc.expected.URI, uri)
+			}
+		})
+	}
+}
+
+func TestParser_ParseStylesheet_Namespaces(t *testing.T) {
+	testcases := []struct {
+		name             string
+		input            string
+		expectDiagnostic bool
+		expected         string
+	}{
+		{
+			name:     "declared prefix",
+			input:    `@namespace svg url(http://www.w3.org/2000/svg); svg|rect { fill: red; }`,
+			expected: `@namespace svg url("http://www.w3.org/2000/svg"); svg|rect { fill: red; }`,
+		},
+		{
+			name:             "undeclared prefix",
+			input:            `@namespace svg url(http://www.w3.org/2000/svg); math|mi { color: red; }`,
+			expectDiagnostic: true,
+			expected:         `@namespace svg url("http://www.w3.org/2000/svg");`,
+		},
+		{
+			name:             "prefix declared later",
+			input:            `svg|rect { fill: red; } @namespace svg url(http://www.w3.org/2000/svg);`,
+			expectDiagnostic: true,
+			expected:         `@namespace svg url("http://www.w3.org/2000/svg");`,
+		},
+		{
+			name:             "undeclared prefix in attribute",
+			input:            `@namespace svg url(http://www.w3.org/2000/svg); [xlink|href] { color: red; }`,
+			expectDiagnostic: true,
+			expected:         `@namespace svg url("http://www.w3.org/2000/svg");`,
+		},
+		{
+			name:     "any and no namespace",
+			input:    `*|div, |div, *|* { color: red; }`,
+			expected: `*|div, |div, *|* { color: red; }`,
+		},
+		{
+			name:     "after @charset and @import",
+			input:    `@charset "utf-8"; @import "a.css"; @namespace "urn:x"; div { }`,
+			expected: `@charset "utf-8"; @import url("a.css"); @namespace url("urn:x"); div { }`,
+		},
+		{
+			name:             "before @import",
+			input:            `@namespace "urn:x"; @import "a.css";`,
+			expectDiagnostic: true,
+			expected:         `@namespace url("urn:x");`,
+		},
+		{
+			name:             "inside @media",
+			input:            `@media screen { @namespace "urn:x"; }`,
+			expectDiagnostic: true,
+			expected:         `@media screen { }`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rules := parser.ParseStylesheet()
+
+			if diagnostics := parser.Diagnostics(); (len(diagnostics) > 0) != tc.expectDiagnostic {
+				t.Errorf("expected diagnostic %v, got %v", tc.expectDiagnostic, diagnostics)
+			}
+
+			result := ""
+			for i, rule := range rules {
+				if i > 0 {
+					result += " "
+				}
+				result += rule.String()
+			}
+
+			if result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestParser_ParseStylesheet_DefaultNamespace(t *testing.T) {
+	input := csslexer.NewInput(`@namespace url(http://www.w3.org/1999/xhtml); @namespace svg url(http://www.w3.org/2000/svg); div[title], .note { }`)
+	parser := NewParser(input)
+
+	rules := parser.ParseStylesheet()
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(rules))
+	}
+
+	selectors := rules[2].(*css.StyleRule).Selectors
+	if len(selectors) != 2 {
+		t.Fatalf("expected 2 selectors, got %d", len(selectors))
+	}
+
+	// The default namespace applies to type selectors...
+	tag, ok := selectors[0].Selectors[0].Data.(*css.SelectorDataTag)
+	if !ok || tag.TagName != "div" || tag.NamespaceURI != "http://www.w3.org/1999/xhtml" {
+		t.Errorf("expected div in the default namespace, got %+v", selectors[0].Selectors[0].Data)
+	}
+
+	// ...but not to attribute names...
+	attr, ok := selectors[0].Selectors[1].Data.(*css.SelectorDataAttr)
+	if !ok || attr.AttrName != "title" || attr.AttrNamespaceURI != "" {
+		t.Errorf("expected title attribute in no namespace, got %+v", selectors[0].Selectors[1].Data)
+	}
+
+	// ...and an implicit universal selector is added when it is omitted.
+	if selectors[1].Selectors[0].Match != css.SelectorMatchUniversalTag {
+		t.Fatalf("expected implicit universal selector, got %v", selectors[1].Selectors[0].Match)
+	}
+	universal := selectors[1].Selectors[0].Data.(*css.SelectorDataTag)
+	if universal.NamespaceURI != "http://www.w3.org/1999/xhtml" {
+		t.Errorf("expected universal selector in the default namespace, got %q", universal.NamespaceURI)
+	}
+}
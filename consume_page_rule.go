@@ -0,0 +1,143 @@
+package cssparser
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/selector"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumePageRule consumes a @page rule. A rule with an invalid page
+// selector list is consumed entirely and an error is returned.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-page-3/#at-page-rule
+func (p *Parser) consumePageRule() (*css.PageRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	selectors, err := selector.ConsumePageSelectorList(p.s)
+	if err == nil && p.s.Peek().Type != csslexer.LeftBraceToken {
+		err = errors.New("expected '{' after page selector list")
+	}
+
+	if err != nil {
+		// Drop the whole rule, including its block
+		p.skipAtRule()
+		return nil, err
+	}
+
+	rule := &css.PageRule{
+		Selectors: selectors,
+	}
+
+	err = p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		return p.consumePageRuleContents(rule)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// consumePageRuleContents consumes the descriptors and the margin rules of
+// a @page rule. Invalid declarations and other at-rules are dropped and
+// reported as diagnostics.
+//
+// https://www.w3.org/TR/css-page-3/#page-properties
+func (p *Parser) consumePageRuleContents(rule *css.PageRule) error {
+	for {
+		p.s.ConsumeWhitespace()
+
+		if p.s.AtEnd() {
+			return nil
+		}
+
+		token := p.s.Peek()
+		if token.Type == csslexer.SemicolonToken {
+			p.s.Consume()
+			continue
+		}
+
+		start := p.s.PeekSpan().Start
+		mark := p.s.StartRecording()
+
+		switch token.Type {
+		case csslexer.AtKeywordToken:
+			name := strings.ToLower(token.Value)
+			if !css.IsPageMarginBoxName(name) {
+				p.skipAtRule()
+				p.reportDiagnostic(DiagnosticKindInvalidRule, errors.New("@"+name+" rule not allowed in @page"), start, p.s.StopRecording(mark))
+				continue
+			}
+
+			marginRule, err := p.consumePageMarginRule()
+			if err != nil {
+				p.reportDiagnostic(DiagnosticKindInvalidRule, err, start, p.s.StopRecording(mark))
+				continue
+			}
+			p.setSpan(marginRule, start)
+			rule.MarginRules = append(rule.MarginRules, marginRule)
+
+		case csslexer.IdentToken:
+			decl, err := p.consumeDeclaration()
+			if err != nil {
+				p.skipToNextDeclarationOrRule()
+				p.reportDiagnostic(DiagnosticKindInvalidDeclaration, err, start, p.s.StopRecording(mark))
+				continue
+			}
+			rule.Descriptors = append(rule.Descriptors, decl)
+
+		default:
+			p.skipToNextDeclarationOrRule()
+			p.reportDiagnostic(DiagnosticKindInvalidDeclaration, errors.New("expected declaration"), start, p.s.StopRecording(mark))
+			continue
+		}
+
+		p.s.StopRecording(mark)
+	}
+}
+
+// consumePageMarginRule consumes a margin rule inside a @page rule, e.g.
+// "@top-center { content: counter(page) }".
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token of a margin rule before calling this method.
+//
+// https://www.w3.org/TR/css-page-3/#margin-at-rules
+func (p *Parser) consumePageMarginRule() (*css.PageMarginRule, error) {
+	name := strings.ToLower(p.s.ConsumeIncludingWhitespace().Value)
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after margin rule name")
+	}
+
+	rule := &css.PageMarginRule{
+		Name: name,
+	}
+
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		defer p.sortDiagnostics(len(p.diagnostics))
+
+		declarations, rules, err := p.consumeBlockContents(nesting.NestingTypeNone, nil)
+		if err != nil {
+			return err
+		}
+		p.reportDroppedRules(rules, errors.New("rule not allowed in margin rule"))
+		rule.Declarations = declarations
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
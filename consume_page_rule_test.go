@@ -0,0 +1,95 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumePageRule(t *testing.T) {
+	testcases := []struct {
+		name                string
+		input               string
+		expectError         bool
+		expected            string
+		expectedMarginRules int
+		expectedNext        csslexer.TokenType
+	}{
+		{
+			name:         "without selector",
+			input:        "@page { size: A4; margin: 2cm }",
+			expected:     "@page { size: A4; margin: 2cm; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "selector list",
+			input:        "@page chapter:first , :LEFT{ margin-left: 3cm; }",
+			expected:     "@page chapter:first, :left { margin-left: 3cm; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:                "margin rules",
+			input:               `@page { @top-left { content: "Title"; } size: letter; @BOTTOM-CENTER { content: counter(page); } }`,
+			expected:            `@page { size: letter; @top-left { content: "Title"; } @bottom-center { content: counter(page); } }`,
+			expectedMarginRules: 2,
+			expectedNext:        csslexer.EOFToken,
+		},
+		{
+			name:         "other at-rules are ignored",
+			input:        "@page { @media print { a { color: red; } } @top-middle { } size: A5; }",
+			expected:     "@page { size: A5; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "invalid declarations are ignored",
+			input:        "@page { 12px; size: A4; margin }",
+			expected:     "@page { size: A4; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "invalid selector",
+			input:        "@page :hover { size: A4; } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@page :first; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumePageRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if len(rule.MarginRules) != tc.expectedMarginRules {
+				t.Errorf("expected %d margin rules, got %d", tc.expectedMarginRules, len(rule.MarginRules))
+			}
+		})
+	}
+}
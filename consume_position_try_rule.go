@@ -0,0 +1,70 @@
+package cssparser
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/token_stream"
+	"go.baoshuo.dev/cssparser/variable"
+)
+
+// consumePositionTryRule consumes a @position-try rule, e.g.
+// "@position-try --flip { top: anchor(bottom); }". Declarations of
+// properties which are not allowed in the rule, !important declarations
+// and at-rules are dropped and reported as diagnostics.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-anchor-position-1/#fallback-rule
+func (p *Parser) consumePositionTryRule() (*css.PositionTryRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	token := p.s.Peek()
+	if !variable.IsValidVariableName(token) {
+		p.skipAtRule()
+		return nil, errors.New("expected dashed-ident after @position-try")
+	}
+	p.s.ConsumeIncludingWhitespace()
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @position-try name")
+	}
+
+	rule := &css.PositionTryRule{
+		Name: token.Value,
+	}
+
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		defer p.sortDiagnostics(len(p.diagnostics))
+
+		declarations, rules, err := p.consumeBlockContents(nesting.NestingTypeNone, nil)
+		if err != nil {
+			return err
+		}
+		p.reportDroppedRules(rules, errors.New("rule not allowed in @position-try"))
+
+		for _, decl := range declarations {
+			switch {
+			case decl.Important:
+				p.reportDroppedDeclaration(decl, errors.New("!important not allowed in @position-try"))
+			case !css.IsPositionTryProperty(strings.ToLower(decl.Property)):
+				p.reportDroppedDeclaration(decl, errors.New("property not allowed in @position-try: "+decl.Property))
+			default:
+				rule.Declarations = append(rule.Declarations, decl)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
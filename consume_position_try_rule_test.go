@@ -0,0 +1,98 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumePositionTryRule(t *testing.T) {
+	testcases := []struct {
+		name        string
+		input       string
+		expectError bool
+		expected    string
+		// The texts of the dropped declarations and rules
+		expectedDiagnostics []string
+		expectedNext        csslexer.TokenType
+	}{
+		{
+			name:         "allowed properties",
+			input:        "@position-try --flip { top: anchor(bottom); margin-inline-start: 4px; position-area: top center; WIDTH: 100px; }",
+			expected:     "@position-try --flip { top: anchor(bottom); margin-inline-start: 4px; position-area: top center; WIDTH: 100px; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:                "other properties are dropped",
+			input:               "@position-try --flip { color: red; left: 0; position: absolute; } a",
+			expected:            "@position-try --flip { left: 0; }",
+			expectedDiagnostics: []string{"color: red", "position: absolute"},
+			expectedNext:        csslexer.WhitespaceToken,
+		},
+		{
+			name:                "important declarations are dropped",
+			input:               "@position-try --flip { top: 0 !important; bottom: 0; }",
+			expected:            "@position-try --flip { bottom: 0; }",
+			expectedDiagnostics: []string{"top: 0 !important"},
+			expectedNext:        csslexer.EOFToken,
+		},
+		{
+			name:                "at-rules are dropped",
+			input:               "@position-try --flip { @foo; top: 0; }",
+			expected:            "@position-try --flip { top: 0; }",
+			expectedDiagnostics: []string{"@foo;"},
+			expectedNext:        csslexer.EOFToken,
+		},
+		{
+			name:         "name is not a dashed-ident",
+			input:        "@position-try flip { top: 0; } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@position-try --flip; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumePositionTryRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			diagnostics := parser.Diagnostics()
+			if len(diagnostics) != len(tc.expectedDiagnostics) {
+				t.Fatalf("expected diagnostics for %q, got %v", tc.expectedDiagnostics, diagnostics)
+			}
+			for i, diagnostic := range diagnostics {
+				if diagnostic.Text != tc.expectedDiagnostics[i] {
+					t.Errorf("expected diagnostic for %q, got %v", tc.expectedDiagnostics[i], diagnostic)
+				}
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/property"
+	"go.baoshuo.dev/cssparser/token_stream"
+	"go.baoshuo.dev/cssparser/variable"
+)
+
+// consumePropertyRule consumes a @property rule, which registers a custom
+// property, e.g. "@property --x { syntax: '<length>'; inherits: false;
+// initial-value: 0px; }".
+//
+// The rule is dropped with an error if its descriptors don't make a valid
+// registration.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#at-property-rule
+func (p *Parser) consumePropertyRule() (*css.PropertyRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	token := p.s.Peek()
+	if !variable.IsValidVariableName(token) {
+		p.skipAtRule()
+		return nil, errors.New("expected custom property name after @property")
+	}
+	p.s.ConsumeIncludingWhitespace()
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @property name")
+	}
+
+	// The descriptors of a dropped rule are not reported on their own
+	diagnostics := len(p.diagnostics)
+
+	var rule *css.PropertyRule
+	var ruleErr error
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		// The error of an invalid rule is reported after the block is
+		// consumed, so that parsing resumes after the rule
+		rule, ruleErr = property.ConsumePropertyDescriptors(ts, token.Value, p.reportDroppedDescriptor)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if ruleErr != nil {
+		p.diagnostics = p.diagnostics[:diagnostics]
+		return nil, ruleErr
+	}
+
+	return rule, nil
+}
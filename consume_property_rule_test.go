@@ -0,0 +1,89 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumePropertyRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "registration",
+			input:        `@property --angle { syntax: "<angle>"; inherits: false; initial-value: 0deg; }`,
+			expected:     `@property --angle { syntax: "<angle>"; inherits: false; initial-value: 0deg; }`,
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "nested rules are ignored",
+			input:        `@property --x { a { color: red; } syntax: "*"; inherits: true; } a`,
+			expected:     `@property --x { syntax: "*"; inherits: true; }`,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid last entry",
+			input:        `@property --x { syntax: "*"; inherits: true; 1 } a`,
+			expected:     `@property --x { syntax: "*"; inherits: true; }`,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid initial value",
+			input:        `@property --x { syntax: "<length>"; inherits: false; initial-value: red; } a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "name is not a custom property",
+			input:        `@property x { syntax: "*"; inherits: true; } a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "extra prelude tokens",
+			input:        `@property --x --y { syntax: "*"; inherits: true; } a`,
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@property --x; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumePropertyRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
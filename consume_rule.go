@@ -16,8 +16,10 @@ import (
 // consumeRuleList consumes a list of CSS rules from the lexer.
 //
 // It handles both at-rules (like @media) and qualified rules.
-// The function continues until it reaches the end of the input (EOF).
+// The function continues until it reaches the end of the input (EOF),
+// or the end of the enclosing block.
 // It ignores whitespace and comments, and processes each rule accordingly.
+// Invalid rules are dropped and reported as diagnostics.
 //
 // https://www.w3.org/TR/2021/CRD-css-syntax-3-20211224/#consume-list-of-rules
 func (p *Parser) consumeRuleList(
@@ -25,53 +27,191 @@ func (p *Parser) consumeRuleList(
 	allowCdoCdcTokens bool,
 	nestingType nesting.NestingTypeType,
 	parentRuleForNesting *css.StyleRule,
-) ([]*css.StyleRule, error) {
-	var rules []*css.StyleRule
+) []css.Rule {
+	var rules []css.Rule
 
-loop:
-	for {
+	for !p.s.AtEnd() {
 		token := p.s.Peek()
 
-		switch token.Type {
-		case csslexer.EOFToken:
-			break loop
+		var rule css.Rule
+		var err error
 
+		start := p.s.PeekSpan().Start
+		mark := p.s.StartRecording()
+
+		switch token.Type {
 		case csslexer.WhitespaceToken:
 			// Ignore whitespace
 			p.s.Consume()
+			p.s.StopRecording(mark)
 			continue
 
 		case csslexer.CDCToken, csslexer.CDOToken:
 			// TODO: Handle CDCToken and CDOToken if needed, now we just ignore them
 			p.s.Consume()
+			p.s.StopRecording(mark)
 			continue
 
 		case csslexer.AtKeywordToken:
 			// Handle at-rules like @media
-			rule, err := p.consumeAtRule()
-			if err != nil {
-				return nil, err
-			}
-			rules = append(rules, rule)
+			rule, err = p.consumeAtRule(allowedRules, nestingType, parentRuleForNesting)
 
 		default:
 			// Handle qualified rules
-			rule, err := p.consumeQualifiedRule(allowedRules, nestingType, parentRuleForNesting)
-			if err != nil {
-				return nil, err
+			rule, err = p.consumeQualifiedRule(allowedRules, nestingType, parentRuleForNesting)
+		}
+
+		if err != nil {
+			if text := p.s.StopRecording(mark); text != "" {
+				p.reportDiagnostic(DiagnosticKindInvalidRule, err, start, text)
+				continue
 			}
-			rules = append(rules, rule)
+
+			// Nothing was consumed, e.g. at a stray '}', so drop the next
+			// component value to make progress.
+			mark = p.s.StartRecording()
+			p.s.ConsumeComponentValue()
+			p.reportDiagnostic(DiagnosticKindInvalidRule, err, start, p.s.StopRecording(mark))
+			continue
 		}
+		p.s.StopRecording(mark)
+
+		p.setSpan(rule, start)
+		rules = append(rules, rule)
+		allowedRules = allowedRules.afterRule(rule)
 	}
 
-	return rules, nil
+	return rules
 }
 
 // consumeAtRule consumes an at-rule from the lexer.
 //
+// Known at-rules are parsed into their dedicated types, other at-rules are
+// kept in their generic form. Rules which are not in allowedRules are
+// consumed and an error is returned.
+//
+// https://www.w3.org/TR/2021/CRD-css-syntax-3-20211224/#consume-at-rule
+func (p *Parser) consumeAtRule(
+	allowedRules allowedRuleType,
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+) (css.Rule, error) {
+	var atRule css.AtRule
+	var err error
+
+	if !allowedRules.Has(qualifiedRuleTypeStyle) {
+		// Only keyframe rules are allowed in @keyframes
+		p.skipAtRule()
+		return nil, errors.New("at-rule not allowed here")
+	}
+
+	switch name := strings.ToLower(p.s.Peek().Value); name {
+	case "charset":
+		if !allowedRules.Has(atRuleTypeCharset) {
+			// @charset is only meaningful as the first rule of the
+			// stylesheet, elsewhere it is invalid
+			p.skipAtRule()
+			return nil, errors.New("@charset rule not allowed here")
+		}
+		atRule, err = p.consumeCharsetRule()
+	case "import":
+		if !allowedRules.Has(atRuleTypeImport) {
+			p.skipAtRule()
+			return nil, errors.New("@import rule not allowed here")
+		}
+		atRule, err = p.consumeImportRule()
+	case "namespace":
+		if !allowedRules.Has(atRuleTypeNamespace) {
+			p.skipAtRule()
+			return nil, errors.New("@namespace rule not allowed here")
+		}
+		atRule, err = p.consumeNamespaceRule()
+	case "media":
+		atRule, err = p.consumeMediaRule(nestingType, parentRuleForNesting, false)
+	case "supports":
+		atRule, err = p.consumeSupportsRule(nestingType, parentRuleForNesting, false)
+	case "keyframes", "-webkit-keyframes":
+		atRule, err = p.consumeKeyframesRule(name == "-webkit-keyframes")
+	case "container":
+		atRule, err = p.consumeContainerRule(nestingType, parentRuleForNesting, false)
+	case "font-face":
+		atRule, err = p.consumeFontFaceRule()
+	case "layer":
+		atRule, err = p.consumeLayerRule(nestingType, parentRuleForNesting, false)
+	case "page":
+		atRule, err = p.consumePageRule()
+	case "scope":
+		atRule, err = p.consumeScopeRule(nestingType, parentRuleForNesting)
+	case "property":
+		atRule, err = p.consumePropertyRule()
+	case "counter-style":
+		atRule, err = p.consumeCounterStyleRule()
+	case "font-feature-values":
+		atRule, err = p.consumeFontFeatureValuesRule()
+	case "font-palette-values":
+		atRule, err = p.consumeFontPaletteValuesRule()
+	case "starting-style":
+		atRule, err = p.consumeStartingStyleRule(nestingType, parentRuleForNesting, false)
+	case "view-transition":
+		atRule, err = p.consumeViewTransitionRule()
+	case "position-try":
+		atRule, err = p.consumePositionTryRule()
+	case "function":
+		atRule, err = p.consumeFunctionRule()
+	case "mixin":
+		atRule, err = p.consumeMixinRule()
+	default:
+		atRule, err = p.consumeGenericAtRule()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return atRule, nil
+}
+
+// consumeGenericAtRule consumes an at-rule in its generic form, keeping
+// its prelude and block as component values.
+//
+// The caller makes sure that the token stream is positioned at an
+// at-keyword token before calling this method.
+//
 // https://www.w3.org/TR/2021/CRD-css-syntax-3-20211224/#consume-at-rule
-func (p *Parser) consumeAtRule() (*css.StyleRule, error) {
-	return nil, errors.New("not implemented yet")
+func (p *Parser) consumeGenericAtRule() (*css.GenericAtRule, error) {
+	token := p.s.Peek()
+	if token.Type != csslexer.AtKeywordToken {
+		return nil, errors.New("expected at-keyword")
+	}
+	p.s.ConsumeIncludingWhitespace()
+
+	atRule := &css.GenericAtRule{
+		Name: token.Value,
+	}
+
+	for {
+		// A '}' ending the enclosing block is a boundary token, so the
+		// at-rule also ends there when it is nested in a style rule.
+		if p.s.AtEnd() {
+			break
+		}
+
+		token := p.s.Peek()
+		if token.Type == csslexer.SemicolonToken {
+			p.s.Consume()
+			break
+		}
+
+		value := p.s.ConsumeComponentValue()
+		if token.Type == csslexer.LeftBraceToken {
+			atRule.Block = value
+			break
+		}
+		atRule.Prelude = append(atRule.Prelude, value)
+	}
+
+	atRule.Prelude = css.TrimComponentValueList(atRule.Prelude)
+
+	return atRule, nil
 }
 
 // consumeQualifiedRule consumes a qualified rule from the lexer.
@@ -104,15 +244,15 @@ func (p *Parser) consumeStyleRule(
 	customPropertyAmbiguity := p.startsCustomPropertyDeclaration()
 
 	// Parse the prelude of the style rule (selectors)
-	selectors, err := selector.ConsumeSelector(p.s, nestingType, parentRuleForNesting)
+	state := p.s.State()
+	selectors, err := selector.ConsumeSelector(p.s, nestingType, parentRuleForNesting, p.namespaces, p.expandNestingParent)
 
 	if err != nil || len(selectors) == 0 {
-		// Read the rest of the prelude if there was an error
-		if nested {
-			p.s.SkipUntil(csslexer.LeftBraceToken, csslexer.SemicolonToken)
-		} else {
-			p.s.SkipUntil(csslexer.LeftBraceToken)
-		}
+		// Read the rest of the prelude if there was an error. The selector
+		// parser may have skipped past the end of the enclosing block, so
+		// start over from the beginning of the prelude.
+		state.Restore()
+		p.skipQualifiedRulePrelude(nested)
 	}
 
 	if p.s.Peek().Type != csslexer.LeftBraceToken {
@@ -131,7 +271,7 @@ func (p *Parser) consumeStyleRule(
 		}
 		// "If nested is false, consume a block from input, and return nothing."
 		// https://drafts.csswg.org/css-syntax/#consume-qualified-rule
-		err := p.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+		err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
 			return nil
 		})
 		if err != nil {
@@ -144,13 +284,16 @@ func (p *Parser) consumeStyleRule(
 	// https://drafts.csswg.org/css-syntax/#consume-qualified-rule
 	// This means checking if the selector parsed successfully
 	if len(selectors) == 0 {
-		err := p.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+		blockErr := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
 			return nil
 		})
-		if err != nil {
-			return nil, err
+		if blockErr != nil {
+			return nil, blockErr
+		}
+		if err == nil {
+			err = errors.New("invalid selector")
 		}
-		return nil, errors.New("invalid selector")
+		return nil, &selectorError{err: err}
 	}
 
 	// Create the style rule and consume its contents
@@ -159,8 +302,10 @@ func (p *Parser) consumeStyleRule(
 		Selectors: selectors,
 	}
 
-	err = p.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
-		return p.consumeStyleRuleContents(styleRule, nestingType)
+	// The contents of a style rule are always parsed in a nesting context,
+	// with the style rule as the parent rule.
+	err = p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		return p.consumeStyleRuleContents(styleRule, nesting.NestingTypeNesting)
 	})
 	if err != nil {
 		return nil, err
@@ -176,8 +321,57 @@ func (p *Parser) startsCustomPropertyDeclaration() bool {
 
 // consumeStyleRuleContents consumes the contents of a style rule block
 func (p *Parser) consumeStyleRuleContents(styleRule *css.StyleRule, nestingType nesting.NestingTypeType) error {
-	var childRules []*css.StyleRule
+	declarations, childRules, err := p.consumeBlockContents(nestingType, styleRule)
+	if err != nil {
+		return err
+	}
+
+	// Store the parsed declarations and child rules
+	styleRule.Declarations = declarations
+	styleRule.Rules = childRules
+
+	return nil
+}
+
+// consumeBlockContents consumes the declarations and nested rules of a
+// block, like the block of a style rule or of a nested @media rule.
+//
+// The declarations before the first nested rule are returned on their own.
+// In style rules and @scope rules, each run of declarations after a nested
+// rule is kept in the rule list as a nested declarations rule, so that the
+// order of declarations and rules is preserved.
+//
+// https://drafts.csswg.org/css-syntax/#consume-block-contents
+func (p *Parser) consumeBlockContents(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+) ([]*css.Declaration, []css.Rule, error) {
+	var childRules []css.Rule
 	var declarations []*css.Declaration
+	var pendingDeclarations []*css.Declaration
+
+	interleaved := nestingType == nesting.NestingTypeNesting || nestingType == nesting.NestingTypeScope
+
+	// start is the position where the declaration or rule being consumed
+	// starts.
+	var start css.Position
+
+	appendRule := func(rule css.Rule) {
+		if len(pendingDeclarations) > 0 {
+			childRules = append(childRules, p.newNestedDeclarationsRule(nestingType, parentRuleForNesting, pendingDeclarations))
+			pendingDeclarations = nil
+		}
+		p.setSpan(rule, start)
+		childRules = append(childRules, rule)
+	}
+
+	appendDeclaration := func(decl *css.Declaration) {
+		if interleaved && len(childRules) > 0 {
+			pendingDeclarations = append(pendingDeclarations, decl)
+		} else {
+			declarations = append(declarations, decl)
+		}
+	}
 
 	for {
 		// Skip whitespace and comments
@@ -188,76 +382,184 @@ func (p *Parser) consumeStyleRuleContents(styleRule *css.StyleRule, nestingType
 		}
 
 		token := p.s.Peek()
-
-		switch token.Type {
-		case csslexer.SemicolonToken:
+		if token.Type == csslexer.SemicolonToken {
 			// Skip semicolons
 			p.s.Consume()
 			continue
+		}
+
+		// The kind and the cause of a dropped declaration or rule
+		var dropKind DiagnosticKind
+		var dropErr error
 
+		start = p.s.PeekSpan().Start
+		mark := p.s.StartRecording()
+
+		switch token.Type {
 		case csslexer.AtKeywordToken:
 			// Handle at-rules (nested @media, @supports, etc.)
+			var nestedRule css.Rule
+			var err error
 			if nestingType != nesting.NestingTypeNone {
-				nestedRule, err := p.consumeNestedAtRule(nestingType, styleRule)
-				if err == nil && nestedRule != nil {
-					childRules = append(childRules, nestedRule)
-				}
+				nestedRule, err = p.consumeNestedAtRule(nestingType, parentRuleForNesting)
 			} else {
-				// Skip unknown at-rules in regular style blocks
-				p.skipToNextDeclarationOrRule()
+				// Only unknown at-rules are kept in regular blocks
+				nestedRule, err = p.consumeOtherAtRule()
+			}
+			if err != nil {
+				dropKind, dropErr = DiagnosticKindInvalidRule, err
+			} else if nestedRule != nil {
+				appendRule(nestedRule)
 			}
 
 		case csslexer.IdentToken:
 			// Try to parse as CSS declaration first
 			state := p.s.State()
-			decl, err := p.consumeDeclaration()
-			if err == nil && decl != nil {
-				declarations = append(declarations, decl)
-			} else {
-				// If declaration parsing failed, try as nested style rule
-				state.Restore()
-				if nestingType != nesting.NestingTypeNone {
-					nestedRule, err := p.consumeNestedStyleRule(nestingType, styleRule)
-					if err == nil && nestedRule != nil {
-						childRules = append(childRules, nestedRule)
-					} else {
-						// Skip to next valid token if nested rule parsing also failed
-						p.skipToNextDeclarationOrRule()
-					}
+			decl, declErr := p.consumeDeclaration()
+			if declErr == nil && decl != nil {
+				if nestingType != nesting.NestingTypeFunction || isFunctionBodyDescriptor(decl) {
+					appendDeclaration(decl)
+				} else {
+					dropKind, dropErr = DiagnosticKindInvalidDeclaration, errors.New("declaration not allowed in @function")
+				}
+				break
+			}
+
+			// If declaration parsing failed, try as nested style rule
+			state.Restore()
+			if nestingType == nesting.NestingTypeNone {
+				// Skip invalid declaration in non-nested context
+				p.skipToNextDeclarationOrRule()
+				dropKind, dropErr = DiagnosticKindInvalidDeclaration, declErr
+				break
+			}
+
+			nestedRule, err := p.consumeNestedStyleRule(nestingType, parentRuleForNesting)
+			switch {
+			case err == nil && nestedRule != nil:
+				// Qualified rules are consumed but dropped in @function
+				if nestingType != nesting.NestingTypeFunction {
+					appendRule(nestedRule)
 				} else {
-					// Skip invalid declaration in non-nested context
-					p.skipToNextDeclarationOrRule()
+					dropKind, dropErr = DiagnosticKindInvalidRule, errors.New("style rule not allowed in @function")
 				}
+			case isSelectorError(err):
+				// The block of the rule has been consumed along with it
+				dropKind, dropErr = DiagnosticKindInvalidRule, err
+			default:
+				// Skip to next valid token if nested rule parsing also failed
+				p.skipToNextDeclarationOrRule()
+				dropKind, dropErr = DiagnosticKindInvalidDeclaration, declErr
 			}
 
 		default:
 			// Handle other tokens that might start nested rules
-			if nestingType != nesting.NestingTypeNone {
-				state := p.s.State()
-				nestedRule, err := p.consumeNestedStyleRule(nestingType, styleRule)
-				if err == nil && nestedRule != nil {
-					childRules = append(childRules, nestedRule)
+			if nestingType == nesting.NestingTypeNone {
+				// Skip unknown tokens in regular style blocks
+				p.skipToNextDeclarationOrRule()
+				dropKind, dropErr = DiagnosticKindInvalidDeclaration, errors.New("expected declaration")
+				break
+			}
+
+			state := p.s.State()
+			nestedRule, err := p.consumeNestedStyleRule(nestingType, parentRuleForNesting)
+			switch {
+			case err == nil && nestedRule != nil:
+				if nestingType != nesting.NestingTypeFunction {
+					appendRule(nestedRule)
 				} else {
-					state.Restore()
-					p.skipToNextDeclarationOrRule()
+					dropKind, dropErr = DiagnosticKindInvalidRule, errors.New("style rule not allowed in @function")
 				}
-			} else {
-				// Skip unknown tokens in regular style blocks
+			case isSelectorError(err):
+				dropKind, dropErr = DiagnosticKindInvalidRule, err
+			default:
+				state.Restore()
 				p.skipToNextDeclarationOrRule()
+				if err == nil {
+					err = errors.New("no nested rule parsed")
+				}
+				dropKind, dropErr = DiagnosticKindInvalidRule, err
 			}
 		}
+
+		text := p.s.StopRecording(mark)
+		if dropErr != nil {
+			p.reportDiagnostic(dropKind, dropErr, start, text)
+		}
 	}
 
-	// Store the parsed declarations and child rules
-	styleRule.Declarations = declarations
-	for _, childRule := range childRules {
-		// TODO: Properly convert childRule to css.GenericRule
-		// For now, we create a placeholder GenericRule
-		_ = childRule // Avoid unused variable error
-		styleRule.Rules = append(styleRule.Rules, &css.GenericRule{})
+	if len(pendingDeclarations) > 0 {
+		childRules = append(childRules, p.newNestedDeclarationsRule(nestingType, parentRuleForNesting, pendingDeclarations))
 	}
 
-	return nil
+	return declarations, childRules, nil
+}
+
+// newNestedDeclarationsRule returns a nested declarations rule holding the
+// given declarations. They match like "&" in style rules, which is expanded
+// to the selectors of the parent rule if enabled, and like
+// ":where(:scope)" in @scope rules.
+//
+// https://www.w3.org/TR/css-nesting-1/#nested-declarations-rule
+func (p *Parser) newNestedDeclarationsRule(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	declarations []*css.Declaration,
+) *css.NestedDeclarationsRule {
+	var selectors []*css.Selector
+	if nestingType == nesting.NestingTypeScope {
+		selectors = []*css.Selector{newWhereSelector(newScopeSelector())}
+	} else {
+		selectors = []*css.Selector{newParentSelector()}
+		if p.expandNestingParent && parentRuleForNesting != nil {
+			selectors = selector.ExpandNestingParent(selectors, parentRuleForNesting.Selectors, false)
+		}
+	}
+
+	rule := &css.NestedDeclarationsRule{
+		Selectors:    selectors,
+		Declarations: declarations,
+	}
+	// The rule spans from its first declaration to its last one
+	rule.Span = css.Span{
+		Start: declarations[0].Span.Start,
+		End:   declarations[len(declarations)-1].Span.End,
+	}
+
+	return rule
+}
+
+// consumeGroupRuleBlock consumes the {}-block of a conditional group rule,
+// like @media or @supports.
+//
+// If nested is true, the rule is inside a style rule and its block is
+// parsed like the contents of a style rule. Otherwise it is parsed as a
+// list of rules, and no declarations are returned.
+//
+// https://www.w3.org/TR/css-conditional-3/#contents-of
+func (p *Parser) consumeGroupRuleBlock(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	nested bool,
+) ([]*css.Declaration, []css.Rule, error) {
+	var declarations []*css.Declaration
+	var rules []css.Rule
+
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		if !nested {
+			rules = p.consumeRuleList(qualifiedRuleTypeStyle, false, nestingType, parentRuleForNesting)
+			return nil
+		}
+
+		var err error
+		declarations, rules, err = p.consumeBlockContents(nestingType, parentRuleForNesting)
+		return err
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return declarations, rules, nil
 }
 
 // consumeDeclaration parses a single CSS declaration (property: value)
@@ -269,6 +571,7 @@ func (p *Parser) consumeDeclaration() (*css.Declaration, error) {
 	}
 
 	propertyName := strings.TrimSpace(token.Value)
+	propertySpan := p.s.PeekSpan()
 	p.s.ConsumeIncludingWhitespace()
 
 	// Expect colon
@@ -282,6 +585,16 @@ func (p *Parser) consumeDeclaration() (*css.Declaration, error) {
 	var valueTokens []string
 	var important bool
 
+	// The spans of the value, without the surrounding whitespace, and of
+	// the "!important"
+	var valueSpan, importantSpan css.Span
+	extendValueSpan := func(span css.Span) {
+		if !valueSpan.Start.IsValid() {
+			valueSpan.Start = span.Start
+		}
+		valueSpan.End = span.End
+	}
+
 	// Consume tokens until we hit semicolon, EOF, or closing brace
 	for {
 		token := p.s.Peek()
@@ -291,6 +604,8 @@ func (p *Parser) consumeDeclaration() (*css.Declaration, error) {
 			break
 		}
 
+		span := p.s.PeekSpan()
+
 		// Check for !important
 		if token.Type == csslexer.DelimiterToken && token.Value == "!" {
 			p.s.Consume()
@@ -299,15 +614,20 @@ func (p *Parser) consumeDeclaration() (*css.Declaration, error) {
 			if nextToken.Type == csslexer.IdentToken &&
 				strings.ToLower(nextToken.Value) == "important" {
 				important = true
+				importantSpan = css.Span{Start: span.Start, End: p.s.PeekSpan().End}
 				p.s.ConsumeIncludingWhitespace()
 				break
 			} else {
 				// Not !important, add the "!" back to value
 				valueTokens = append(valueTokens, "!")
+				extendValueSpan(span)
 			}
 		} else {
 			valueTokens = append(valueTokens, token.String())
 			p.s.Consume()
+			if token.Type != csslexer.WhitespaceToken {
+				extendValueSpan(span)
+			}
 		}
 	}
 
@@ -320,16 +640,57 @@ func (p *Parser) consumeDeclaration() (*css.Declaration, error) {
 		return nil, errors.New("empty property value")
 	}
 
+	decl := &css.Declaration{
+		Property:      propertyName,
+		Value:         value,
+		Important:     important,
+		PropertySpan:  propertySpan,
+		ValueSpan:     valueSpan,
+		ImportantSpan: importantSpan,
+	}
+	decl.Span = css.Span{Start: propertySpan.Start, End: valueSpan.End}
+	if important {
+		decl.Span.End = importantSpan.End
+	}
+
 	// Consume semicolon if present
 	if p.s.Peek().Type == csslexer.SemicolonToken {
 		p.s.Consume()
 	}
 
-	return &css.Declaration{
-		Property:  propertyName,
-		Value:     value,
-		Important: important,
-	}, nil
+	return decl, nil
+}
+
+// skipAtRule consumes the rest of an invalid at-rule, up to and including
+// its block or its ending ';'.
+func (p *Parser) skipAtRule() {
+	p.s.SkipUntil(csslexer.LeftBraceToken, csslexer.SemicolonToken, csslexer.RightBraceToken)
+
+	switch p.s.Peek().Type {
+	case csslexer.LeftBraceToken:
+		_ = p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error { return nil })
+	case csslexer.SemicolonToken:
+		p.s.Consume()
+	}
+}
+
+// skipQualifiedRulePrelude consumes the prelude of an invalid qualified
+// rule, up to its '{'. Nested rules also end at a ';', which is not
+// consumed. The end of the enclosing block is not consumed either.
+//
+// https://drafts.csswg.org/css-syntax/#consume-qualified-rule
+func (p *Parser) skipQualifiedRulePrelude(nested bool) {
+	for !p.s.AtEnd() {
+		switch p.s.Peek().Type {
+		case csslexer.LeftBraceToken:
+			return
+		case csslexer.SemicolonToken:
+			if nested {
+				return
+			}
+		}
+		p.s.ConsumeComponentValue()
+	}
 }
 
 // skipToNextDeclarationOrRule skips tokens until the next declaration or rule
@@ -349,11 +710,91 @@ func (p *Parser) skipToNextDeclarationOrRule() {
 }
 
 // consumeNestedAtRule handles nested at-rules like @media, @supports within style rules
-func (p *Parser) consumeNestedAtRule(nestingType nesting.NestingTypeType, parentRule *css.StyleRule) (*css.StyleRule, error) {
-	// For now, skip nested at-rules as they require more complex parsing
-	// In a full implementation, this would handle @media, @supports, @container, etc.
-	p.skipToNextDeclarationOrRule()
-	return nil, errors.New("nested at-rules not implemented yet")
+func (p *Parser) consumeNestedAtRule(nestingType nesting.NestingTypeType, parentRule *css.StyleRule) (css.Rule, error) {
+	var atRule css.AtRule
+	var err error
+
+	name := strings.ToLower(p.s.Peek().Value)
+	if nestingType == nesting.NestingTypeFunction && name != "media" && name != "supports" && name != "container" {
+		// Only conditional group rules are allowed in @function
+		if _, err := p.consumeGenericAtRule(); err != nil {
+			return nil, err
+		}
+		return nil, errors.New("at-rule not allowed in @function")
+	}
+
+	switch name {
+	case "media":
+		atRule, err = p.consumeMediaRule(nestingType, parentRule, true)
+	case "supports":
+		atRule, err = p.consumeSupportsRule(nestingType, parentRule, true)
+	case "container":
+		atRule, err = p.consumeContainerRule(nestingType, parentRule, true)
+	case "layer":
+		atRule, err = p.consumeLayerRule(nestingType, parentRule, true)
+	case "scope":
+		atRule, err = p.consumeScopeRule(nestingType, parentRule)
+	case "starting-style":
+		atRule, err = p.consumeStartingStyleRule(nestingType, parentRule, true)
+	case "apply":
+		atRule, err = p.consumeApplyRule()
+	default:
+		return p.consumeOtherAtRule()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return atRule, nil
+}
+
+// knownAtRules holds the names of the at-rules which are parsed into
+// their dedicated types where they are allowed.
+var knownAtRules = map[string]bool{
+	"apply":               true,
+	"charset":             true,
+	"container":           true,
+	"counter-style":       true,
+	"font-face":           true,
+	"font-feature-values": true,
+	"font-palette-values": true,
+	"function":            true,
+	"import":              true,
+	"keyframes":           true,
+	"-webkit-keyframes":   true,
+	"layer":               true,
+	"media":               true,
+	"mixin":               true,
+	"namespace":           true,
+	"page":                true,
+	"position-try":        true,
+	"property":            true,
+	"scope":               true,
+	"starting-style":      true,
+	"supports":            true,
+	"view-transition":     true,
+}
+
+// consumeOtherAtRule consumes an at-rule which is not handled in the
+// current block.
+//
+// Unknown at-rules, like "@tailwind base;" or vendor-specific ones, are
+// kept in their generic form so that they survive a round trip. Known
+// at-rules are not valid in the block, so they are dropped and an error is
+// returned.
+func (p *Parser) consumeOtherAtRule() (css.Rule, error) {
+	name := strings.ToLower(p.s.Peek().Value)
+
+	atRule, err := p.consumeGenericAtRule()
+	if err != nil {
+		return nil, err
+	}
+
+	if knownAtRules[name] {
+		return nil, errors.New("@" + name + " rule not allowed here")
+	}
+
+	return atRule, nil
 }
 
 // consumeNestedStyleRule handles nested style rules within CSS nesting
@@ -361,7 +802,3 @@ func (p *Parser) consumeNestedStyleRule(nestingType nesting.NestingTypeType, par
 	// Parse nested style rule with the current nesting context
 	return p.consumeStyleRule(nestingType, parentRule, true)
 }
-
-func (p *Parser) consumeKeyframeStyleRule() (*css.StyleRule, error) {
-	return nil, errors.New("not implemented yet")
-}
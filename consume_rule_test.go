@@ -1,6 +1,8 @@
 package cssparser
 
 import (
+	"fmt"
+	"strings"
 	"testing"
 
 	"go.baoshuo.dev/csslexer"
@@ -42,7 +44,6 @@ func TestParser_ConsumeQualifiedRule(t *testing.T) {
 				Declarations: []*css.Declaration{
 					{Property: "color", Value: "red", Important: false},
 				},
-				Rules: []*css.GenericRule{},
 			},
 		},
 		{
@@ -50,7 +51,16 @@ func TestParser_ConsumeQualifiedRule(t *testing.T) {
 			input:        "0% { opacity: 0; }",
 			allowedRules: qualifiedRuleTypeKeyframes,
 			nestingType:  nesting.NestingTypeNone,
-			expectError:  true, // not implemented yet
+			expectError:  false,
+			expected: &css.StyleRule{
+				Type: css.StyleRuleTypeKeyframe,
+				Declarations: []*css.Declaration{
+					{Property: "opacity", Value: "0", Important: false},
+				},
+				KeyframeSelectors: []*css.KeyframeSelector{
+					{Percentage: 0},
+				},
+			},
 		},
 		{
 			name:         "no allowed rules",
@@ -123,7 +133,6 @@ func TestParser_ConsumeStyleRule(t *testing.T) {
 					{Property: "margin", Value: "10px", Important: false},
 					{Property: "padding", Value: "5px", Important: false},
 				},
-				Rules: []*css.GenericRule{},
 			},
 		},
 		{
@@ -373,6 +382,78 @@ func TestParser_ConsumeStyleRuleContents(t *testing.T) {
 	}
 }
 
+func TestParser_ConsumeBlockContentsAtRules(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		input                string
+		nestingType          nesting.NestingTypeType
+		expectedDeclarations int
+		expectedRules        []string
+	}{
+		{
+			name:                 "unknown at-rules in a style rule",
+			input:                "color: red; margin: 0; @tailwind base; @-moz-document url-prefix() { a { color: blue; } }",
+			nestingType:          nesting.NestingTypeNesting,
+			expectedDeclarations: 2,
+			expectedRules:        []string{"@tailwind base;", "@-moz-document url-prefix() { a { color: blue; } }"},
+		},
+		{
+			name:                 "unknown at-rule ended by the block",
+			input:                "color: red; @apply-thing foo",
+			nestingType:          nesting.NestingTypeNesting,
+			expectedDeclarations: 1,
+			expectedRules:        []string{"@apply-thing foo;"},
+		},
+		{
+			name:                 "known at-rules not allowed in a style rule are dropped",
+			input:                "@font-face { font-family: foo; } @import \"a.css\"; color: red;",
+			nestingType:          nesting.NestingTypeNesting,
+			expectedDeclarations: 1,
+		},
+		{
+			name:                 "unknown at-rules in a regular block",
+			input:                "font-family: foo; @custom-thing { x: y; } @media print { } src: url(a);",
+			nestingType:          nesting.NestingTypeNone,
+			expectedDeclarations: 2,
+			expectedRules:        []string{"@custom-thing { x: y; }"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput("{ " + tc.input + " }")
+			parser := NewParser(input)
+
+			var declarations []*css.Declaration
+			var rules []css.Rule
+			err := parser.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+				var err error
+				declarations, rules, err = parser.consumeBlockContents(tc.nestingType, nil)
+				return err
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(declarations) != tc.expectedDeclarations {
+				t.Errorf("expected %d declarations, got %d", tc.expectedDeclarations, len(declarations))
+			}
+
+			if len(rules) != len(tc.expectedRules) {
+				t.Fatalf("expected %d rules, got %d", len(tc.expectedRules), len(rules))
+			}
+			for i, rule := range rules {
+				if _, ok := rule.(*css.GenericAtRule); !ok {
+					t.Errorf("expected *css.GenericAtRule, got %T", rule)
+				}
+				if rule.String() != tc.expectedRules[i] {
+					t.Errorf("expected %q, got %q", tc.expectedRules[i], rule.String())
+				}
+			}
+		})
+	}
+}
+
 func TestParser_StartsCustomPropertyDeclaration(t *testing.T) {
 	testcases := []struct {
 		name     string
@@ -425,21 +506,115 @@ func TestParser_StartsCustomPropertyDeclaration(t *testing.T) {
 	}
 }
 
+func TestParser_ConsumeAtRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expectedName string
+		expectBlock  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "statement at-rule",
+			input:        `@custom-media --narrow (max-width: 30em); div {}`,
+			expectError:  false,
+			expectedName: "custom-media",
+			expectBlock:  false,
+			expected:     `@custom-media --narrow (max-width: 30em);`,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "block at-rule",
+			input:        "@custom screen and (min-width: 100px) { div { color: red; } }",
+			expectError:  false,
+			expectedName: "custom",
+			expectBlock:  true,
+			expected:     "@custom screen and (min-width: 100px) { div { color: red; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "at-rule without prelude",
+			input:        "@custom-thing { font-family: foo; }",
+			expectError:  false,
+			expectedName: "custom-thing",
+			expectBlock:  true,
+			expected:     "@custom-thing { font-family: foo; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "at-rule ended by EOF",
+			input:        "@tailwind base   ",
+			expectError:  false,
+			expectedName: "tailwind",
+			expectBlock:  false,
+			expected:     "@tailwind base;",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:        "not an at-keyword",
+			input:       "div {}",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeAtRule(topLevelAllowedRules, nesting.NestingTypeNone, nil)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			atRule, ok := rule.(*css.GenericAtRule)
+			if !ok {
+				t.Fatalf("expected *css.GenericAtRule, got %T", rule)
+			}
+
+			if atRule.Name != tc.expectedName {
+				t.Errorf("expected name %q, got %q", tc.expectedName, atRule.Name)
+			}
+
+			if (atRule.Block != nil) != tc.expectBlock {
+				t.Errorf("expected block %v, got %v", tc.expectBlock, atRule.Block != nil)
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+		})
+	}
+}
+
 func TestParser_ConsumeRuleList(t *testing.T) {
 	testcases := []struct {
 		name         string
 		input        string
 		allowedRules allowedRuleType
-		expectError  bool
-		expected     []*css.StyleRule
+		expected     []css.Rule
 	}{
 		{
 			name:         "single rule",
 			input:        "div { color: red; }",
 			allowedRules: qualifiedRuleTypeStyle,
-			expectError:  false,
-			expected: []*css.StyleRule{
-				{
+			expected: []css.Rule{
+				&css.StyleRule{
 					Type: css.StyleRuleTypeQualifiedRule,
 					Selectors: []*css.Selector{
 						{
@@ -456,7 +631,6 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 					Declarations: []*css.Declaration{
 						{Property: "color", Value: "red", Important: false},
 					},
-					Rules: []*css.GenericRule{},
 				},
 			},
 		},
@@ -464,9 +638,8 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 			name:         "multiple rules",
 			input:        "div { color: red; } .class { margin: 10px; }",
 			allowedRules: qualifiedRuleTypeStyle,
-			expectError:  false,
-			expected: []*css.StyleRule{
-				{
+			expected: []css.Rule{
+				&css.StyleRule{
 					Type: css.StyleRuleTypeQualifiedRule,
 					Selectors: []*css.Selector{
 						{
@@ -483,9 +656,8 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 					Declarations: []*css.Declaration{
 						{Property: "color", Value: "red", Important: false},
 					},
-					Rules: []*css.GenericRule{},
 				},
-				{
+				&css.StyleRule{
 					Type: css.StyleRuleTypeQualifiedRule,
 					Selectors: []*css.Selector{
 						{
@@ -502,7 +674,40 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 					Declarations: []*css.Declaration{
 						{Property: "margin", Value: "10px", Important: false},
 					},
-					Rules: []*css.GenericRule{},
+				},
+			},
+		},
+		{
+			name:         "at-rule before rule",
+			input:        "@foo \"utf-8\"; div { color: red; }",
+			allowedRules: qualifiedRuleTypeStyle,
+			expected: []css.Rule{
+				&css.GenericAtRule{
+					Name: "foo",
+					Prelude: []*css.ComponentValue{
+						{
+							Type:  css.ComponentValueTypePreservedToken,
+							Token: csslexer.Token{Type: csslexer.StringToken, Value: "utf-8"},
+						},
+					},
+				},
+				&css.StyleRule{
+					Type: css.StyleRuleTypeQualifiedRule,
+					Selectors: []*css.Selector{
+						{
+							Flag: css.SelectorFlagContainsComplexSelector,
+							Selectors: []*css.SimpleSelector{
+								{
+									Match:    css.SelectorMatchTag,
+									Data:     css.NewSelectorDataTag("", "div"),
+									Relation: css.SelectorRelationSubSelector,
+								},
+							},
+						},
+					},
+					Declarations: []*css.Declaration{
+						{Property: "color", Value: "red", Important: false},
+					},
 				},
 			},
 		},
@@ -510,16 +715,14 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 			name:         "empty input",
 			input:        "",
 			allowedRules: qualifiedRuleTypeStyle,
-			expectError:  false,
-			expected:     []*css.StyleRule{},
+			expected:     []css.Rule{},
 		},
 		{
 			name:         "whitespace and comments",
 			input:        "/* comment */ div { color: red; } /* another comment */",
 			allowedRules: qualifiedRuleTypeStyle,
-			expectError:  false,
-			expected: []*css.StyleRule{
-				{
+			expected: []css.Rule{
+				&css.StyleRule{
 					Type: css.StyleRuleTypeQualifiedRule,
 					Selectors: []*css.Selector{
 						{
@@ -536,7 +739,6 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 					Declarations: []*css.Declaration{
 						{Property: "color", Value: "red", Important: false},
 					},
-					Rules: []*css.GenericRule{},
 				},
 			},
 		},
@@ -547,19 +749,7 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 			input := csslexer.NewInput(tc.input)
 			parser := NewParser(input)
 
-			rules, err := parser.consumeRuleList(tc.allowedRules, true, nesting.NestingTypeNone, nil)
-
-			if tc.expectError {
-				if err == nil {
-					t.Errorf("expected error but got none")
-				}
-				return
-			}
-
-			if err != nil {
-				t.Errorf("unexpected error: %v", err)
-				return
-			}
+			rules := parser.consumeRuleList(tc.allowedRules, true, nesting.NestingTypeNone, nil)
 
 			if len(rules) != len(tc.expected) {
 				t.Errorf("expected %d rules, got %d", len(tc.expected), len(rules))
@@ -574,3 +764,336 @@ func TestParser_ConsumeRuleList(t *testing.T) {
 		})
 	}
 }
+
+func TestParser_ConsumeNestedRules(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "nested style rule",
+			input:    ".a { color: red; .b { color: blue; } }",
+			expected: ".a { color: red; .b { color: blue; } }",
+		},
+		{
+			name:     "nested at-rules",
+			input:    ".a { &:hover { color: blue; } @media print { color: green; } }",
+			expected: ".a { &:hover { color: blue; } @media print { color: green; } }",
+		},
+		{
+			name:     "deeply nested",
+			input:    ".a { .b { .c { color: red } } }",
+			expected: ".a { .b { .c { color: red; } } }",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rules := parser.ParseStylesheet()
+
+			if len(rules) != 1 {
+				t.Fatalf("expected 1 rule, got %d", len(rules))
+			}
+
+			if rules[0].String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rules[0].String())
+			}
+
+			// Parsing the same input again yields an equal tree.
+			again := NewParser(csslexer.NewInput(tc.input)).ParseStylesheet()
+			if !rules[0].Equals(again[0]) {
+				t.Errorf("expected the rules to be equal")
+			}
+		})
+	}
+}
+
+func TestParser_ConsumeInterleavedDeclarations(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expected     string
+		declarations int
+		rules        []string
+	}{
+		{
+			name:         "declarations after a nested rule",
+			input:        "a { color: red; & b {} color: blue; }",
+			expected:     "a { color: red; & b { } color: blue; }",
+			declarations: 1,
+			rules:        []string{"*css.StyleRule", "*css.NestedDeclarationsRule"},
+		},
+		{
+			name:         "several runs of declarations",
+			input:        "a { & b {} color: blue; margin: 0; @media print {} padding: 0 }",
+			expected:     "a { & b { } color: blue; margin: 0; @media print { } padding: 0; }",
+			declarations: 0,
+			rules: []string{
+				"*css.StyleRule", "*css.NestedDeclarationsRule",
+				"*css.MediaRule", "*css.NestedDeclarationsRule",
+			},
+		},
+		{
+			name:         "declarations only",
+			input:        "a { color: red; margin: 0; }",
+			expected:     "a { color: red; margin: 0; }",
+			declarations: 2,
+		},
+		{
+			name:         "rule at the end",
+			input:        "a { color: red; & b { color: blue; } }",
+			expected:     "a { color: red; & b { color: blue; } }",
+			declarations: 1,
+			rules:        []string{"*css.StyleRule"},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rules := parser.ParseStylesheet()
+			if len(rules) != 1 {
+				t.Fatalf("expected 1 rule, got %d", len(rules))
+			}
+
+			if rules[0].String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rules[0].String())
+			}
+
+			styleRule := rules[0].(*css.StyleRule)
+			if len(styleRule.Declarations) != tc.declarations {
+				t.Errorf("expected %d declarations, got %d", tc.declarations, len(styleRule.Declarations))
+			}
+
+			if len(styleRule.Rules) != len(tc.rules) {
+				t.Fatalf("expected %d rules, got %d", len(tc.rules), len(styleRule.Rules))
+			}
+			for i, rule := range styleRule.Rules {
+				if ruleType := fmt.Sprintf("%T", rule); ruleType != tc.rules[i] {
+					t.Errorf("rule %d: expected %s, got %s", i, tc.rules[i], ruleType)
+				}
+
+				// Nested declarations match like '&'
+				if nested, ok := rule.(*css.NestedDeclarationsRule); ok {
+					if len(nested.Selectors) != 1 || nested.Selectors[0].String() != "&" {
+						t.Errorf("rule %d: expected implicit & selector, got %v", i, nested.Selectors)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestParser_ConsumeInterleavedDeclarationsInGroupRule(t *testing.T) {
+	input := csslexer.NewInput(".a { @media print { color: red; .b {} color: blue; } }")
+	parser := NewParser(input)
+
+	rules := parser.ParseStylesheet()
+
+	mediaRule, ok := rules[0].(*css.StyleRule).Rules[0].(*css.MediaRule)
+	if !ok {
+		t.Fatalf("expected *css.MediaRule, got %T", rules[0].(*css.StyleRule).Rules[0])
+	}
+
+	if len(mediaRule.Declarations) != 1 || mediaRule.Declarations[0].Value != "red" {
+		t.Errorf("expected the leading declaration, got %v", mediaRule.Declarations)
+	}
+	if len(mediaRule.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(mediaRule.Rules))
+	}
+	if _, ok := mediaRule.Rules[1].(*css.NestedDeclarationsRule); !ok {
+		t.Errorf("expected *css.NestedDeclarationsRule, got %T", mediaRule.Rules[1])
+	}
+}
+
+func TestParser_ExpandNestingParent(t *testing.T) {
+	input := csslexer.NewInput(".a, .b { color: red; &:hover { .c & { color: blue; } } @media print { .d { } color: green; } }")
+	parser := NewParser(input)
+	parser.SetExpandNestingParent(true)
+
+	rules := parser.ParseStylesheet()
+
+	selectorText := func(selectors []*css.Selector) string {
+		strs := make([]string, 0, len(selectors))
+		for _, sel := range selectors {
+			strs = append(strs, sel.String())
+		}
+		return strings.Join(strs, ", ")
+	}
+
+	var selectors []string
+	var collect func(rules []css.Rule)
+	collect = func(rules []css.Rule) {
+		for _, rule := range rules {
+			switch rule := rule.(type) {
+			case *css.StyleRule:
+				selectors = append(selectors, selectorText(rule.Selectors))
+				collect(rule.Rules)
+			case *css.NestedDeclarationsRule:
+				selectors = append(selectors, selectorText(rule.Selectors))
+			case *css.MediaRule:
+				collect(rule.Rules)
+			}
+		}
+	}
+	collect(rules)
+
+	expected := []string{
+		".a, .b",
+		":is(.a, .b):hover",
+		".c :is(.a, .b):hover",
+		":is(.a, .b) .d",
+		":is(.a, .b)",
+	}
+	if strings.Join(selectors, " | ") != strings.Join(expected, " | ") {
+		t.Errorf("expected %q, got %q", expected, selectors)
+	}
+}
+
+func TestParser_Spans(t *testing.T) {
+	source := "/* header */\na,\n.b > c { color: red !important; & d { margin:0 } }\n@media print {\n  e { }\n}"
+
+	rules := NewParser(csslexer.NewInput(source)).ParseStylesheet()
+	if len(rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rules))
+	}
+
+	text := func(span css.Span) string {
+		if !span.IsValid() {
+			return "<invalid>"
+		}
+		return source[span.Start.Offset:span.End.Offset]
+	}
+	expectSpan := func(name string, span css.Span, expectedText, expectedPos string) {
+		t.Helper()
+		if result := text(span); result != expectedText {
+			t.Errorf("%s: expected text %q, got %q", name, expectedText, result)
+		}
+		if span.String() != expectedPos {
+			t.Errorf("%s: expected span %s, got %s", name, expectedPos, span)
+		}
+	}
+
+	styleRule := rules[0].(*css.StyleRule)
+	expectSpan("style rule", styleRule.Span, "a,\n.b > c { color: red !important; & d { margin:0 } }", "2:1-3:51")
+	expectSpan("first selector", styleRule.Selectors[0].Span, "a", "2:1-2:2")
+	expectSpan("second selector", styleRule.Selectors[1].Span, ".b > c", "3:1-3:7")
+	expectSpan("class selector", styleRule.Selectors[1].Selectors[0].Span, ".b", "3:1-3:3")
+
+	decl := styleRule.Declarations[0]
+	expectSpan("declaration", decl.Span, "color: red !important", "3:10-3:31")
+	expectSpan("property", decl.PropertySpan, "color", "3:10-3:15")
+	expectSpan("value", decl.ValueSpan, "red", "3:17-3:20")
+	expectSpan("important", decl.ImportantSpan, "!important", "3:21-3:31")
+
+	nested := styleRule.Rules[0].(*css.StyleRule)
+	expectSpan("nested rule", nested.Span, "& d { margin:0 }", "3:33-3:49")
+	expectSpan("nested declaration value", nested.Declarations[0].ValueSpan, "0", "3:46-3:47")
+	if nested.Declarations[0].ImportantSpan.IsValid() {
+		t.Errorf("expected no important span, got %s", nested.Declarations[0].ImportantSpan)
+	}
+
+	mediaRule := rules[1].(*css.MediaRule)
+	expectSpan("media rule", mediaRule.Span, "@media print {\n  e { }\n}", "4:1-6:2")
+	expectSpan("rule in media rule", mediaRule.Rules[0].(*css.StyleRule).Span, "e { }", "5:3-5:8")
+}
+
+func TestParser_DescriptorSpans(t *testing.T) {
+	source := "@font-face {\n  font-display: swap;\n}\n@font-feature-values Foo {\n  @swash { fancy: 1 }\n}\n@supports (color: red !important) {}"
+
+	rules := NewParser(csslexer.NewInput(source)).ParseStylesheet()
+	if len(rules) != 3 {
+		t.Fatalf("expected 3 rules, got %d", len(rules))
+	}
+
+	expectSpan := func(name string, span css.Span, expectedText, expectedPos string) {
+		t.Helper()
+		result := "<invalid>"
+		if span.IsValid() {
+			result = source[span.Start.Offset:span.End.Offset]
+		}
+		if result != expectedText {
+			t.Errorf("%s: expected text %q, got %q", name, expectedText, result)
+		}
+		if span.String() != expectedPos {
+			t.Errorf("%s: expected span %s, got %s", name, expectedPos, span)
+		}
+	}
+
+	descriptor := rules[0].(*css.FontFaceRule).Descriptors[0]
+	expectSpan("descriptor", descriptor.Span, "font-display: swap", "2:3-2:21")
+	expectSpan("descriptor name", descriptor.PropertySpan, "font-display", "2:3-2:15")
+	expectSpan("descriptor value", descriptor.ValueSpan, "swap", "2:17-2:21")
+
+	block := rules[1].(*css.FontFeatureValuesRule).Blocks[0]
+	expectSpan("feature values block", block.Span, "@swash { fancy: 1 }", "5:3-5:22")
+	expectSpan("feature value", block.Values[0].Span, "fancy: 1", "5:12-5:20")
+
+	decl := rules[2].(*css.SupportsRule).Condition.Declaration
+	expectSpan("supports declaration", decl.Span, "color: red !important", "7:12-7:33")
+	expectSpan("supports property", decl.PropertySpan, "color", "7:12-7:17")
+	expectSpan("supports value", decl.ValueSpan, "red", "7:19-7:22")
+	expectSpan("supports important", decl.ImportantSpan, "!important", "7:23-7:33")
+}
+
+func TestParser_ConditionSpans(t *testing.T) {
+	source := "@media screen and (min-width: 1px), not print {}\n" +
+		"@supports (not (display: grid)) or foo(a) {}\n" +
+		"@container card (width > 1px) and style(--x: 1) {}\n" +
+		"@keyframes k { from , 50% {} }\n" +
+		"@font-face { src: local(A), url(a.woff) format(\"woff\"); unicode-range: U+0-7F , u+4?? }"
+
+	rules := NewParser(csslexer.NewInput(source)).ParseStylesheet()
+	if len(rules) != 5 {
+		t.Fatalf("expected 5 rules, got %d", len(rules))
+	}
+
+	expectSpan := func(name string, span css.Span, expectedText, expectedPos string) {
+		t.Helper()
+		result := "<invalid>"
+		if span.IsValid() {
+			result = source[span.Start.Offset:span.End.Offset]
+		}
+		if result != expectedText {
+			t.Errorf("%s: expected text %q, got %q", name, expectedText, result)
+		}
+		if span.String() != expectedPos {
+			t.Errorf("%s: expected span %s, got %s", name, expectedPos, span)
+		}
+	}
+
+	queries := rules[0].(*css.MediaRule).Queries.Queries
+	expectSpan("media query", queries[0].Span, "screen and (min-width: 1px)", "1:8-1:35")
+	expectSpan("media condition", queries[0].Condition.Span, "(min-width: 1px)", "1:19-1:35")
+	expectSpan("media feature", queries[0].Condition.Feature.Span, "(min-width: 1px)", "1:19-1:35")
+	expectSpan("second media query", queries[1].Span, "not print", "1:37-1:46")
+
+	supports := rules[1].(*css.SupportsRule).Condition
+	expectSpan("supports condition", supports.Span, "(not (display: grid)) or foo(a)", "2:11-2:42")
+	expectSpan("supports not", supports.Children[0].Span, "(not (display: grid))", "2:11-2:32")
+	expectSpan("supports declaration", supports.Children[0].Children[0].Span, "(display: grid)", "2:16-2:31")
+	expectSpan("supports general enclosed", supports.Children[1].Span, "foo(a)", "2:36-2:42")
+
+	query := rules[2].(*css.ContainerRule).Queries[0]
+	expectSpan("container query", query.Span, "card (width > 1px) and style(--x: 1)", "3:12-3:48")
+	expectSpan("container condition", query.Condition.Span, "(width > 1px) and style(--x: 1)", "3:17-3:48")
+	expectSpan("container feature", query.Condition.Children[0].Feature.Span, "(width > 1px)", "3:17-3:30")
+	expectSpan("style query", query.Condition.Children[1].Span, "style(--x: 1)", "3:35-3:48")
+	expectSpan("style feature", query.Condition.Children[1].Children[0].Span, "--x: 1", "3:41-3:47")
+
+	selectors := rules[3].(*css.KeyframesRule).Keyframes[0].KeyframeSelectors
+	expectSpan("keyframe selector", selectors[0].Span, "from", "4:16-4:20")
+	expectSpan("second keyframe selector", selectors[1].Span, "50%", "4:23-4:26")
+
+	fontFace := rules[4].(*css.FontFaceRule)
+	expectSpan("local source", fontFace.Src[0].Span, "local(A)", "5:19-5:27")
+	expectSpan("url source", fontFace.Src[1].Span, "url(a.woff) format(\"woff\")", "5:29-5:55")
+	expectSpan("unicode range", fontFace.UnicodeRange[0].Span, "U+0-7F", "5:72-5:78")
+	expectSpan("wildcard unicode range", fontFace.UnicodeRange[1].Span, "u+4??", "5:81-5:86")
+}
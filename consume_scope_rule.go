@@ -0,0 +1,148 @@
+package cssparser
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/selector"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeScopeRule consumes a @scope rule. A rule with an invalid prelude
+// is consumed entirely and an error is returned.
+//
+// The <scope-start> selectors are parsed in the nesting context of the rule
+// itself, so that '&' refers to the parent style rule of a nested @scope.
+// The <scope-end> selectors and the body are parsed with
+// NestingTypeScope, where '&' refers to the <scope-start> selectors and
+// ':scope' to the scoping root. Declarations directly inside the body are
+// kept as a nested declarations rule.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-cascade-6/#scope-atrule
+func (p *Parser) consumeScopeRule(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+) (*css.ScopeRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	rule := &css.ScopeRule{}
+
+	err := p.consumeScopePrelude(rule, nestingType, parentRuleForNesting)
+	if err == nil && p.s.Peek().Type != csslexer.LeftBraceToken {
+		err = errors.New("expected '{' after @scope prelude")
+	}
+
+	if err != nil {
+		// Drop the whole rule, including its block
+		p.skipAtRule()
+		return nil, err
+	}
+
+	var declarations []*css.Declaration
+	err = p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		var err error
+		declarations, rule.Rules, err = p.consumeBlockContents(nesting.NestingTypeScope, scopeRuleForNesting(rule))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(declarations) > 0 {
+		// Declarations directly inside @scope apply to the scoping root,
+		// as if they were in a ":where(:scope)" style rule.
+		nestedDeclarations := p.newNestedDeclarationsRule(nesting.NestingTypeScope, nil, declarations)
+		rule.Rules = append([]css.Rule{nestedDeclarations}, rule.Rules...)
+	}
+
+	return rule, nil
+}
+
+// consumeScopePrelude consumes the prelude of a @scope rule:
+//
+//	[ ( <scope-start> ) ]? [ to ( <scope-end> ) ]?
+func (p *Parser) consumeScopePrelude(
+	rule *css.ScopeRule,
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+) error {
+	if p.s.Peek().Type == csslexer.LeftParenthesisToken {
+		start, err := p.consumeScopeBoundary(nestingType, parentRuleForNesting)
+		if err != nil {
+			return err
+		}
+		rule.Start = start
+		p.s.ConsumeWhitespace()
+	}
+
+	token := p.s.Peek()
+	if token.Type != csslexer.IdentToken || !strings.EqualFold(token.Value, "to") {
+		return nil
+	}
+	p.s.ConsumeIncludingWhitespace()
+
+	if p.s.Peek().Type != csslexer.LeftParenthesisToken {
+		return errors.New("expected '(' after 'to' in @scope prelude")
+	}
+
+	end, err := p.consumeScopeBoundary(nesting.NestingTypeScope, scopeRuleForNesting(rule))
+	if err != nil {
+		return err
+	}
+	rule.End = end
+	p.s.ConsumeWhitespace()
+
+	return nil
+}
+
+// consumeScopeBoundary consumes the parenthesized selector list of a
+// <scope-start> or <scope-end>.
+func (p *Parser) consumeScopeBoundary(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+) ([]*css.Selector, error) {
+	var selectors []*css.Selector
+
+	err := p.s.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+		var err error
+		selectors, err = selector.ConsumeSelector(ts, nestingType, parentRuleForNesting, p.namespaces, p.expandNestingParent)
+		if err != nil {
+			return err
+		}
+
+		ts.ConsumeWhitespace()
+		if len(selectors) == 0 || !ts.AtEnd() {
+			return errors.New("invalid selector list in @scope prelude")
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return selectors, nil
+}
+
+// scopeRuleForNesting returns the rule which '&' refers to inside a @scope
+// rule, i.e. a style rule matching ":where(<scope-start>)". Returns nil if
+// the rule has no <scope-start>, in which case '&' refers to ':scope'.
+//
+// https://www.w3.org/TR/css-cascade-6/#scoped-rules
+func scopeRuleForNesting(rule *css.ScopeRule) *css.StyleRule {
+	if len(rule.Start) == 0 {
+		return nil
+	}
+
+	return &css.StyleRule{
+		Type:      css.StyleRuleTypeQualifiedRule,
+		Selectors: []*css.Selector{newWhereSelector(rule.Start...)},
+	}
+}
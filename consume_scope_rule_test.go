@@ -0,0 +1,186 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+func TestParser_ConsumeScopeRule(t *testing.T) {
+	testcases := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expected     string
+		expectedNext csslexer.TokenType
+	}{
+		{
+			name:         "start and end",
+			input:        "@scope (.card) to (.content) { img { border: none; } }",
+			expected:     "@scope (.card) to (.content) { img { border: none; } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "selector lists",
+			input:        "@scope (.card,.panel) to (.content, footer) { }",
+			expected:     "@scope (.card, .panel) to (.content, footer) { }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "empty prelude",
+			input:        "@scope { p { } }",
+			expected:     "@scope { p { } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "end only",
+			input:        "@scope to (.content) { }",
+			expected:     "@scope to (.content) { }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "relative scope end",
+			input:        "@scope (.card) to (> .content) { }",
+			expected:     "@scope (.card) to (:scope > .content) { }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "scoping root and relative selectors in body",
+			input:        "@scope (.card) { :scope { padding: 0; } > .title { margin: 0; } & .icon { } }",
+			expected:     "@scope (.card) { :scope { padding: 0; } :scope > .title { margin: 0; } & .icon { } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "bare declarations",
+			input:        "@scope (.card) { color: red; img { } }",
+			expected:     "@scope (.card) { color: red; img { } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "nested group rule",
+			input:        "@scope (.card) { @media print { color: black; p { } } }",
+			expected:     "@scope (.card) { @media print { color: black; p { } } }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "missing parenthesis after to",
+			input:        "@scope (.card) to .content { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "invalid start selector",
+			input:        "@scope (1) { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "empty start selector",
+			input:        "@scope () { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "unparenthesized start selector",
+			input:        "@scope .card { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeScopeRule(nesting.NestingTypeNone, nil)
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func TestParser_ConsumeScopeRule_NestedDeclarations(t *testing.T) {
+	input := csslexer.NewInput("@scope (.card) { img { } color: red; }")
+	parser := NewParser(input)
+
+	rule, err := parser.consumeScopeRule(nesting.NestingTypeNone, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(rule.Rules) != 2 {
+		t.Fatalf("expected 2 rules, got %d", len(rule.Rules))
+	}
+
+	// The declarations stay after the rule they follow.
+	nested, ok := rule.Rules[1].(*css.NestedDeclarationsRule)
+	if !ok {
+		t.Fatalf("expected *css.NestedDeclarationsRule, got %T", rule.Rules[1])
+	}
+
+	// Declarations directly inside @scope match like ":where(:scope)".
+	if len(nested.Selectors) != 1 || nested.Selectors[0].String() != ":where(:scope)" {
+		t.Errorf("expected implicit :where(:scope) selector, got %v", nested.Selectors)
+	}
+
+	if len(nested.Declarations) != 1 || nested.Declarations[0].Property != "color" {
+		t.Errorf("expected the color declaration, got %v", nested.Declarations)
+	}
+}
+
+func TestParser_ConsumeScopeRule_Nested(t *testing.T) {
+	// A @scope rule inside the ".card" style rule, where '&' in the
+	// <scope-start> refers to the style rule and relative selectors are
+	// relative to it.
+	input := csslexer.NewInput("@scope (> .body) to (.footer) { p { } }")
+	parser := NewParser(input)
+	parent := &css.StyleRule{Type: css.StyleRuleTypeQualifiedRule}
+
+	rule, err := parser.consumeNestedAtRule(nesting.NestingTypeNesting, parent)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "@scope (& > .body) to (.footer) { p { } }"
+	if rule.String() != expected {
+		t.Errorf("expected %q, got %q", expected, rule.String())
+	}
+}
+
+func TestParser_ParseStylesheet_Scope(t *testing.T) {
+	input := csslexer.NewInput("@scope (.a) { @layer x { } } @layer y;")
+	parser := NewParser(input)
+
+	rules := parser.ParseStylesheet()
+
+	if _, ok := rules[0].(*css.ScopeRule); !ok {
+		t.Fatalf("expected *css.ScopeRule, got %T", rules[0])
+	}
+
+	order := css.LayerOrder(rules)
+	if len(order) != 2 || order[0].String() != "x" || order[1].String() != "y" {
+		t.Errorf("expected layers x and y, got %v", order)
+	}
+}
@@ -0,0 +1,43 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+// consumeStartingStyleRule consumes a @starting-style rule.
+//
+// If nested is true, the rule is inside a style rule and its block is
+// parsed like the contents of a style rule, so that declarations directly
+// inside it apply to the parent rule.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-transitions-2/#defining-before-change-style
+func (p *Parser) consumeStartingStyleRule(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	nested bool,
+) (*css.StartingStyleRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @starting-style")
+	}
+
+	declarations, rules, err := p.consumeGroupRuleBlock(nestingType, parentRuleForNesting, nested)
+	if err != nil {
+		return nil, err
+	}
+
+	return &css.StartingStyleRule{
+		Declarations: declarations,
+		Rules:        rules,
+	}, nil
+}
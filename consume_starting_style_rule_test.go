@@ -0,0 +1,89 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+func TestParser_ConsumeStartingStyleRule(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		input                string
+		nestingType          nesting.NestingTypeType
+		nested               bool
+		expectError          bool
+		expected             string
+		expectedDeclarations int
+		expectedRules        int
+	}{
+		{
+			name:          "top-level rule",
+			input:         "@starting-style { .a { opacity: 0; } div { color: red; } }",
+			expected:      "@starting-style { .a { opacity: 0; } div { color: red; } }",
+			expectedRules: 2,
+		},
+		{
+			name:     "empty block",
+			input:    "@starting-style { }",
+			expected: "@starting-style { }",
+		},
+		{
+			name:                 "nested declarations",
+			input:                "@starting-style { opacity: 0; div { color: red; } }",
+			nestingType:          nesting.NestingTypeNesting,
+			nested:               true,
+			expected:             "@starting-style { opacity: 0; div { color: red; } }",
+			expectedDeclarations: 1,
+			expectedRules:        1,
+		},
+		{
+			name:        "non-empty prelude",
+			input:       "@starting-style foo { .a { opacity: 0; } }",
+			expectError: true,
+		},
+		{
+			name:        "missing block",
+			input:       "@starting-style;",
+			expectError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeStartingStyleRule(tc.nestingType, nil, tc.nested)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				if next := parser.s.Peek(); next.Type != csslexer.EOFToken {
+					t.Errorf("expected EOF, got %v", next.Type)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if len(rule.Declarations) != tc.expectedDeclarations {
+				t.Errorf("expected %d declarations, got %d", tc.expectedDeclarations, len(rule.Declarations))
+			}
+
+			if len(rule.Rules) != tc.expectedRules {
+				t.Errorf("expected %d rules, got %d", tc.expectedRules, len(rule.Rules))
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package cssparser
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/supports"
+)
+
+// consumeSupportsRule consumes a @supports rule.
+//
+// If nested is true, the rule is inside a style rule and its block is
+// parsed like the contents of a style rule. A rule with an invalid
+// condition is consumed entirely and an error is returned.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-conditional-5/#at-supports
+func (p *Parser) consumeSupportsRule(
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	nested bool,
+) (*css.SupportsRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	condition, err := supports.ConsumeSupportsCondition(p.s, nestingType, parentRuleForNesting, p.namespaces)
+	if err == nil {
+		p.s.ConsumeWhitespace()
+		if p.s.Peek().Type != csslexer.LeftBraceToken {
+			err = errors.New("unexpected tokens after supports condition")
+		}
+	}
+
+	if err != nil {
+		// Drop the whole rule, including its block
+		p.skipAtRule()
+		return nil, err
+	}
+
+	rule := &css.SupportsRule{
+		Condition: condition,
+	}
+
+	declarations, rules, err := p.consumeGroupRuleBlock(nestingType, parentRuleForNesting, nested)
+	if err != nil {
+		return nil, err
+	}
+	rule.Declarations = declarations
+	rule.Rules = rules
+
+	return rule, nil
+}
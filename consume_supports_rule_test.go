@@ -0,0 +1,103 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/nesting"
+)
+
+func TestParser_ConsumeSupportsRule(t *testing.T) {
+	testcases := []struct {
+		name                 string
+		input                string
+		nestingType          nesting.NestingTypeType
+		nested               bool
+		expectError          bool
+		expected             string
+		expectedDeclarations int
+		expectedRules        int
+		expectedNext         csslexer.TokenType
+	}{
+		{
+			name:          "top-level rule",
+			input:         "@supports (display: grid) and selector(a > b) { div { display: grid; } }",
+			expected:      "@supports (display: grid) and selector(a > b) { div { display: grid; } }",
+			expectedRules: 1,
+			expectedNext:  csslexer.EOFToken,
+		},
+		{
+			name:          "nested @media",
+			input:         "@supports not (display: grid) { @media print { div { float: left; } } }",
+			expected:      "@supports not (display: grid) { @media print { div { float: left; } } }",
+			expectedRules: 1,
+			expectedNext:  csslexer.EOFToken,
+		},
+		{
+			name:                 "nested declarations",
+			input:                "@supports (display: grid) { display: grid; div { gap: 0; } }",
+			nestingType:          nesting.NestingTypeNesting,
+			nested:               true,
+			expected:             "@supports (display: grid) { display: grid; div { gap: 0; } }",
+			expectedDeclarations: 1,
+			expectedRules:        1,
+			expectedNext:         csslexer.EOFToken,
+		},
+		{
+			name:         "invalid condition",
+			input:        "@supports display: grid { div { display: grid; } } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "mixed operators",
+			input:        "@supports (a: b) and (c: d) or (e: f) { } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@supports (display: grid); a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeSupportsRule(tc.nestingType, nil, tc.nested)
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if len(rule.Declarations) != tc.expectedDeclarations {
+				t.Errorf("expected %d declarations, got %d", tc.expectedDeclarations, len(rule.Declarations))
+			}
+
+			if len(rule.Rules) != tc.expectedRules {
+				t.Errorf("expected %d rules, got %d", tc.expectedRules, len(rule.Rules))
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package cssparser
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeViewTransitionRule consumes a @view-transition rule, e.g.
+// "@view-transition { navigation: auto; types: slide; }". Invalid and
+// unknown descriptors, and at-rules, are dropped and reported as
+// diagnostics.
+//
+// The caller makes sure that the token stream is positioned at the
+// at-keyword token before calling this method.
+//
+// https://www.w3.org/TR/css-view-transitions-2/#view-transition-rule
+func (p *Parser) consumeViewTransitionRule() (*css.ViewTransitionRule, error) {
+	p.s.ConsumeIncludingWhitespace() // Consume the at-keyword
+
+	if p.s.Peek().Type != csslexer.LeftBraceToken {
+		p.skipAtRule()
+		return nil, errors.New("expected '{' after @view-transition")
+	}
+
+	rule := &css.ViewTransitionRule{}
+
+	err := p.s.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		defer p.sortDiagnostics(len(p.diagnostics))
+
+		declarations, rules, err := p.consumeBlockContents(nesting.NestingTypeNone, nil)
+		if err != nil {
+			return err
+		}
+		p.reportDroppedRules(rules, errors.New("rule not allowed in @view-transition"))
+
+		for _, decl := range declarations {
+			if decl.Important {
+				p.reportDroppedDeclaration(decl, errors.New("descriptors can't be !important"))
+				continue
+			}
+
+			name := strings.ToLower(decl.Property)
+			switch name {
+			case "navigation":
+				navigation := strings.ToLower(decl.Value)
+				if navigation != "auto" && navigation != "none" {
+					p.reportDroppedDeclaration(decl, errors.New("invalid value for descriptor: "+name))
+					continue
+				}
+				rule.Navigation = navigation
+
+			case "types":
+				types, ok := parseViewTransitionTypes(decl.Value)
+				if !ok {
+					p.reportDroppedDeclaration(decl, errors.New("invalid value for descriptor: "+name))
+					continue
+				}
+				rule.Types = types
+
+			default:
+				p.reportDroppedDeclaration(decl, errors.New("unknown descriptor: "+decl.Property))
+				continue
+			}
+
+			// Keep the spans of the declaration
+			descriptor := *decl
+			descriptor.Property = name
+			rule.Descriptors = append(rule.Descriptors, &descriptor)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// parseViewTransitionTypes parses the value of the types descriptor:
+//
+//	none | <custom-ident>+
+//
+// The types can't be "none" or start with "-ua-". Returns no types for
+// none.
+//
+// https://www.w3.org/TR/css-view-transitions-2/#types-cross-doc
+func parseViewTransitionTypes(value string) ([]string, bool) {
+	ts := token_stream.NewTokenStream(csslexer.NewInput(value))
+
+	var types []string
+	for _, v := range ts.ConsumeComponentValueList() {
+		if v.IsWhitespace() {
+			continue
+		}
+		if v.Type != css.ComponentValueTypePreservedToken || v.Token.Type != csslexer.IdentToken {
+			return nil, false
+		}
+		types = append(types, v.Token.Value)
+	}
+
+	if len(types) == 1 && strings.EqualFold(types[0], "none") {
+		return nil, true
+	}
+
+	for _, t := range types {
+		lower := strings.ToLower(t)
+		if lower == "none" || css.IsReservedCustomIdent(lower) || strings.HasPrefix(lower, "-ua-") {
+			return nil, false
+		}
+	}
+
+	return types, len(types) > 0
+}
@@ -0,0 +1,122 @@
+package cssparser
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_ConsumeViewTransitionRule(t *testing.T) {
+	testcases := []struct {
+		name               string
+		input              string
+		expectError        bool
+		expected           string
+		expectedNavigation string
+		expectedTypes      []string
+		// The texts of the dropped declarations and rules
+		expectedDiagnostics []string
+		expectedNext        csslexer.TokenType
+	}{
+		{
+			name:               "navigation",
+			input:              "@view-transition { navigation: AUTO; }",
+			expected:           "@view-transition { navigation: AUTO; }",
+			expectedNavigation: "auto",
+			expectedNext:       csslexer.EOFToken,
+		},
+		{
+			name:               "types",
+			input:              "@view-transition { navigation: none; types: slide Forwards; } a",
+			expected:           "@view-transition { navigation: none; types: slide Forwards; }",
+			expectedNavigation: "none",
+			expectedTypes:      []string{"slide", "Forwards"},
+			expectedNext:       csslexer.WhitespaceToken,
+		},
+		{
+			name:         "types none",
+			input:        "@view-transition { types: none; }",
+			expected:     "@view-transition { types: none; }",
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:     "invalid descriptors are dropped",
+			input:    "@view-transition { navigation: always; types: -ua-slide; types: none slide; types: 1; color: red; navigation: auto !important; @foo; }",
+			expected: "@view-transition { }",
+			expectedDiagnostics: []string{
+				"navigation: always",
+				"types: -ua-slide",
+				"types: none slide",
+				"types: 1",
+				"color: red",
+				"navigation: auto !important",
+				"@foo;",
+			},
+			expectedNext: csslexer.EOFToken,
+		},
+		{
+			name:         "non-empty prelude",
+			input:        "@view-transition foo { navigation: auto; } a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+		{
+			name:         "missing block",
+			input:        "@view-transition; a",
+			expectError:  true,
+			expectedNext: csslexer.WhitespaceToken,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rule, err := parser.consumeViewTransitionRule()
+
+			if next := parser.s.Peek(); next.Type != tc.expectedNext {
+				t.Errorf("expected next token %v, got %v", tc.expectedNext, next.Type)
+			}
+
+			diagnostics := parser.Diagnostics()
+			if len(diagnostics) != len(tc.expectedDiagnostics) {
+				t.Fatalf("expected diagnostics for %q, got %v", tc.expectedDiagnostics, diagnostics)
+			}
+			for i, diagnostic := range diagnostics {
+				if diagnostic.Text != tc.expectedDiagnostics[i] {
+					t.Errorf("expected diagnostic for %q, got %v", tc.expectedDiagnostics[i], diagnostic)
+				}
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if rule.Navigation != tc.expectedNavigation {
+				t.Errorf("expected navigation %q, got %q", tc.expectedNavigation, rule.Navigation)
+			}
+
+			if len(rule.Types) != len(tc.expectedTypes) {
+				t.Fatalf("expected types %q, got %q", tc.expectedTypes, rule.Types)
+			}
+			for i, typ := range rule.Types {
+				if typ != tc.expectedTypes[i] {
+					t.Errorf("expected type %q, got %q", tc.expectedTypes[i], typ)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package container
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/media"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeContainerQueryList consumes a comma-separated list of container
+// queries.
+//
+// https://www.w3.org/TR/css-conditional-5/#container-rule
+func (cp *ContainerQueryParser) consumeContainerQueryList() ([]*css.ContainerQuery, error) {
+	var queries []*css.ContainerQuery
+
+	for {
+		query, err := cp.consumeContainerQuery()
+		if err != nil {
+			return nil, err
+		}
+		queries = append(queries, query)
+
+		cp.tokenStream.ConsumeWhitespace()
+		if cp.atEndOfQueryList() {
+			return queries, nil
+		}
+		if cp.tokenStream.Peek().Type != csslexer.CommaToken {
+			return nil, errors.New("invalid container query: unexpected tokens after query")
+		}
+		cp.tokenStream.ConsumeIncludingWhitespace()
+	}
+}
+
+// consumeContainerQuery consumes an optional container name followed by
+// an optional container condition.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-container-condition
+func (cp *ContainerQueryParser) consumeContainerQuery() (*css.ContainerQuery, error) {
+	query := &css.ContainerQuery{}
+	start := cp.tokenStream.PeekSpan().Start
+
+	if token := cp.tokenStream.Peek(); token.Type == csslexer.IdentToken && !strings.EqualFold(token.Value, "not") {
+		if !isValidContainerName(token.Value) {
+			return nil, errors.New("invalid container query: invalid container name")
+		}
+		query.Name = token.Value
+		cp.tokenStream.ConsumeIncludingWhitespace()
+
+		if cp.atEndOfQuery() {
+			query.SetSpan(cp.spanFrom(start))
+			return query, nil
+		}
+	}
+
+	condition, err := cp.consumeContainerCondition()
+	if err != nil {
+		return nil, err
+	}
+	query.Condition = condition
+	query.SetSpan(cp.spanFrom(start))
+
+	return query, nil
+}
+
+// consumeContainerCondition consumes a <container-query>, which is a
+// condition made of size features, style() and scroll-state() queries.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-container-query
+func (cp *ContainerQueryParser) consumeContainerCondition() (*css.ContainerCondition, error) {
+	return cp.consumeCondition(cp.consumeQueryInParens)
+}
+
+// consumeCondition consumes a condition made of operands combined with
+// not, and & or. The same grammar is used by container queries and by the
+// queries inside style() and scroll-state(), which only differ in their
+// operands.
+func (cp *ContainerQueryParser) consumeCondition(
+	consumeInParens func() (*css.ContainerCondition, error),
+) (*css.ContainerCondition, error) {
+	start := cp.tokenStream.PeekSpan().Start
+
+	if cp.peekIsKeyword("not") {
+		cp.tokenStream.ConsumeIncludingWhitespace()
+
+		operand, err := consumeInParens()
+		if err != nil {
+			return nil, err
+		}
+
+		condition := &css.ContainerCondition{
+			Type:     css.ContainerConditionNot,
+			Children: []*css.ContainerCondition{operand},
+		}
+		condition.SetSpan(cp.spanFrom(start))
+
+		return condition, nil
+	}
+
+	first, err := consumeInParens()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only peek past the whitespace, so that it is left untouched if the
+	// condition ends here.
+	state := cp.tokenStream.State()
+	cp.tokenStream.ConsumeWhitespace()
+
+	var conditionType css.ContainerConditionType
+	var keyword string
+	switch {
+	case cp.peekIsKeyword("and"):
+		conditionType = css.ContainerConditionAnd
+		keyword = "and"
+	case cp.peekIsKeyword("or"):
+		conditionType = css.ContainerConditionOr
+		keyword = "or"
+	default:
+		state.Restore()
+		return first, nil
+	}
+
+	condition := &css.ContainerCondition{
+		Type:     conditionType,
+		Children: []*css.ContainerCondition{first},
+	}
+
+	for cp.peekIsKeyword(keyword) {
+		cp.tokenStream.ConsumeIncludingWhitespace()
+
+		operand, err := consumeInParens()
+		if err != nil {
+			return nil, err
+		}
+		condition.Children = append(condition.Children, operand)
+
+		state = cp.tokenStream.State()
+		cp.tokenStream.ConsumeWhitespace()
+	}
+	state.Restore()
+	condition.SetSpan(cp.spanFrom(start))
+
+	return condition, nil
+}
+
+// consumeQueryInParens consumes a parenthesized container query, a size
+// feature, a style() or scroll-state() query or a general-enclosed value.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-query-in-parens
+func (cp *ContainerQueryParser) consumeQueryInParens() (*css.ContainerCondition, error) {
+	token := cp.tokenStream.Peek()
+	start := cp.tokenStream.PeekSpan().Start
+
+	switch token.Type {
+	case csslexer.LeftParenthesisToken:
+		// ( <container-query> )
+		if condition, ok := cp.consumeInBlock(cp.consumeContainerCondition); ok {
+			// The span includes the parentheses
+			condition.SetSpan(cp.spanFrom(start))
+			return condition, nil
+		}
+
+		// ( <size-feature> )
+		if condition, ok := cp.consumeInBlock(cp.consumeFeature); ok {
+			// Like in media queries, the span of the feature includes the
+			// parentheses
+			condition.SetSpan(cp.spanFrom(start))
+			condition.Feature.SetSpan(condition.Span)
+			return condition, nil
+		}
+
+		// <general-enclosed>
+		return cp.consumeGeneralEnclosed(), nil
+
+	case csslexer.FunctionToken:
+		var consumeQuery func() (*css.ContainerCondition, error)
+		var conditionType css.ContainerConditionType
+
+		switch strings.ToLower(token.Value) {
+		case "style":
+			consumeQuery = cp.consumeStyleQuery
+			conditionType = css.ContainerConditionStyle
+		case "scroll-state":
+			consumeQuery = cp.consumeScrollStateQuery
+			conditionType = css.ContainerConditionScrollState
+		default:
+			return cp.consumeGeneralEnclosed(), nil
+		}
+
+		if query, ok := cp.consumeInBlock(consumeQuery); ok {
+			condition := &css.ContainerCondition{
+				Type:     conditionType,
+				Children: []*css.ContainerCondition{query},
+			}
+			condition.SetSpan(cp.spanFrom(start))
+			return condition, nil
+		}
+
+		// <general-enclosed>
+		return cp.consumeGeneralEnclosed(), nil
+
+	default:
+		return nil, errors.New("invalid container condition: expected '(' or function")
+	}
+}
+
+// consumeStyleQuery consumes the contents of style(), which is either a
+// single style feature or a condition on style features.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-style-query
+func (cp *ContainerQueryParser) consumeStyleQuery() (*css.ContainerCondition, error) {
+	if cp.tokenStream.Peek().Type == csslexer.IdentToken && !cp.peekIsKeyword("not") {
+		return cp.consumeStyleFeature()
+	}
+
+	return cp.consumeCondition(cp.consumeStyleInParens)
+}
+
+// consumeStyleInParens consumes a parenthesized style query, a style
+// feature or a general-enclosed value.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-style-in-parens
+func (cp *ContainerQueryParser) consumeStyleInParens() (*css.ContainerCondition, error) {
+	start := cp.tokenStream.PeekSpan().Start
+
+	switch cp.tokenStream.Peek().Type {
+	case csslexer.LeftParenthesisToken:
+		// ( <style-query> ) and ( <style-feature> )
+		if condition, ok := cp.consumeInBlock(cp.consumeStyleQuery); ok {
+			// The span includes the parentheses
+			condition.SetSpan(cp.spanFrom(start))
+			return condition, nil
+		}
+
+		// <general-enclosed>
+		return cp.consumeGeneralEnclosed(), nil
+
+	case csslexer.FunctionToken:
+		// <general-enclosed>
+		return cp.consumeGeneralEnclosed(), nil
+
+	default:
+		return nil, errors.New("invalid style query: expected '(' or function")
+	}
+}
+
+// consumeStyleFeature consumes a style feature, i.e. a custom property
+// name optionally followed by a colon and a value.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-style-feature
+func (cp *ContainerQueryParser) consumeStyleFeature() (*css.ContainerCondition, error) {
+	token := cp.tokenStream.Peek()
+	start := cp.tokenStream.PeekSpan().Start
+	if token.Type != csslexer.IdentToken || !isCustomPropertyName(token.Value) {
+		return nil, errors.New("invalid style feature: expected custom property name")
+	}
+	cp.tokenStream.ConsumeIncludingWhitespace()
+
+	condition := &css.ContainerCondition{
+		Type:     css.ContainerConditionStyleFeature,
+		Property: token.Value,
+	}
+
+	if cp.tokenStream.AtEnd() {
+		condition.SetSpan(cp.spanFrom(start))
+		return condition, nil
+	}
+
+	if cp.tokenStream.Peek().Type != csslexer.ColonToken {
+		return nil, errors.New("invalid style feature: expected ':' after property name")
+	}
+	cp.tokenStream.Consume()
+
+	value := []*css.ComponentValue{}
+	for !cp.tokenStream.AtEnd() {
+		value = append(value, cp.tokenStream.ConsumeComponentValue())
+	}
+	condition.Value = css.TrimComponentValueList(value)
+	condition.SetSpan(cp.spanFrom(start))
+
+	return condition, nil
+}
+
+// consumeScrollStateQuery consumes the contents of scroll-state(), which is
+// either a single scroll-state feature or a condition on scroll-state
+// features.
+//
+// https://drafts.csswg.org/css-conditional-5/#typedef-scroll-state-query
+func (cp *ContainerQueryParser) consumeScrollStateQuery() (*css.ContainerCondition, error) {
+	if cp.tokenStream.Peek().Type == csslexer.IdentToken && !cp.peekIsKeyword("not") {
+		return cp.consumeFeature()
+	}
+
+	return cp.consumeCondition(cp.consumeScrollStateInParens)
+}
+
+// consumeScrollStateInParens consumes a parenthesized scroll-state query, a
+// scroll-state feature or a general-enclosed value.
+//
+// https://drafts.csswg.org/css-conditional-5/#typedef-scroll-state-in-parens
+func (cp *ContainerQueryParser) consumeScrollStateInParens() (*css.ContainerCondition, error) {
+	start := cp.tokenStream.PeekSpan().Start
+
+	switch cp.tokenStream.Peek().Type {
+	case csslexer.LeftParenthesisToken:
+		// ( <scroll-state-query> ) and ( <scroll-state-feature> )
+		if condition, ok := cp.consumeInBlock(cp.consumeScrollStateQuery); ok {
+			// The span includes the parentheses, and the one of a feature
+			// too, like in media queries
+			condition.SetSpan(cp.spanFrom(start))
+			if condition.Feature != nil {
+				condition.Feature.SetSpan(condition.Span)
+			}
+			return condition, nil
+		}
+
+		// <general-enclosed>
+		return cp.consumeGeneralEnclosed(), nil
+
+	case csslexer.FunctionToken:
+		// <general-enclosed>
+		return cp.consumeGeneralEnclosed(), nil
+
+	default:
+		return nil, errors.New("invalid scroll-state query: expected '(' or function")
+	}
+}
+
+// consumeFeature consumes a size or scroll-state feature, which share the
+// syntax of media features.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-size-feature
+func (cp *ContainerQueryParser) consumeFeature() (*css.ContainerCondition, error) {
+	start := cp.tokenStream.PeekSpan().Start
+
+	feature, err := media.ConsumeMediaFeature(cp.tokenStream)
+	if err != nil {
+		return nil, err
+	}
+	feature.SetSpan(cp.spanFrom(start))
+
+	condition := &css.ContainerCondition{
+		Type:    css.ContainerConditionFeature,
+		Feature: feature,
+	}
+	condition.SetSpan(feature.Span)
+
+	return condition, nil
+}
+
+// consumeInBlock consumes a function or a simple block whose whole contents
+// are consumed by consume. If they are not, the token stream is left
+// untouched and false is returned.
+func (cp *ContainerQueryParser) consumeInBlock(
+	consume func() (*css.ContainerCondition, error),
+) (*css.ContainerCondition, bool) {
+	state := cp.tokenStream.State()
+
+	var condition *css.ContainerCondition
+	err := cp.tokenStream.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+		ts.ConsumeWhitespace()
+
+		c, err := consume()
+		if err != nil {
+			return err
+		}
+
+		ts.ConsumeWhitespace()
+		if !ts.AtEnd() {
+			return errors.New("invalid container condition: unexpected tokens")
+		}
+
+		condition = c
+		return nil
+	})
+	if err != nil {
+		state.Restore()
+		return nil, false
+	}
+
+	return condition, true
+}
+
+// consumeGeneralEnclosed consumes a function or a simple block as a
+// general-enclosed value.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-general-enclosed
+func (cp *ContainerQueryParser) consumeGeneralEnclosed() *css.ContainerCondition {
+	start := cp.tokenStream.PeekSpan().Start
+
+	condition := &css.ContainerCondition{
+		Type:            css.ContainerConditionGeneralEnclosed,
+		GeneralEnclosed: cp.tokenStream.ConsumeComponentValue(),
+	}
+	condition.SetSpan(cp.spanFrom(start))
+
+	return condition
+}
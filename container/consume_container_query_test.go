@@ -0,0 +1,157 @@
+package container
+
+import (
+	"strings"
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeContainerQueryList(t *testing.T) {
+	testcases := []struct {
+		name              string
+		input             string
+		expectError       bool
+		expected          string
+		expectedNextToken csslexer.TokenType
+	}{
+		{"size feature", "(min-width: 400px)", false, "(min-width: 400px)", csslexer.EOFToken},
+		{"range feature", "(400px <= width < 800px) {", false, "(400px <= width < 800px)", csslexer.LeftBraceToken},
+		{"name only", "sidebar", false, "sidebar", csslexer.EOFToken},
+		{"name and condition", "card (orientation: portrait)", false, "card (orientation: portrait)", csslexer.EOFToken},
+		{"list", "a (width > 1px),b,(height > 1px) ;", false, "a (width > 1px), b, (height > 1px)", csslexer.SemicolonToken},
+		{"not", "not (width > 400px)", false, "not (width > 400px)", csslexer.EOFToken},
+		{"and", "(width > 400px) and (height > 400px)", false, "(width > 400px) and (height > 400px)", csslexer.EOFToken},
+		{"nested condition", "card ((width > 1px) or (height > 1px)) and (not (aspect-ratio > 1))", false, "card ((width > 1px) or (height > 1px)) and (not (aspect-ratio > 1))", csslexer.EOFToken},
+		{"style feature", "style(--theme:  dark)", false, "style(--theme: dark)", csslexer.EOFToken},
+		{"style feature without value", "STYLE(--theme)", false, "style(--theme)", csslexer.EOFToken},
+		{"style condition", "style((--a: 1) and (not (--b: 2)))", false, "style((--a: 1) and (not (--b: 2)))", csslexer.EOFToken},
+		{"style with standard property", "style(color: red)", false, "style(color: red)", csslexer.EOFToken},
+		{"scroll-state feature", "scroll-state(stuck: top)", false, "scroll-state(stuck: top)", csslexer.EOFToken},
+		{"scroll-state condition", "scroll-state((snapped: x) or (scrollable))", false, "scroll-state((snapped: x) or (scrollable))", csslexer.EOFToken},
+		{"mixed", "card style(--a: 1) and scroll-state(stuck: top) and (width > 1px)", false, "card style(--a: 1) and scroll-state(stuck: top) and (width > 1px)", csslexer.EOFToken},
+		{"general enclosed", "(unknown thing) or foo(bar)", false, "(unknown thing) or foo(bar)", csslexer.EOFToken},
+		{"empty", "{", true, "", 0},
+		{"reserved name", "none (width > 1px)", true, "", 0},
+		{"CSS-wide keyword name", "inherit", true, "", 0},
+		{"two names", "a b", true, "", 0},
+		{"mixed operators", "(width > 1px) and (height > 1px) or (color)", true, "", 0},
+		{"trailing comma", "a,", true, "", 0},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			queries, err := ConsumeContainerQueryList(ts)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			queryStrs := make([]string, 0, len(queries))
+			for _, query := range queries {
+				queryStrs = append(queryStrs, query.String())
+			}
+			if result := strings.Join(queryStrs, ", "); result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+
+			if next := ts.Peek(); next.Type != tc.expectedNextToken {
+				t.Errorf("expected next token %v, got %v", tc.expectedNextToken, next.Type)
+			}
+		})
+	}
+}
+
+func Test_ConsumeContainerCondition_Structure(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected *css.ContainerCondition
+	}{
+		{
+			name:  "style feature",
+			input: "style(--theme: dark)",
+			expected: &css.ContainerCondition{
+				Type: css.ContainerConditionStyle,
+				Children: []*css.ContainerCondition{
+					{
+						Type:     css.ContainerConditionStyleFeature,
+						Property: "--theme",
+						Value: []*css.ComponentValue{
+							{Type: css.ComponentValueTypePreservedToken, Token: csslexer.Token{Type: csslexer.IdentToken, Value: "dark"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "style with standard property",
+			input: "style(color: red)",
+			expected: &css.ContainerCondition{
+				Type: css.ContainerConditionGeneralEnclosed,
+				GeneralEnclosed: &css.ComponentValue{
+					Type:  css.ComponentValueTypeFunction,
+					Token: csslexer.Token{Type: csslexer.FunctionToken, Value: "style"},
+					Children: []*css.ComponentValue{
+						{Type: css.ComponentValueTypePreservedToken, Token: csslexer.Token{Type: csslexer.IdentToken, Value: "color"}},
+						{Type: css.ComponentValueTypePreservedToken, Token: csslexer.Token{Type: csslexer.ColonToken, Value: ":"}},
+						{Type: css.ComponentValueTypePreservedToken, Token: csslexer.Token{Type: csslexer.WhitespaceToken, Value: " "}},
+						{Type: css.ComponentValueTypePreservedToken, Token: csslexer.Token{Type: csslexer.IdentToken, Value: "red"}},
+					},
+				},
+			},
+		},
+		{
+			name:  "scroll-state feature",
+			input: "not scroll-state(stuck: top)",
+			expected: &css.ContainerCondition{
+				Type: css.ContainerConditionNot,
+				Children: []*css.ContainerCondition{
+					{
+						Type: css.ContainerConditionScrollState,
+						Children: []*css.ContainerCondition{
+							{
+								Type: css.ContainerConditionFeature,
+								Feature: &css.MediaFeature{
+									Type:  css.MediaFeaturePlain,
+									Name:  "stuck",
+									Value: &css.MediaFeatureValue{Type: css.MediaFeatureValueIdent, Ident: "top"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			condition, err := ConsumeContainerCondition(ts)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !condition.Equals(tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, condition)
+			}
+		})
+	}
+}
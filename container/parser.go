@@ -0,0 +1,34 @@
+package container
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+type ContainerQueryParser struct {
+	tokenStream *token_stream.TokenStream
+}
+
+func NewContainerQueryParser(tokenStream *token_stream.TokenStream) *ContainerQueryParser {
+	return &ContainerQueryParser{
+		tokenStream: tokenStream,
+	}
+}
+
+// ConsumeContainerQueryList consumes the comma-separated container queries
+// of a @container rule, stopping before a '{', a ';' or the end of the
+// token stream.
+//
+// Unlike media query lists, the whole list is invalid if one of its
+// queries is invalid.
+func ConsumeContainerQueryList(tokenStream *token_stream.TokenStream) ([]*css.ContainerQuery, error) {
+	tokenStream.ConsumeWhitespace()
+	return NewContainerQueryParser(tokenStream).consumeContainerQueryList()
+}
+
+// ConsumeContainerCondition consumes a container condition, stopping at
+// the first token which cannot be part of it.
+func ConsumeContainerCondition(tokenStream *token_stream.TokenStream) (*css.ContainerCondition, error) {
+	tokenStream.ConsumeWhitespace()
+	return NewContainerQueryParser(tokenStream).consumeContainerCondition()
+}
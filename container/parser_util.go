@@ -0,0 +1,36 @@
+package container
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+func (cp *ContainerQueryParser) atEndOfQueryList() bool {
+	if cp.tokenStream.AtEnd() {
+		return true
+	}
+
+	t := cp.tokenStream.Peek()
+
+	return t.Type == csslexer.LeftBraceToken || t.Type == csslexer.SemicolonToken
+}
+
+func (cp *ContainerQueryParser) atEndOfQuery() bool {
+	return cp.atEndOfQueryList() || cp.tokenStream.Peek().Type == csslexer.CommaToken
+}
+
+// peekIsKeyword checks if the next token is the given identifier, ignoring
+// ASCII case.
+func (cp *ContainerQueryParser) peekIsKeyword(keyword string) bool {
+	t := cp.tokenStream.Peek()
+	return t.Type == csslexer.IdentToken && strings.EqualFold(t.Value, keyword)
+}
+
+// spanFrom returns the span of a node which starts at the given position
+// and ends with the last consumed token, not counting whitespace.
+func (cp *ContainerQueryParser) spanFrom(start css.Position) css.Span {
+	return css.Span{Start: start, End: cp.tokenStream.PositionBeforeWhitespace()}
+}
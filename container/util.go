@@ -0,0 +1,25 @@
+package container
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// isValidContainerName checks if an identifier can be used as a container
+// name.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-container-name
+func isValidContainerName(name string) bool {
+	switch strings.ToLower(name) {
+	case "none", "and", "not", "or":
+		return false
+	default:
+		return !css.IsReservedCustomIdent(name)
+	}
+}
+
+// isCustomPropertyName checks if an identifier is a custom property name.
+func isCustomPropertyName(name string) bool {
+	return strings.HasPrefix(name, "--") && len(name) > 2
+}
@@ -0,0 +1,77 @@
+package counterstyle
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+)
+
+// consumeDescriptorList consumes a list of @counter-style descriptors and
+// checks that they define a counter style.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#the-counter-style-rule
+func (cp *CounterStyleParser) consumeDescriptorList(name string) (*css.CounterStyleRule, error) {
+	rule := &css.CounterStyleRule{
+		Name: name,
+	}
+
+	descriptor.ConsumeList(cp.tokenStream, cp.report, func(d *descriptor.Descriptor) error {
+		return cp.consumeDescriptor(rule, d)
+	}, nil)
+
+	if err := checkSymbols(rule); err != nil {
+		return nil, err
+	}
+
+	return rule, nil
+}
+
+// consumeDescriptor adds a descriptor to the rule if it is valid.
+func (cp *CounterStyleParser) consumeDescriptor(rule *css.CounterStyleRule, d *descriptor.Descriptor) error {
+	apply, ok := descriptorParsers[d.Name]
+	if !ok {
+		return errors.New("unknown descriptor: " + d.Name)
+	}
+	if !apply(rule, css.StripComponentValueListWhitespace(d.Values)) {
+		return errors.New("invalid value for descriptor: " + d.Name)
+	}
+
+	rule.Descriptors = append(rule.Descriptors, d.Declaration(css.SerializeComponentValueList(d.Values)))
+	return nil
+}
+
+// checkSymbols checks that the rule has the symbols needed by its counter
+// system.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#counter-style-symbols
+func checkSymbols(rule *css.CounterStyleRule) error {
+	system := css.CounterStyleSystemSymbolic
+	if rule.System != nil {
+		system = rule.System.Type
+	}
+
+	switch system {
+	case css.CounterStyleSystemCyclic, css.CounterStyleSystemFixed, css.CounterStyleSystemSymbolic:
+		if len(rule.Symbols) == 0 {
+			return errors.New("invalid @counter-style rule: the " + system.String() + " system needs at least one symbol")
+		}
+
+	case css.CounterStyleSystemAlphabetic, css.CounterStyleSystemNumeric:
+		if len(rule.Symbols) < 2 {
+			return errors.New("invalid @counter-style rule: the " + system.String() + " system needs at least two symbols")
+		}
+
+	case css.CounterStyleSystemAdditive:
+		if len(rule.AdditiveSymbols) == 0 {
+			return errors.New("invalid @counter-style rule: the additive system needs additive symbols")
+		}
+
+	case css.CounterStyleSystemExtends:
+		if rule.Descriptor("symbols") != nil || rule.Descriptor("additive-symbols") != nil {
+			return errors.New("invalid @counter-style rule: the extends system can't have symbols")
+		}
+	}
+
+	return nil
+}
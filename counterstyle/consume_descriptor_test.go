@@ -0,0 +1,121 @@
+package counterstyle
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeCounterStyleDescriptors(t *testing.T) {
+	testcases := []struct {
+		name        string
+		input       string
+		expectError bool
+		expected    string
+	}{
+		{name: "symbolic by default", input: `symbols: "*" "†"`, expected: `@counter-style x { symbols: "*" "†"; }`},
+		{name: "cyclic", input: `system: CYCLIC; symbols: ◦;`, expected: `@counter-style x { system: CYCLIC; symbols: ◦; }`},
+		{name: "fixed with first value", input: `system: fixed 3; symbols: a b c`, expected: `@counter-style x { system: fixed 3; symbols: a b c; }`},
+		{name: "fixed with invalid first value", input: `system: fixed 1.5; system: numeric; symbols: "0" "1"`, expected: `@counter-style x { system: numeric; symbols: "0" "1"; }`},
+		{name: "numeric needs two symbols", input: `system: numeric; symbols: "0"`, expectError: true},
+		{name: "alphabetic", input: `system: alphabetic; symbols: a b`, expected: `@counter-style x { system: alphabetic; symbols: a b; }`},
+		{name: "missing symbols", input: `system: cyclic`, expectError: true},
+		{name: "image symbol", input: `symbols: url(a.png) linear-gradient(red, blue)`, expected: `@counter-style x { symbols: url(a.png) linear-gradient(red, blue); }`},
+		{name: "invalid symbol", input: `symbols: a 1; symbols: b`, expected: `@counter-style x { symbols: b; }`},
+		{name: "additive", input: `system: additive; additive-symbols: 10 X, 5 V, I 1`, expected: `@counter-style x { system: additive; additive-symbols: 10 X, 5 V, I 1; }`},
+		{name: "additive weights not decreasing", input: `system: additive; additive-symbols: 1 I, 5 V`, expectError: true},
+		{name: "additive negative weight", input: `system: additive; additive-symbols: -1 I`, expectError: true},
+		{name: "extends", input: `system: extends Decimal; suffix: ") "`, expected: `@counter-style x { system: extends Decimal; suffix: ") "; }`},
+		{name: "extends with symbols", input: `system: extends decimal; symbols: a`, expectError: true},
+		{name: "extends none", input: `system: extends none; symbols: a`, expected: `@counter-style x { symbols: a; }`},
+		{name: "range", input: `symbols: a; range: infinite -1, 1 INFINITE, 3 5`, expected: `@counter-style x { symbols: a; range: infinite -1, 1 INFINITE, 3 5; }`},
+		{name: "range auto", input: `symbols: a; range: auto`, expected: `@counter-style x { symbols: a; range: auto; }`},
+		{name: "reversed range", input: `symbols: a; range: 5 3`, expected: `@counter-style x { symbols: a; }`},
+		{name: "pad", input: `symbols: a; pad: 3 "0"; pad: "0" 2; pad: -1 "0"`, expected: `@counter-style x { symbols: a; pad: 3 "0"; pad: "0" 2; }`},
+		{name: "negative", input: `symbols: a; negative: "(" ")"; negative: a b c`, expected: `@counter-style x { symbols: a; negative: "(" ")"; }`},
+		{name: "prefix and suffix", input: `symbols: a; prefix: "["; suffix: "]" "]"`, expected: `@counter-style x { symbols: a; prefix: "["; }`},
+		{name: "fallback", input: `symbols: a; fallback: lower-ALPHA; fallback: none`, expected: `@counter-style x { symbols: a; fallback: lower-ALPHA; }`},
+		{name: "speak-as", input: `symbols: a; speak-as: bullets; speak-as: 1`, expected: `@counter-style x { symbols: a; speak-as: bullets; }`},
+		{name: "unknown and important descriptors", input: `color: red; symbols: a !important; symbols: b; { }`, expected: `@counter-style x { symbols: b; }`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			rule, err := ConsumeCounterStyleDescriptors(ts, "x", nil)
+
+			if next := ts.Peek(); next.Type != csslexer.EOFToken {
+				t.Errorf("expected EOF, got %v", next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got %q", rule.String())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func Test_ConsumeCounterStyleDescriptors_ParsedValues(t *testing.T) {
+	input := csslexer.NewInput(`system: extends Lower-Roman; range: 1 infinite; pad: 2 "0"; fallback: my-style`)
+	ts := token_stream.NewTokenStream(input)
+
+	rule, err := ConsumeCounterStyleDescriptors(ts, "x", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := rule.System.String(); got != "extends lower-roman" {
+		t.Errorf("expected system %q, got %q", "extends lower-roman", got)
+	}
+	if len(rule.Range) != 1 || rule.Range[0].String() != "1 infinite" || !rule.Range[0].Contains(100) {
+		t.Errorf("unexpected range %v", rule.Range)
+	}
+	if rule.Pad == nil || rule.Pad.String() != `2 "0"` {
+		t.Errorf("unexpected pad %v", rule.Pad)
+	}
+	if rule.Fallback != "my-style" {
+		t.Errorf("expected fallback %q, got %q", "my-style", rule.Fallback)
+	}
+}
+
+func Test_ParseCounterStyleName(t *testing.T) {
+	testcases := []struct {
+		input    string
+		expected string
+		ok       bool
+	}{
+		{"thumbs", "thumbs", true},
+		{"Thumbs", "Thumbs", true},
+		{"UPPER-ROMAN", "upper-roman", true},
+		{"none", "", false},
+		{"inherit", "", false},
+		{"'thumbs'", "", false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.input, func(t *testing.T) {
+			ts := token_stream.NewTokenStream(csslexer.NewInput(tc.input))
+
+			name, ok := ParseCounterStyleName(ts.ConsumeComponentValue())
+			if ok != tc.ok || name != tc.expected {
+				t.Errorf("expected %q, %v, got %q, %v", tc.expected, tc.ok, name, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,247 @@
+package counterstyle
+
+import (
+	"math"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/numeric"
+)
+
+// descriptorParsers maps the names of the @counter-style descriptors to a
+// function validating their value and storing it in the rule in its parsed
+// form, if it has one. The functions are given the value without
+// whitespace.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#the-counter-style-rule
+var descriptorParsers = map[string]func(rule *css.CounterStyleRule, values []*css.ComponentValue) bool{
+	"system":           parseSystem,
+	"negative":         parseNegative,
+	"prefix":           parseSingleSymbol,
+	"suffix":           parseSingleSymbol,
+	"range":            parseRange,
+	"pad":              parsePad,
+	"fallback":         parseFallback,
+	"symbols":          parseSymbols,
+	"additive-symbols": parseAdditiveSymbols,
+	"speak-as":         parseSpeakAs,
+}
+
+// parseSystem parses the system descriptor:
+//
+//	cyclic | numeric | alphabetic | symbolic | additive |
+//	[ fixed <integer>? ] | [ extends <counter-style-name> ]
+func parseSystem(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	if !values[0].IsToken(csslexer.IdentToken) {
+		return false
+	}
+
+	system := &css.CounterStyleSystem{}
+
+	switch strings.ToLower(values[0].Token.Value) {
+	case "cyclic":
+		system.Type = css.CounterStyleSystemCyclic
+	case "numeric":
+		system.Type = css.CounterStyleSystemNumeric
+	case "alphabetic":
+		system.Type = css.CounterStyleSystemAlphabetic
+	case "symbolic":
+		system.Type = css.CounterStyleSystemSymbolic
+	case "additive":
+		system.Type = css.CounterStyleSystemAdditive
+
+	case "fixed":
+		system.Type = css.CounterStyleSystemFixed
+		system.FirstSymbolValue = 1
+		if len(values) == 2 {
+			first, ok := numeric.ParseInteger(values[1].Token)
+			if !ok {
+				return false
+			}
+			system.FirstSymbolValue = first
+		}
+		if len(values) > 2 {
+			return false
+		}
+
+	case "extends":
+		if len(values) != 2 {
+			return false
+		}
+		name, ok := ParseCounterStyleName(values[1])
+		if !ok {
+			return false
+		}
+		system.Type = css.CounterStyleSystemExtends
+		system.Extends = name
+
+	default:
+		return false
+	}
+
+	if len(values) > 1 && system.Type != css.CounterStyleSystemFixed && system.Type != css.CounterStyleSystemExtends {
+		return false
+	}
+
+	rule.System = system
+	return true
+}
+
+// parseNegative validates the negative descriptor, <symbol> <symbol>?.
+func parseNegative(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	if len(values) > 2 {
+		return false
+	}
+
+	for _, value := range values {
+		if !isSymbol(value) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// parseSingleSymbol validates the prefix and suffix descriptors, a single
+// <symbol>.
+func parseSingleSymbol(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	return len(values) == 1 && isSymbol(values[0])
+}
+
+// parseRange parses the range descriptor:
+//
+//	[ [ <integer> | infinite ]{2} ]# | auto
+//
+// The lower bound of each range can't be greater than its upper bound.
+func parseRange(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	if len(values) == 1 && values[0].IsKeyword("auto") {
+		rule.Range = nil
+		return true
+	}
+
+	var ranges []*css.CounterStyleRange
+	for _, item := range css.SplitComponentValueList(values) {
+		if len(item) != 2 {
+			return false
+		}
+
+		lower, ok := parseRangeBound(item[0], math.MinInt)
+		if !ok {
+			return false
+		}
+		upper, ok := parseRangeBound(item[1], math.MaxInt)
+		if !ok || lower > upper {
+			return false
+		}
+
+		ranges = append(ranges, &css.CounterStyleRange{Lower: lower, Upper: upper})
+	}
+
+	rule.Range = ranges
+	return true
+}
+
+// parseRangeBound parses a bound of a range, an <integer> or "infinite"
+// which stands for the given value.
+func parseRangeBound(value *css.ComponentValue, infinite int) (int, bool) {
+	if value.IsKeyword("infinite") {
+		return infinite, true
+	}
+	return numeric.ParseInteger(value.Token)
+}
+
+// parsePad parses the pad descriptor, <integer [0,∞]> && <symbol>.
+func parsePad(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	weight, symbol, ok := parseWeightedSymbol(values)
+	if !ok {
+		return false
+	}
+
+	rule.Pad = &css.CounterStylePad{Length: weight, Symbol: symbol}
+	return true
+}
+
+// parseFallback parses the fallback descriptor, a <counter-style-name>.
+func parseFallback(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	if len(values) != 1 {
+		return false
+	}
+
+	name, ok := ParseCounterStyleName(values[0])
+	if !ok {
+		return false
+	}
+
+	rule.Fallback = name
+	return true
+}
+
+// parseSymbols parses the symbols descriptor, <symbol>+.
+func parseSymbols(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	for _, value := range values {
+		if !isSymbol(value) {
+			return false
+		}
+	}
+
+	rule.Symbols = values
+	return true
+}
+
+// parseAdditiveSymbols parses the additive-symbols descriptor:
+//
+//	[ <integer [0,∞]> && <symbol> ]#
+//
+// The tuples must be given in order of decreasing weight.
+func parseAdditiveSymbols(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	var symbols []*css.CounterStyleAdditiveSymbol
+	for _, item := range css.SplitComponentValueList(values) {
+		weight, symbol, ok := parseWeightedSymbol(item)
+		if !ok {
+			return false
+		}
+
+		if n := len(symbols); n > 0 && weight >= symbols[n-1].Weight {
+			return false
+		}
+
+		symbols = append(symbols, &css.CounterStyleAdditiveSymbol{Weight: weight, Symbol: symbol})
+	}
+
+	rule.AdditiveSymbols = symbols
+	return true
+}
+
+// parseWeightedSymbol parses a non-negative integer and a symbol, in any
+// order.
+func parseWeightedSymbol(values []*css.ComponentValue) (int, *css.ComponentValue, bool) {
+	if len(values) != 2 {
+		return 0, nil, false
+	}
+
+	weightValue, symbol := values[0], values[1]
+	if !weightValue.IsToken(csslexer.NumberToken) {
+		weightValue, symbol = symbol, weightValue
+	}
+
+	weight, ok := numeric.ParseInteger(weightValue.Token)
+	if !ok || weight < 0 || !isSymbol(symbol) {
+		return 0, nil, false
+	}
+
+	return weight, symbol, true
+}
+
+// parseSpeakAs validates the speak-as descriptor:
+//
+//	auto | bullets | numbers | words | spell-out | <counter-style-name>
+func parseSpeakAs(rule *css.CounterStyleRule, values []*css.ComponentValue) bool {
+	if len(values) != 1 {
+		return false
+	}
+
+	_, ok := ParseCounterStyleName(values[0])
+	return ok
+}
@@ -0,0 +1,34 @@
+package counterstyle
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+type CounterStyleParser struct {
+	tokenStream *token_stream.TokenStream
+	report      descriptor.ReportFunc
+}
+
+func NewCounterStyleParser(tokenStream *token_stream.TokenStream, report descriptor.ReportFunc) *CounterStyleParser {
+	return &CounterStyleParser{
+		tokenStream: tokenStream,
+		report:      report,
+	}
+}
+
+// ConsumeCounterStyleDescriptors consumes the contents of a @counter-style
+// block until the end of the token stream, and returns the rule defining
+// the counter style with the given name. Invalid and unknown descriptors
+// are dropped, and passed to report if it is not nil.
+//
+// An error is returned if the descriptors don't define a counter style,
+// e.g. if a numeric system has less than two symbols.
+func ConsumeCounterStyleDescriptors(
+	tokenStream *token_stream.TokenStream,
+	name string,
+	report descriptor.ReportFunc,
+) (*css.CounterStyleRule, error) {
+	return NewCounterStyleParser(tokenStream, report).consumeDescriptorList(name)
+}
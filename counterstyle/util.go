@@ -0,0 +1,96 @@
+package counterstyle
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// predefinedCounterStyles are the counter styles defined by CSS, whose
+// names are matched ASCII case-insensitively.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#predefined-counters
+var predefinedCounterStyles = map[string]bool{
+	"decimal": true, "decimal-leading-zero": true, "arabic-indic": true, "armenian": true,
+	"upper-armenian": true, "lower-armenian": true, "bengali": true, "cambodian": true,
+	"khmer": true, "cjk-decimal": true, "devanagari": true, "georgian": true,
+	"gujarati": true, "gurmukhi": true, "hebrew": true, "kannada": true, "lao": true,
+	"malayalam": true, "mongolian": true, "myanmar": true, "oriya": true, "persian": true,
+	"lower-roman": true, "upper-roman": true, "tamil": true, "telugu": true, "thai": true,
+	"tibetan": true, "lower-alpha": true, "lower-latin": true, "upper-alpha": true,
+	"upper-latin": true, "lower-greek": true, "hiragana": true, "hiragana-iroha": true,
+	"katakana": true, "katakana-iroha": true, "disc": true, "circle": true, "square": true,
+	"disclosure-open": true, "disclosure-closed": true, "cjk-earthly-branch": true,
+	"cjk-heavenly-stem": true, "japanese-informal": true, "japanese-formal": true,
+	"korean-hangul-formal": true, "korean-hanja-informal": true, "korean-hanja-formal": true,
+	"simp-chinese-informal": true, "simp-chinese-formal": true, "trad-chinese-informal": true,
+	"trad-chinese-formal": true, "ethiopic-numeric": true,
+}
+
+// ParseCounterStyleName parses a <counter-style-name>, a <custom-ident>
+// other than "none". Counter style names are case-sensitive, except for
+// the names of the predefined counter styles, which are lowercased.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#typedef-counter-style-name
+func ParseCounterStyleName(value *css.ComponentValue) (string, bool) {
+	if !value.IsToken(csslexer.IdentToken) {
+		return "", false
+	}
+
+	name := value.Token.Value
+	lower := strings.ToLower(name)
+	if lower == "none" || css.IsReservedCustomIdent(lower) {
+		return "", false
+	}
+	if predefinedCounterStyles[lower] {
+		return lower, true
+	}
+
+	return name, true
+}
+
+// IsNonOverridableCounterStyleName checks if a counter style name can't
+// be redefined by a @counter-style rule.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#the-counter-style-rule
+func IsNonOverridableCounterStyleName(name string) bool {
+	switch name {
+	case "decimal", "disc", "square", "circle", "disclosure-open", "disclosure-closed":
+		return true
+	default:
+		return false
+	}
+}
+
+// isSymbol checks if a component value is a <symbol>, i.e. a <string>, an
+// <image> or a <custom-ident>.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#typedef-symbol
+func isSymbol(value *css.ComponentValue) bool {
+	switch value.Type {
+	case css.ComponentValueTypePreservedToken:
+		switch value.Token.Type {
+		case csslexer.StringToken, csslexer.UrlToken:
+			return true
+		case csslexer.IdentToken:
+			return !css.IsReservedCustomIdent(value.Token.Value)
+		default:
+			return false
+		}
+
+	case css.ComponentValueTypeFunction:
+		switch strings.ToLower(value.Token.Value) {
+		case "url", "image", "image-set", "cross-fade", "element",
+			"linear-gradient", "radial-gradient", "conic-gradient",
+			"repeating-linear-gradient", "repeating-radial-gradient", "repeating-conic-gradient":
+			return true
+		default:
+			return false
+		}
+
+	default:
+		return false
+	}
+}
@@ -0,0 +1,141 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== AtRule =====
+
+// AtRule is implemented by every at-rule, e.g. MediaRule or the generic
+// GenericAtRule.
+type AtRule interface {
+	Rule
+}
+
+// serializeBlock returns the string representation of a {}-block holding
+// the given declarations and rules.
+func serializeBlock(declarations []*Declaration, rules []Rule) string {
+	var result strings.Builder
+
+	result.WriteString("{")
+	for _, decl := range declarations {
+		result.WriteString(" ")
+		result.WriteString(decl.String())
+		result.WriteString(";")
+	}
+	for _, rule := range rules {
+		result.WriteString(" ")
+		result.WriteString(rule.String())
+	}
+	result.WriteString(" }")
+
+	return result.String()
+}
+
+// serializeDeclarationList returns the string representation of a list of
+// declarations, each followed by a semicolon.
+func serializeDeclarationList(declarations []*Declaration) string {
+	declStrs := make([]string, 0, len(declarations))
+	for _, decl := range declarations {
+		declStrs = append(declStrs, decl.String()+";")
+	}
+	return strings.Join(declStrs, " ")
+}
+
+// styleRuleListEquals compares two lists of style rules, like the keyframe
+// rules of a @keyframes rule.
+func styleRuleListEquals(a, b []*StyleRule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, rule := range a {
+		if !rule.Equals(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// declarationListEquals compares two lists of declarations.
+func declarationListEquals(a, b []*Declaration) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, decl := range a {
+		if !decl.Equals(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// lastDeclaration returns the last declaration with the given property
+// name, or nil if there is none.
+func lastDeclaration(declarations []*Declaration, name string) *Declaration {
+	for i := len(declarations) - 1; i >= 0; i-- {
+		if strings.EqualFold(declarations[i].Property, name) {
+			return declarations[i]
+		}
+	}
+	return nil
+}
+
+// ===== GenericAtRule =====
+
+// GenericAtRule represents an at-rule in its generic form, i.e. a name,
+// a prelude and an optional block, all kept as component values.
+//
+// https://www.w3.org/TR/2021/CRD-css-syntax-3-20211224/#at-rule
+type GenericAtRule struct {
+	Node
+
+	Name    string            // The name of the at-rule, without the leading '@'
+	Prelude []*ComponentValue // The prelude of the at-rule, with surrounding whitespace trimmed
+	Block   *ComponentValue   // The {}-block of the at-rule, nil if the rule ended with ';'
+}
+
+// String returns the string representation of the at-rule.
+func (r *GenericAtRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@")
+	result.WriteString(cssutil.SerializeIdentifier(r.Name))
+
+	if len(r.Prelude) > 0 {
+		result.WriteString(" ")
+		result.WriteString(SerializeComponentValueList(r.Prelude))
+	}
+
+	if r.Block != nil {
+		result.WriteString(" ")
+		result.WriteString(r.Block.String())
+	} else {
+		result.WriteString(";")
+	}
+
+	return result.String()
+}
+
+// Equals compares two GenericAtRule instances.
+func (r *GenericAtRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*GenericAtRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	if r.Name != otherRule.Name || !ComponentValueListEquals(r.Prelude, otherRule.Prelude) {
+		return false
+	}
+
+	if r.Block == nil || otherRule.Block == nil {
+		return r.Block == nil && otherRule.Block == nil
+	}
+
+	return r.Block.Equals(otherRule.Block)
+}
@@ -0,0 +1,84 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestGenericAtRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *GenericAtRule
+		expected string
+	}{
+		{
+			name:     "statement without prelude",
+			rule:     &GenericAtRule{Name: "foo"},
+			expected: "@foo;",
+		},
+		{
+			name: "statement with prelude",
+			rule: &GenericAtRule{
+				Name: "tailwind",
+				Prelude: []*ComponentValue{
+					newTestToken(csslexer.IdentToken, "base"),
+				},
+			},
+			expected: "@tailwind base;",
+		},
+		{
+			name: "block",
+			rule: &GenericAtRule{
+				Name: "foo",
+				Prelude: []*ComponentValue{
+					newTestToken(csslexer.IdentToken, "bar"),
+				},
+				Block: &ComponentValue{
+					Type:  ComponentValueTypeSimpleBlock,
+					Token: csslexer.Token{Type: csslexer.LeftBraceToken, Value: "{"},
+				},
+			},
+			expected: "@foo bar {}",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestGenericAtRuleEquals(t *testing.T) {
+	block := &ComponentValue{
+		Type:  ComponentValueTypeSimpleBlock,
+		Token: csslexer.Token{Type: csslexer.LeftBraceToken, Value: "{"},
+	}
+	prelude := []*ComponentValue{newTestToken(csslexer.IdentToken, "bar")}
+
+	tests := []struct {
+		name     string
+		rule1    *GenericAtRule
+		rule2    AtRule
+		expected bool
+	}{
+		{"identical", &GenericAtRule{Name: "foo", Prelude: prelude, Block: block}, &GenericAtRule{Name: "foo", Prelude: prelude, Block: block}, true},
+		{"different names", &GenericAtRule{Name: "foo"}, &GenericAtRule{Name: "bar"}, false},
+		{"different preludes", &GenericAtRule{Name: "foo", Prelude: prelude}, &GenericAtRule{Name: "foo"}, false},
+		{"block and no block", &GenericAtRule{Name: "foo", Block: block}, &GenericAtRule{Name: "foo"}, false},
+		{"nil comparison", &GenericAtRule{Name: "foo"}, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule1.Equals(tt.rule2)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
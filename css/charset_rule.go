@@ -0,0 +1,33 @@
+package css
+
+import (
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== CharsetRule =====
+
+// CharsetRule represents a @charset rule at the start of a stylesheet,
+// e.g. '@charset "utf-8";'. The rule only matters to the decoding of the
+// stylesheet, which happens before it is parsed.
+//
+// https://www.w3.org/TR/css-syntax-3/#charset-rule
+type CharsetRule struct {
+	Node
+
+	Encoding string // The encoding label, as written
+}
+
+// String returns the string representation of the @charset rule.
+func (r *CharsetRule) String() string {
+	return "@charset " + cssutil.SerializeString(r.Encoding) + ";"
+}
+
+// Equals compares two CharsetRule instances.
+func (r *CharsetRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*CharsetRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Encoding == otherRule.Encoding
+}
@@ -0,0 +1,19 @@
+package css
+
+import "testing"
+
+func TestCharsetRule(t *testing.T) {
+	rule := &CharsetRule{Encoding: "utf-8"}
+
+	expected := `@charset "utf-8";`
+	if result := rule.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	if rule.Equals(&CharsetRule{Encoding: "latin1"}) {
+		t.Errorf("expected rules with different encodings to differ")
+	}
+	if !rule.Equals(&CharsetRule{Encoding: "utf-8"}) {
+		t.Errorf("expected rules with the same encoding to be equal")
+	}
+}
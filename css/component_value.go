@@ -0,0 +1,182 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+// ===== ComponentValueType =====
+
+type ComponentValueType int
+
+const (
+	ComponentValueTypePreservedToken ComponentValueType = iota // Any token except {, ( , [ and function tokens
+	ComponentValueTypeFunction                                 // Example: calc(1px + 2px)
+	ComponentValueTypeSimpleBlock                              // Example: { color: red }, (min-width: 0), [name]
+)
+
+func (cvt ComponentValueType) String() string {
+	switch cvt {
+	case ComponentValueTypePreservedToken:
+		return "PreservedToken"
+	case ComponentValueTypeFunction:
+		return "Function"
+	case ComponentValueTypeSimpleBlock:
+		return "SimpleBlock"
+	default:
+		return "Unknown"
+	}
+}
+
+// ===== ComponentValue =====
+
+// ComponentValue represents a component value as defined by css-syntax.
+//
+// For preserved tokens, Token holds the token itself. For functions and
+// simple blocks, Token holds the opening token (the function token or one
+// of {, ( and [) and Children holds the component values inside.
+//
+// https://www.w3.org/TR/2021/CRD-css-syntax-3-20211224/#component-value
+type ComponentValue struct {
+	Type     ComponentValueType // The type of the component value
+	Token    csslexer.Token     // The preserved token, or the opening token
+	Children []*ComponentValue  // The contents of a function or simple block
+}
+
+// String returns the string representation of the component value.
+func (cv *ComponentValue) String() string {
+	var result strings.Builder
+
+	result.WriteString(cv.Token.String())
+
+	if cv.Type == ComponentValueTypePreservedToken {
+		return result.String()
+	}
+
+	result.WriteString(SerializeComponentValueList(cv.Children))
+	result.WriteString(cv.closingString())
+
+	return result.String()
+}
+
+// closingString returns the string closing a function or a simple block.
+func (cv *ComponentValue) closingString() string {
+	switch cv.Token.Type {
+	case csslexer.LeftBraceToken:
+		return "}"
+	case csslexer.LeftBracketToken:
+		return "]"
+	default:
+		return ")"
+	}
+}
+
+// Equals compares two ComponentValue instances.
+//
+// Tokens are compared by their type and value, the raw text is ignored.
+func (cv *ComponentValue) Equals(other *ComponentValue) bool {
+	if other == nil {
+		return false
+	}
+
+	if cv.Type != other.Type ||
+		cv.Token.Type != other.Token.Type ||
+		cv.Token.Value != other.Token.Value {
+		return false
+	}
+
+	return ComponentValueListEquals(cv.Children, other.Children)
+}
+
+// IsWhitespace returns true if the component value is a whitespace token.
+func (cv *ComponentValue) IsWhitespace() bool {
+	return cv.Type == ComponentValueTypePreservedToken &&
+		cv.Token.Type == csslexer.WhitespaceToken
+}
+
+// IsToken returns true if the component value is a preserved token of the
+// given type.
+func (cv *ComponentValue) IsToken(tokenType csslexer.TokenType) bool {
+	return cv.Type == ComponentValueTypePreservedToken && cv.Token.Type == tokenType
+}
+
+// IsKeyword returns true if the component value is the given identifier,
+// ignoring ASCII case.
+func (cv *ComponentValue) IsKeyword(keyword string) bool {
+	return cv.IsToken(csslexer.IdentToken) && strings.EqualFold(cv.Token.Value, keyword)
+}
+
+// IsFunction returns true if the component value is a function with the
+// given name, ignoring ASCII case.
+func (cv *ComponentValue) IsFunction(name string) bool {
+	return cv.Type == ComponentValueTypeFunction && strings.EqualFold(cv.Token.Value, name)
+}
+
+// SerializeComponentValueList returns the string representation of a list
+// of component values.
+func SerializeComponentValueList(values []*ComponentValue) string {
+	var result strings.Builder
+
+	for _, value := range values {
+		result.WriteString(value.String())
+	}
+
+	return result.String()
+}
+
+// ComponentValueListEquals compares two lists of component values.
+func ComponentValueListEquals(a, b []*ComponentValue) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, value := range a {
+		if !value.Equals(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// TrimComponentValueList returns the list without its leading and trailing
+// whitespace tokens.
+func TrimComponentValueList(values []*ComponentValue) []*ComponentValue {
+	for len(values) > 0 && values[0].IsWhitespace() {
+		values = values[1:]
+	}
+	for len(values) > 0 && values[len(values)-1].IsWhitespace() {
+		values = values[:len(values)-1]
+	}
+	return values
+}
+
+// StripComponentValueListWhitespace returns the component values of the
+// list which are not whitespace tokens.
+func StripComponentValueListWhitespace(values []*ComponentValue) []*ComponentValue {
+	result := make([]*ComponentValue, 0, len(values))
+	for _, value := range values {
+		if !value.IsWhitespace() {
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// SplitComponentValueList splits a list of component values on its
+// top-level commas, trimming the whitespace around each item.
+func SplitComponentValueList(values []*ComponentValue) [][]*ComponentValue {
+	var items [][]*ComponentValue
+
+	start := 0
+	for i, value := range values {
+		if value.IsToken(csslexer.CommaToken) {
+			items = append(items, TrimComponentValueList(values[start:i]))
+			start = i + 1
+		}
+	}
+	items = append(items, TrimComponentValueList(values[start:]))
+
+	return items
+}
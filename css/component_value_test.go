@@ -0,0 +1,229 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestComponentValueType(t *testing.T) {
+	tests := []struct {
+		name      string
+		valueType ComponentValueType
+		expected  string
+	}{
+		{"preserved token", ComponentValueTypePreservedToken, "PreservedToken"},
+		{"function", ComponentValueTypeFunction, "Function"},
+		{"simple block", ComponentValueTypeSimpleBlock, "SimpleBlock"},
+		{"unknown", ComponentValueType(-1), "Unknown"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.valueType.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func newTestToken(tokenType csslexer.TokenType, value string) *ComponentValue {
+	return &ComponentValue{
+		Type:  ComponentValueTypePreservedToken,
+		Token: csslexer.Token{Type: tokenType, Value: value},
+	}
+}
+
+func TestComponentValueString(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    *ComponentValue
+		expected string
+	}{
+		{
+			name:     "ident",
+			value:    newTestToken(csslexer.IdentToken, "screen"),
+			expected: "screen",
+		},
+		{
+			name: "function",
+			value: &ComponentValue{
+				Type:  ComponentValueTypeFunction,
+				Token: csslexer.Token{Type: csslexer.FunctionToken, Value: "calc"},
+				Children: []*ComponentValue{
+					newTestToken(csslexer.NumberToken, "1"),
+				},
+			},
+			expected: "calc(1)",
+		},
+		{
+			name: "parenthesis block",
+			value: &ComponentValue{
+				Type:  ComponentValueTypeSimpleBlock,
+				Token: csslexer.Token{Type: csslexer.LeftParenthesisToken, Value: "("},
+				Children: []*ComponentValue{
+					newTestToken(csslexer.IdentToken, "color"),
+				},
+			},
+			expected: "(color)",
+		},
+		{
+			name: "brace block",
+			value: &ComponentValue{
+				Type:  ComponentValueTypeSimpleBlock,
+				Token: csslexer.Token{Type: csslexer.LeftBraceToken, Value: "{"},
+				Children: []*ComponentValue{
+					newTestToken(csslexer.WhitespaceToken, " "),
+				},
+			},
+			expected: "{ }",
+		},
+		{
+			name: "bracket block",
+			value: &ComponentValue{
+				Type:  ComponentValueTypeSimpleBlock,
+				Token: csslexer.Token{Type: csslexer.LeftBracketToken, Value: "["},
+				Children: []*ComponentValue{
+					newTestToken(csslexer.IdentToken, "name"),
+				},
+			},
+			expected: "[name]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.value.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestComponentValueEquals(t *testing.T) {
+	block := func(children ...*ComponentValue) *ComponentValue {
+		return &ComponentValue{
+			Type:     ComponentValueTypeSimpleBlock,
+			Token:    csslexer.Token{Type: csslexer.LeftParenthesisToken, Value: "("},
+			Children: children,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		value1   *ComponentValue
+		value2   *ComponentValue
+		expected bool
+	}{
+		{"same token", newTestToken(csslexer.IdentToken, "a"), newTestToken(csslexer.IdentToken, "a"), true},
+		{"different value", newTestToken(csslexer.IdentToken, "a"), newTestToken(csslexer.IdentToken, "b"), false},
+		{"different token type", newTestToken(csslexer.IdentToken, "a"), newTestToken(csslexer.StringToken, "a"), false},
+		{"same block", block(newTestToken(csslexer.IdentToken, "a")), block(newTestToken(csslexer.IdentToken, "a")), true},
+		{"different children", block(newTestToken(csslexer.IdentToken, "a")), block(), false},
+		{"nil comparison", newTestToken(csslexer.IdentToken, "a"), nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.value1.Equals(tt.value2)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTrimComponentValueList(t *testing.T) {
+	ws := newTestToken(csslexer.WhitespaceToken, " ")
+	a := newTestToken(csslexer.IdentToken, "a")
+	b := newTestToken(csslexer.IdentToken, "b")
+
+	tests := []struct {
+		name     string
+		values   []*ComponentValue
+		expected []*ComponentValue
+	}{
+		{"no whitespace", []*ComponentValue{a, b}, []*ComponentValue{a, b}},
+		{"surrounding whitespace", []*ComponentValue{ws, a, ws, b, ws}, []*ComponentValue{a, ws, b}},
+		{"only whitespace", []*ComponentValue{ws, ws}, nil},
+		{"empty", nil, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := TrimComponentValueList(tt.values)
+			if !ComponentValueListEquals(result, tt.expected) {
+				t.Errorf("expected %q, got %q", SerializeComponentValueList(tt.expected), SerializeComponentValueList(result))
+			}
+		})
+	}
+}
+
+func TestStripComponentValueListWhitespace(t *testing.T) {
+	ws := newTestToken(csslexer.WhitespaceToken, " ")
+	a := newTestToken(csslexer.IdentToken, "a")
+	b := newTestToken(csslexer.IdentToken, "b")
+
+	result := StripComponentValueListWhitespace([]*ComponentValue{ws, a, ws, ws, b, ws})
+	if expected := []*ComponentValue{a, b}; !ComponentValueListEquals(result, expected) {
+		t.Errorf("expected %q, got %q", SerializeComponentValueList(expected), SerializeComponentValueList(result))
+	}
+}
+
+func TestSplitComponentValueList(t *testing.T) {
+	ws := newTestToken(csslexer.WhitespaceToken, " ")
+	comma := newTestToken(csslexer.CommaToken, ",")
+	a := newTestToken(csslexer.IdentToken, "a")
+	b := newTestToken(csslexer.IdentToken, "b")
+	fn := &ComponentValue{
+		Type:     ComponentValueTypeFunction,
+		Token:    csslexer.Token{Type: csslexer.FunctionToken, Value: "f"},
+		Children: []*ComponentValue{a, comma, b},
+	}
+
+	tests := []struct {
+		name     string
+		values   []*ComponentValue
+		expected []string
+	}{
+		{"single item", []*ComponentValue{a, ws, b}, []string{"a b"}},
+		{"items are trimmed", []*ComponentValue{ws, a, ws, comma, ws, b, ws}, []string{"a", "b"}},
+		{"empty items", []*ComponentValue{comma, a, comma}, []string{"", "a", ""}},
+		{"nested commas", []*ComponentValue{fn, comma, a}, []string{"f(a,b)", "a"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			items := SplitComponentValueList(tt.values)
+			if len(items) != len(tt.expected) {
+				t.Fatalf("expected %d items, got %d", len(tt.expected), len(items))
+			}
+			for i, item := range items {
+				if result := SerializeComponentValueList(item); result != tt.expected[i] {
+					t.Errorf("item %d: expected %q, got %q", i, tt.expected[i], result)
+				}
+			}
+		})
+	}
+}
+
+func TestComponentValueIsKeyword(t *testing.T) {
+	ident := newTestToken(csslexer.IdentToken, "Auto")
+	str := newTestToken(csslexer.StringToken, "auto")
+	fn := &ComponentValue{
+		Type:  ComponentValueTypeFunction,
+		Token: csslexer.Token{Type: csslexer.FunctionToken, Value: "URL"},
+	}
+
+	if !ident.IsKeyword("auto") || str.IsKeyword("auto") {
+		t.Errorf("expected only the identifier to be the keyword")
+	}
+	if !str.IsToken(csslexer.StringToken) || fn.IsToken(csslexer.FunctionToken) {
+		t.Errorf("expected only the string to be a preserved token")
+	}
+	if !fn.IsFunction("url") || ident.IsFunction("auto") {
+		t.Errorf("expected only the function to be the function")
+	}
+}
@@ -0,0 +1,173 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== ContainerQuery =====
+
+// ContainerQuery represents an entry of the prelude of a @container rule,
+// i.e. an optional container name and an optional container condition. At
+// least one of them is present.
+//
+// https://www.w3.org/TR/css-conditional-5/#container-rule
+type ContainerQuery struct {
+	Node
+
+	Name      string              // The container name, empty if omitted
+	Condition *ContainerCondition // The container condition, nil if omitted
+}
+
+func (q *ContainerQuery) String() string {
+	var result strings.Builder
+
+	if q.Name != "" {
+		result.WriteString(cssutil.SerializeIdentifier(q.Name))
+		if q.Condition != nil {
+			result.WriteString(" ")
+		}
+	}
+
+	if q.Condition != nil {
+		result.WriteString(q.Condition.String())
+	}
+
+	return result.String()
+}
+
+func (q *ContainerQuery) Equals(other *ContainerQuery) bool {
+	if other == nil || q.Name != other.Name {
+		return false
+	}
+
+	if q.Condition == nil || other.Condition == nil {
+		return q.Condition == nil && other.Condition == nil
+	}
+
+	return q.Condition.Equals(other.Condition)
+}
+
+// ===== ContainerConditionType =====
+
+type ContainerConditionType int
+
+const (
+	ContainerConditionFeature         ContainerConditionType = iota // Example: (min-width: 400px)
+	ContainerConditionNot                                           // Example: not (width > 400px)
+	ContainerConditionAnd                                           // Example: (width > 400px) and (height > 400px)
+	ContainerConditionOr                                            // Example: (width > 400px) or (orientation: portrait)
+	ContainerConditionStyle                                         // Example: style(--theme: dark)
+	ContainerConditionStyleFeature                                  // Example: --theme: dark, inside style()
+	ContainerConditionScrollState                                   // Example: scroll-state(stuck: top)
+	ContainerConditionGeneralEnclosed                               // Example: (unknown-thing), foo(bar)
+)
+
+// ===== ContainerCondition =====
+
+// ContainerCondition represents a node of a container condition tree.
+//
+// The queries inside style() and scroll-state() are the only child of the
+// corresponding node. Size features and scroll-state features are stored
+// as media features, since they share the same syntax.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-container-condition
+type ContainerCondition struct {
+	Node
+
+	Type            ContainerConditionType // The type of the condition node
+	Children        []*ContainerCondition  // The operands of not, and & or conditions, or the query of style() and scroll-state()
+	Feature         *MediaFeature          // The size or scroll-state feature, for feature conditions
+	Property        string                 // The custom property name, for style features
+	Value           []*ComponentValue      // The value of the custom property, for style features, nil if omitted
+	GeneralEnclosed *ComponentValue        // The function or block, for general-enclosed conditions
+}
+
+func (c *ContainerCondition) String() string {
+	switch c.Type {
+	case ContainerConditionFeature:
+		return c.Feature.String()
+
+	case ContainerConditionNot:
+		return "not " + c.Children[0].operandString()
+
+	case ContainerConditionAnd, ContainerConditionOr:
+		separator := " and "
+		if c.Type == ContainerConditionOr {
+			separator = " or "
+		}
+
+		operandStrs := make([]string, 0, len(c.Children))
+		for _, child := range c.Children {
+			operandStrs = append(operandStrs, child.operandString())
+		}
+		return strings.Join(operandStrs, separator)
+
+	case ContainerConditionStyle:
+		return "style(" + c.Children[0].queryString() + ")"
+
+	case ContainerConditionStyleFeature:
+		if c.Value == nil {
+			return cssutil.SerializeIdentifier(c.Property)
+		}
+		return cssutil.SerializeIdentifier(c.Property) + ": " + SerializeComponentValueList(c.Value)
+
+	case ContainerConditionScrollState:
+		return "scroll-state(" + c.Children[0].queryString() + ")"
+
+	case ContainerConditionGeneralEnclosed:
+		return c.GeneralEnclosed.String()
+
+	default:
+		return ""
+	}
+}
+
+// operandString returns the string representation of the condition when
+// it is an operand of another condition, wrapping it in parentheses if
+// needed.
+func (c *ContainerCondition) operandString() string {
+	switch c.Type {
+	case ContainerConditionNot, ContainerConditionAnd, ContainerConditionOr, ContainerConditionStyleFeature:
+		return "(" + c.String() + ")"
+	default:
+		return c.String()
+	}
+}
+
+// queryString returns the string representation of the condition when it
+// is the query of style() or scroll-state(), where a single feature is
+// written without parentheses.
+func (c *ContainerCondition) queryString() string {
+	if c.Type == ContainerConditionFeature {
+		feature := c.Feature.String()
+		return feature[1 : len(feature)-1]
+	}
+	return c.String()
+}
+
+func (c *ContainerCondition) Equals(other *ContainerCondition) bool {
+	if other == nil || c.Type != other.Type || len(c.Children) != len(other.Children) {
+		return false
+	}
+
+	for i, child := range c.Children {
+		if !child.Equals(other.Children[i]) {
+			return false
+		}
+	}
+
+	switch c.Type {
+	case ContainerConditionFeature:
+		return c.Feature.Equals(other.Feature)
+	case ContainerConditionStyleFeature:
+		return c.Property == other.Property &&
+			(c.Value == nil) == (other.Value == nil) &&
+			ComponentValueListEquals(c.Value, other.Value)
+	case ContainerConditionGeneralEnclosed:
+		return c.GeneralEnclosed.Equals(other.GeneralEnclosed)
+	default:
+		return true
+	}
+}
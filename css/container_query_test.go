@@ -0,0 +1,109 @@
+package css
+
+import "testing"
+
+func TestContainerQueryString(t *testing.T) {
+	widthFeature := &ContainerCondition{
+		Type: ContainerConditionFeature,
+		Feature: &MediaFeature{
+			Type:            MediaFeatureRange,
+			Name:            "width",
+			Right:           &MediaFeatureValue{Type: MediaFeatureValueDimension, Number: 400, Unit: "px"},
+			RightComparison: MediaComparisonGreater,
+		},
+	}
+	styleFeature := &ContainerCondition{
+		Type:     ContainerConditionStyleFeature,
+		Property: "--theme",
+		Value:    []*ComponentValue{},
+	}
+
+	tests := []struct {
+		name     string
+		query    *ContainerQuery
+		expected string
+	}{
+		{
+			name:     "name only",
+			query:    &ContainerQuery{Name: "sidebar"},
+			expected: "sidebar",
+		},
+		{
+			name:     "condition only",
+			query:    &ContainerQuery{Condition: widthFeature},
+			expected: "(width > 400px)",
+		},
+		{
+			name: "name and not condition",
+			query: &ContainerQuery{
+				Name:      "card",
+				Condition: &ContainerCondition{Type: ContainerConditionNot, Children: []*ContainerCondition{widthFeature}},
+			},
+			expected: "card not (width > 400px)",
+		},
+		{
+			name: "style feature with empty value",
+			query: &ContainerQuery{
+				Condition: &ContainerCondition{Type: ContainerConditionStyle, Children: []*ContainerCondition{styleFeature}},
+			},
+			expected: "style(--theme: )",
+		},
+		{
+			name: "style condition",
+			query: &ContainerQuery{
+				Condition: &ContainerCondition{
+					Type: ContainerConditionStyle,
+					Children: []*ContainerCondition{
+						{Type: ContainerConditionOr, Children: []*ContainerCondition{styleFeature, {Type: ContainerConditionStyleFeature, Property: "--a"}}},
+					},
+				},
+			},
+			expected: "style((--theme: ) or (--a))",
+		},
+		{
+			name: "scroll-state feature",
+			query: &ContainerQuery{
+				Condition: &ContainerCondition{
+					Type: ContainerConditionScrollState,
+					Children: []*ContainerCondition{
+						{Type: ContainerConditionFeature, Feature: &MediaFeature{Type: MediaFeatureBoolean, Name: "scrollable"}},
+					},
+				},
+			},
+			expected: "scroll-state(scrollable)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.query.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestContainerConditionEquals(t *testing.T) {
+	condition := &ContainerCondition{Type: ContainerConditionStyleFeature, Property: "--a"}
+
+	tests := []struct {
+		name     string
+		other    *ContainerCondition
+		expected bool
+	}{
+		{"equal", &ContainerCondition{Type: ContainerConditionStyleFeature, Property: "--a"}, true},
+		{"different property", &ContainerCondition{Type: ContainerConditionStyleFeature, Property: "--b"}, false},
+		{"empty value", &ContainerCondition{Type: ContainerConditionStyleFeature, Property: "--a", Value: []*ComponentValue{}}, false},
+		{"different type", &ContainerCondition{Type: ContainerConditionGeneralEnclosed}, false},
+		{"nil", nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := condition.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
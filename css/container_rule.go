@@ -0,0 +1,45 @@
+package css
+
+import (
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== ContainerRule =====
+
+// ContainerRule represents a @container rule.
+//
+// https://www.w3.org/TR/css-conditional-5/#container-rule
+type ContainerRule struct {
+	Node
+
+	Queries      []*ContainerQuery // The comma-separated container queries of the rule
+	Declarations []*Declaration    // Declarations directly inside a @container nested in a style rule
+	Rules        []Rule            // Child rules
+}
+
+// String returns the string representation of the @container rule.
+func (r *ContainerRule) String() string {
+	queryStrs := make([]string, 0, len(r.Queries))
+	for _, query := range r.Queries {
+		queryStrs = append(queryStrs, query.String())
+	}
+
+	return "@container " + cssutil.SerializeCommaSeparatedList(queryStrs) + " " + serializeBlock(r.Declarations, r.Rules)
+}
+
+// Equals compares two ContainerRule instances.
+func (r *ContainerRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*ContainerRule)
+	if !ok || otherRule == nil || len(r.Queries) != len(otherRule.Queries) {
+		return false
+	}
+
+	for i, query := range r.Queries {
+		if !query.Equals(otherRule.Queries[i]) {
+			return false
+		}
+	}
+
+	return declarationListEquals(r.Declarations, otherRule.Declarations) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
@@ -0,0 +1,172 @@
+package css
+
+import (
+	"math"
+	"strconv"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== CounterStyleRule =====
+
+// CounterStyleRule represents a @counter-style rule, which defines a custom
+// counter style.
+//
+// Only valid descriptors are kept. The values of the system, symbols,
+// additive-symbols, range, pad and fallback descriptors are also available
+// in their parsed form.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#the-counter-style-rule
+type CounterStyleRule struct {
+	Node
+
+	Name            string                        // The name of the counter style
+	Descriptors     []*Declaration                // The valid descriptors, in source order
+	System          *CounterStyleSystem           // The parsed value of the last system descriptor, nil if omitted
+	Symbols         []*ComponentValue             // The parsed value of the last symbols descriptor
+	AdditiveSymbols []*CounterStyleAdditiveSymbol // The parsed value of the last additive-symbols descriptor
+	Range           []*CounterStyleRange          // The parsed value of the last range descriptor, nil for auto
+	Pad             *CounterStylePad              // The parsed value of the last pad descriptor, nil if omitted
+	Fallback        string                        // The name of the fallback counter style, empty if omitted
+}
+
+// String returns the string representation of the @counter-style rule.
+func (r *CounterStyleRule) String() string {
+	return "@counter-style " + cssutil.SerializeIdentifier(r.Name) + " " + serializeBlock(r.Descriptors, nil)
+}
+
+// Equals compares two CounterStyleRule instances.
+//
+// The parsed descriptor values are derived from the descriptors, so only
+// the name and the descriptors are compared.
+func (r *CounterStyleRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*CounterStyleRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Name == otherRule.Name && declarationListEquals(r.Descriptors, otherRule.Descriptors)
+}
+
+// Descriptor returns the last descriptor with the given name, or nil if
+// there is none.
+func (r *CounterStyleRule) Descriptor(name string) *Declaration {
+	return lastDeclaration(r.Descriptors, name)
+}
+
+// ===== CounterStyleSystemType =====
+
+type CounterStyleSystemType int
+
+const (
+	CounterStyleSystemSymbolic   CounterStyleSystemType = iota // Example: symbolic
+	CounterStyleSystemCyclic                                   // Example: cyclic
+	CounterStyleSystemNumeric                                  // Example: numeric
+	CounterStyleSystemAlphabetic                               // Example: alphabetic
+	CounterStyleSystemAdditive                                 // Example: additive
+	CounterStyleSystemFixed                                    // Example: fixed 3
+	CounterStyleSystemExtends                                  // Example: extends decimal
+)
+
+func (t CounterStyleSystemType) String() string {
+	switch t {
+	case CounterStyleSystemSymbolic:
+		return "symbolic"
+	case CounterStyleSystemCyclic:
+		return "cyclic"
+	case CounterStyleSystemNumeric:
+		return "numeric"
+	case CounterStyleSystemAlphabetic:
+		return "alphabetic"
+	case CounterStyleSystemAdditive:
+		return "additive"
+	case CounterStyleSystemFixed:
+		return "fixed"
+	case CounterStyleSystemExtends:
+		return "extends"
+	default:
+		return ""
+	}
+}
+
+// ===== CounterStyleSystem =====
+
+// CounterStyleSystem represents the value of the system descriptor of a
+// @counter-style rule.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#counter-style-system
+type CounterStyleSystem struct {
+	Type             CounterStyleSystemType // The counter system
+	FirstSymbolValue int                    // The value of the first symbol, for the fixed system
+	Extends          string                 // The name of the extended counter style, for the extends system
+}
+
+func (s *CounterStyleSystem) String() string {
+	switch s.Type {
+	case CounterStyleSystemFixed:
+		return "fixed " + strconv.Itoa(s.FirstSymbolValue)
+	case CounterStyleSystemExtends:
+		return "extends " + cssutil.SerializeIdentifier(s.Extends)
+	default:
+		return s.Type.String()
+	}
+}
+
+// ===== CounterStyleAdditiveSymbol =====
+
+// CounterStyleAdditiveSymbol represents an additive tuple of the
+// additive-symbols descriptor, e.g. "10 X".
+//
+// https://www.w3.org/TR/css-counter-styles-3/#counter-style-symbols
+type CounterStyleAdditiveSymbol struct {
+	Weight int             // The non-negative weight of the tuple
+	Symbol *ComponentValue // The counter symbol
+}
+
+func (s *CounterStyleAdditiveSymbol) String() string {
+	return strconv.Itoa(s.Weight) + " " + s.Symbol.String()
+}
+
+// ===== CounterStyleRange =====
+
+// CounterStyleRange represents an inclusive range of the range descriptor.
+//
+// An infinite lower bound is represented by math.MinInt, and an infinite
+// upper bound by math.MaxInt.
+//
+// https://www.w3.org/TR/css-counter-styles-3/#counter-style-range
+type CounterStyleRange struct {
+	Lower int // The lower bound of the range
+	Upper int // The upper bound of the range
+}
+
+func (r *CounterStyleRange) String() string {
+	lower, upper := "infinite", "infinite"
+	if r.Lower != math.MinInt {
+		lower = strconv.Itoa(r.Lower)
+	}
+	if r.Upper != math.MaxInt {
+		upper = strconv.Itoa(r.Upper)
+	}
+	return lower + " " + upper
+}
+
+// Contains checks if a counter value is in the range.
+func (r *CounterStyleRange) Contains(value int) bool {
+	return value >= r.Lower && value <= r.Upper
+}
+
+// ===== CounterStylePad =====
+
+// CounterStylePad represents the value of the pad descriptor, e.g.
+// "3 '0'".
+//
+// https://www.w3.org/TR/css-counter-styles-3/#counter-style-pad
+type CounterStylePad struct {
+	Length int             // The minimum length of the representation
+	Symbol *ComponentValue // The symbol used for padding
+}
+
+func (p *CounterStylePad) String() string {
+	return strconv.Itoa(p.Length) + " " + p.Symbol.String()
+}
@@ -0,0 +1,93 @@
+package css
+
+import (
+	"math"
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestCounterStyleRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *CounterStyleRule
+		expected string
+	}{
+		{
+			name:     "empty",
+			rule:     &CounterStyleRule{Name: "thumbs"},
+			expected: "@counter-style thumbs { }",
+		},
+		{
+			name: "descriptors",
+			rule: &CounterStyleRule{
+				Name:        "thumbs",
+				Descriptors: []*Declaration{{Property: "system", Value: "cyclic"}, {Property: "symbols", Value: `"👍"`}},
+			},
+			expected: `@counter-style thumbs { system: cyclic; symbols: "👍"; }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCounterStyleRuleEquals(t *testing.T) {
+	newRule := func(name string) *CounterStyleRule {
+		return &CounterStyleRule{
+			Name:        name,
+			Descriptors: []*Declaration{{Property: "symbols", Value: "a"}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{"equal", newRule("x"), true},
+		{"different name", newRule("y"), false},
+		{"different descriptors", &CounterStyleRule{Name: "x"}, false},
+		{"different type", &FontPaletteValuesRule{Name: "x"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newRule("x").Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCounterStyleValuesString(t *testing.T) {
+	symbol := newTestToken(csslexer.StringToken, "0")
+
+	tests := []struct {
+		name     string
+		value    interface{ String() string }
+		expected string
+	}{
+		{"system", &CounterStyleSystem{Type: CounterStyleSystemAlphabetic}, "alphabetic"},
+		{"fixed system", &CounterStyleSystem{Type: CounterStyleSystemFixed, FirstSymbolValue: -2}, "fixed -2"},
+		{"extends system", &CounterStyleSystem{Type: CounterStyleSystemExtends, Extends: "decimal"}, "extends decimal"},
+		{"range", &CounterStyleRange{Lower: 1, Upper: 10}, "1 10"},
+		{"infinite range", &CounterStyleRange{Lower: math.MinInt, Upper: math.MaxInt}, "infinite infinite"},
+		{"pad", &CounterStylePad{Length: 3, Symbol: symbol}, `3 "0"`},
+		{"additive symbol", &CounterStyleAdditiveSymbol{Weight: 10, Symbol: symbol}, `10 "0"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.value.String(); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
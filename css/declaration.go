@@ -6,9 +6,15 @@ import (
 
 // Declaration represents a CSS property declaration (property: value)
 type Declaration struct {
+	Node
+
 	Property  string // CSS property name
 	Value     string // CSS property value (unparsed)
 	Important bool   // Whether the declaration has !important
+
+	PropertySpan  Span // Span of the property name
+	ValueSpan     Span // Span of the value, without the surrounding whitespace
+	ImportantSpan Span // Span of the "!important", zero if not important
 }
 
 // String returns the string representation of the declaration
@@ -0,0 +1,160 @@
+package css
+
+import (
+	"fmt"
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== FontFaceRule =====
+
+// FontFaceRule represents a @font-face rule.
+//
+// Only valid descriptors are kept. The values of the src and unicode-range
+// descriptors are also available in their parsed form.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-face-rule
+type FontFaceRule struct {
+	Node
+
+	Descriptors  []*Declaration    // The valid descriptors, in source order
+	Src          []*FontFaceSource // The parsed value of the last src descriptor
+	UnicodeRange []*UnicodeRange   // The parsed value of the last unicode-range descriptor
+}
+
+// String returns the string representation of the @font-face rule.
+func (r *FontFaceRule) String() string {
+	return "@font-face " + serializeBlock(r.Descriptors, nil)
+}
+
+// Equals compares two FontFaceRule instances.
+func (r *FontFaceRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*FontFaceRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	if !declarationListEquals(r.Descriptors, otherRule.Descriptors) ||
+		len(r.Src) != len(otherRule.Src) ||
+		len(r.UnicodeRange) != len(otherRule.UnicodeRange) {
+		return false
+	}
+
+	for i, source := range r.Src {
+		if !source.Equals(otherRule.Src[i]) {
+			return false
+		}
+	}
+
+	for i, urange := range r.UnicodeRange {
+		if *urange != *otherRule.UnicodeRange[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Descriptor returns the last descriptor with the given name, or nil if
+// there is none.
+func (r *FontFaceRule) Descriptor(name string) *Declaration {
+	return lastDeclaration(r.Descriptors, name)
+}
+
+// ===== FontFaceSourceType =====
+
+type FontFaceSourceType int
+
+const (
+	FontFaceSourceURL   FontFaceSourceType = iota // Example: url(font.woff2) format(woff2)
+	FontFaceSourceLocal                           // Example: local("Helvetica Neue")
+)
+
+// ===== FontFaceSource =====
+
+// FontFaceSource represents an entry of the src descriptor of a @font-face
+// rule.
+//
+// https://www.w3.org/TR/css-fonts-4/#src-desc
+type FontFaceSource struct {
+	Node
+
+	Type   FontFaceSourceType // The type of the source
+	URL    string             // The URL of the font, for url sources
+	Format string             // The font format, lowercased if it was a keyword, empty if omitted
+	Techs  []string           // The lowercased font technologies, empty if omitted
+	Local  string             // The font face name, for local sources
+}
+
+func (s *FontFaceSource) String() string {
+	if s.Type == FontFaceSourceLocal {
+		return "local(" + cssutil.SerializeString(s.Local) + ")"
+	}
+
+	var result strings.Builder
+
+	result.WriteString("url(")
+	result.WriteString(cssutil.SerializeString(s.URL))
+	result.WriteString(")")
+
+	if s.Format != "" {
+		result.WriteString(" format(")
+		result.WriteString(cssutil.SerializeString(s.Format))
+		result.WriteString(")")
+	}
+
+	if len(s.Techs) > 0 {
+		techStrs := make([]string, 0, len(s.Techs))
+		for _, tech := range s.Techs {
+			techStrs = append(techStrs, cssutil.SerializeIdentifier(tech))
+		}
+
+		result.WriteString(" tech(")
+		result.WriteString(cssutil.SerializeCommaSeparatedList(techStrs))
+		result.WriteString(")")
+	}
+
+	return result.String()
+}
+
+func (s *FontFaceSource) Equals(other *FontFaceSource) bool {
+	if other == nil || len(s.Techs) != len(other.Techs) {
+		return false
+	}
+
+	for i, tech := range s.Techs {
+		if tech != other.Techs[i] {
+			return false
+		}
+	}
+
+	return s.Type == other.Type &&
+		s.URL == other.URL &&
+		s.Format == other.Format &&
+		s.Local == other.Local
+}
+
+// ===== UnicodeRange =====
+
+// UnicodeRange represents an inclusive range of code points.
+//
+// https://www.w3.org/TR/css-syntax-3/#urange
+type UnicodeRange struct {
+	Node
+
+	Start rune // The first code point of the range
+	End   rune // The last code point of the range
+}
+
+func (r *UnicodeRange) String() string {
+	if r.Start == r.End {
+		return fmt.Sprintf("U+%X", r.Start)
+	}
+	return fmt.Sprintf("U+%X-%X", r.Start, r.End)
+}
+
+// Contains checks if a code point is in the range.
+func (r *UnicodeRange) Contains(c rune) bool {
+	return c >= r.Start && c <= r.End
+}
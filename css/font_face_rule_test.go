@@ -0,0 +1,141 @@
+package css
+
+import "testing"
+
+func TestFontFaceRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *FontFaceRule
+		expected string
+	}{
+		{
+			name:     "empty",
+			rule:     &FontFaceRule{},
+			expected: "@font-face { }",
+		},
+		{
+			name: "with descriptors",
+			rule: &FontFaceRule{Descriptors: []*Declaration{
+				{Property: "font-family", Value: "Foo"},
+				{Property: "src", Value: `url("foo.woff2")`},
+			}},
+			expected: `@font-face { font-family: Foo; src: url("foo.woff2"); }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFontFaceRuleEquals(t *testing.T) {
+	rule := &FontFaceRule{
+		Descriptors:  []*Declaration{{Property: "src", Value: `url("a.woff")`}},
+		Src:          []*FontFaceSource{{Type: FontFaceSourceURL, URL: "a.woff"}},
+		UnicodeRange: []*UnicodeRange{{Start: 0, End: 0x7F}},
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{
+			name: "equal",
+			other: &FontFaceRule{
+				Descriptors:  []*Declaration{{Property: "src", Value: `url("a.woff")`}},
+				Src:          []*FontFaceSource{{Type: FontFaceSourceURL, URL: "a.woff"}},
+				UnicodeRange: []*UnicodeRange{{Start: 0, End: 0x7F}},
+			},
+			expected: true,
+		},
+		{
+			name: "different source",
+			other: &FontFaceRule{
+				Descriptors:  []*Declaration{{Property: "src", Value: `url("a.woff")`}},
+				Src:          []*FontFaceSource{{Type: FontFaceSourceURL, URL: "a.woff", Format: "woff"}},
+				UnicodeRange: []*UnicodeRange{{Start: 0, End: 0x7F}},
+			},
+			expected: false,
+		},
+		{
+			name: "different unicode range",
+			other: &FontFaceRule{
+				Descriptors:  []*Declaration{{Property: "src", Value: `url("a.woff")`}},
+				Src:          []*FontFaceSource{{Type: FontFaceSourceURL, URL: "a.woff"}},
+				UnicodeRange: []*UnicodeRange{{Start: 0, End: 0xFF}},
+			},
+			expected: false,
+		},
+		{
+			name:     "different type",
+			other:    &GenericAtRule{Name: "font-face"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rule.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFontFaceSourceString(t *testing.T) {
+	tests := []struct {
+		name     string
+		source   *FontFaceSource
+		expected string
+	}{
+		{
+			name:     "url",
+			source:   &FontFaceSource{Type: FontFaceSourceURL, URL: "a.woff"},
+			expected: `url("a.woff")`,
+		},
+		{
+			name:     "url with format and techs",
+			source:   &FontFaceSource{Type: FontFaceSourceURL, URL: "a.otf", Format: "opentype", Techs: []string{"variations", "color-svg"}},
+			expected: `url("a.otf") format("opentype") tech(variations, color-svg)`,
+		},
+		{
+			name:     "local",
+			source:   &FontFaceSource{Type: FontFaceSourceLocal, Local: "Helvetica Neue"},
+			expected: `local("Helvetica Neue")`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.source.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestUnicodeRangeString(t *testing.T) {
+	tests := []struct {
+		urange   *UnicodeRange
+		expected string
+	}{
+		{&UnicodeRange{Start: 0x26, End: 0x26}, "U+26"},
+		{&UnicodeRange{Start: 0x400, End: 0x4FF}, "U+400-4FF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			result := tt.urange.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package css
+
+import (
+	"strconv"
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== FontFeatureValuesRule =====
+
+// FontFeatureValuesRule represents a @font-feature-values rule, which
+// gives names to the feature indexes of a font family, e.g. "@swash {
+// fancy: 1; }".
+//
+// Only valid feature values and descriptors are kept.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-feature-values
+type FontFeatureValuesRule struct {
+	Node
+
+	FamilyNames []string                  // The font family names from the prelude
+	Descriptors []*Declaration            // The valid descriptors, i.e. font-display, in source order
+	Blocks      []*FontFeatureValuesBlock // The feature value blocks, in source order
+}
+
+// String returns the string representation of the @font-feature-values
+// rule.
+func (r *FontFeatureValuesRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@font-feature-values ")
+	result.WriteString(serializeFamilyNameList(r.FamilyNames))
+	result.WriteString(" {")
+	for _, decl := range r.Descriptors {
+		result.WriteString(" ")
+		result.WriteString(decl.String())
+		result.WriteString(";")
+	}
+	for _, block := range r.Blocks {
+		result.WriteString(" ")
+		result.WriteString(block.String())
+	}
+	result.WriteString(" }")
+
+	return result.String()
+}
+
+// Equals compares two FontFeatureValuesRule instances.
+func (r *FontFeatureValuesRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*FontFeatureValuesRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	if !stringListEquals(r.FamilyNames, otherRule.FamilyNames) ||
+		!declarationListEquals(r.Descriptors, otherRule.Descriptors) ||
+		len(r.Blocks) != len(otherRule.Blocks) {
+		return false
+	}
+
+	for i, block := range r.Blocks {
+		if !block.Equals(otherRule.Blocks[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Lookup returns the feature indexes defined for a name in blocks of the
+// given type, or nil if there are none. Later definitions override
+// earlier ones, even across blocks.
+func (r *FontFeatureValuesRule) Lookup(blockType FontFeatureValuesBlockType, name string) []int {
+	var indexes []int
+	for _, block := range r.Blocks {
+		if block.Type != blockType {
+			continue
+		}
+		for _, value := range block.Values {
+			if value.Name == name {
+				indexes = value.Indexes
+			}
+		}
+	}
+	return indexes
+}
+
+// ===== FontFeatureValuesBlockType =====
+
+type FontFeatureValuesBlockType int
+
+const (
+	FontFeatureValuesStylistic        FontFeatureValuesBlockType = iota // Example: @stylistic { alt: 1; }
+	FontFeatureValuesHistoricalForms                                    // Example: @historical-forms { old: 1; }
+	FontFeatureValuesStyleset                                           // Example: @styleset { set: 1 2 3; }
+	FontFeatureValuesCharacterVariant                                   // Example: @character-variant { cv: 1 2; }
+	FontFeatureValuesSwash                                              // Example: @swash { fancy: 1; }
+	FontFeatureValuesOrnaments                                          // Example: @ornaments { fleurons: 1; }
+	FontFeatureValuesAnnotation                                         // Example: @annotation { circled: 1; }
+)
+
+func (t FontFeatureValuesBlockType) String() string {
+	switch t {
+	case FontFeatureValuesStylistic:
+		return "stylistic"
+	case FontFeatureValuesHistoricalForms:
+		return "historical-forms"
+	case FontFeatureValuesStyleset:
+		return "styleset"
+	case FontFeatureValuesCharacterVariant:
+		return "character-variant"
+	case FontFeatureValuesSwash:
+		return "swash"
+	case FontFeatureValuesOrnaments:
+		return "ornaments"
+	case FontFeatureValuesAnnotation:
+		return "annotation"
+	default:
+		return ""
+	}
+}
+
+// ===== FontFeatureValuesBlock =====
+
+// FontFeatureValuesBlock represents a feature value block of a
+// @font-feature-values rule, e.g. "@styleset { nice-style: 12; }".
+//
+// https://www.w3.org/TR/css-fonts-4/#feature-value-blocks
+type FontFeatureValuesBlock struct {
+	Node
+
+	Type   FontFeatureValuesBlockType // The type of the block
+	Values []*FontFeatureValue        // The valid feature values, in source order
+}
+
+func (b *FontFeatureValuesBlock) String() string {
+	var result strings.Builder
+
+	result.WriteString("@")
+	result.WriteString(b.Type.String())
+	result.WriteString(" {")
+	for _, value := range b.Values {
+		result.WriteString(" ")
+		result.WriteString(value.String())
+		result.WriteString(";")
+	}
+	result.WriteString(" }")
+
+	return result.String()
+}
+
+func (b *FontFeatureValuesBlock) Equals(other *FontFeatureValuesBlock) bool {
+	if other == nil || b.Type != other.Type || len(b.Values) != len(other.Values) {
+		return false
+	}
+
+	for i, value := range b.Values {
+		if !value.Equals(other.Values[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ===== FontFeatureValue =====
+
+// FontFeatureValue represents a feature value declaration, which maps a
+// name to one or more feature indexes.
+type FontFeatureValue struct {
+	Node
+
+	Name    string // The case-sensitive name
+	Indexes []int  // The non-negative feature indexes
+}
+
+func (v *FontFeatureValue) String() string {
+	indexStrs := make([]string, 0, len(v.Indexes))
+	for _, index := range v.Indexes {
+		indexStrs = append(indexStrs, strconv.Itoa(index))
+	}
+	return cssutil.SerializeIdentifier(v.Name) + ": " + strings.Join(indexStrs, " ")
+}
+
+func (v *FontFeatureValue) Equals(other *FontFeatureValue) bool {
+	if other == nil || v.Name != other.Name || len(v.Indexes) != len(other.Indexes) {
+		return false
+	}
+
+	for i, index := range v.Indexes {
+		if index != other.Indexes[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// serializeFamilyNameList returns the string representation of a list of
+// font family names, each serialized as a string.
+func serializeFamilyNameList(names []string) string {
+	nameStrs := make([]string, 0, len(names))
+	for _, name := range names {
+		nameStrs = append(nameStrs, cssutil.SerializeString(name))
+	}
+	return cssutil.SerializeCommaSeparatedList(nameStrs)
+}
+
+// stringListEquals compares two lists of strings.
+func stringListEquals(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, s := range a {
+		if s != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,68 @@
+package css
+
+import "testing"
+
+func TestFontFeatureValuesRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *FontFeatureValuesRule
+		expected string
+	}{
+		{
+			name:     "empty",
+			rule:     &FontFeatureValuesRule{FamilyNames: []string{"Font One"}},
+			expected: `@font-feature-values "Font One" { }`,
+		},
+		{
+			name: "blocks and descriptors",
+			rule: &FontFeatureValuesRule{
+				FamilyNames: []string{"Font One", "Font Two"},
+				Descriptors: []*Declaration{{Property: "font-display", Value: "swap"}},
+				Blocks: []*FontFeatureValuesBlock{
+					{Type: FontFeatureValuesSwash, Values: []*FontFeatureValue{{Name: "fancy", Indexes: []int{1}}}},
+					{Type: FontFeatureValuesStyleset, Values: []*FontFeatureValue{{Name: "nice", Indexes: []int{1, 2}}, {Name: "other", Indexes: []int{3}}}},
+				},
+			},
+			expected: `@font-feature-values "Font One", "Font Two" { font-display: swap; @swash { fancy: 1; } @styleset { nice: 1 2; other: 3; } }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestFontFeatureValuesRuleEquals(t *testing.T) {
+	newRule := func(index int) *FontFeatureValuesRule {
+		return &FontFeatureValuesRule{
+			FamilyNames: []string{"Foo"},
+			Blocks: []*FontFeatureValuesBlock{
+				{Type: FontFeatureValuesSwash, Values: []*FontFeatureValue{{Name: "fancy", Indexes: []int{index}}}},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{"equal", newRule(1), true},
+		{"different index", newRule(2), false},
+		{"different family", &FontFeatureValuesRule{FamilyNames: []string{"Bar"}, Blocks: newRule(1).Blocks}, false},
+		{"different type", &FontFaceRule{}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newRule(1).Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
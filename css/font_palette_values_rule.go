@@ -0,0 +1,66 @@
+package css
+
+import (
+	"strconv"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== FontPaletteValuesRule =====
+
+// FontPaletteValuesRule represents a @font-palette-values rule, which
+// defines a color palette for a font, e.g. "@font-palette-values --warm {
+// font-family: Bixa; override-colors: 0 red; }".
+//
+// Only valid descriptors are kept. The values of the font-family and
+// override-colors descriptors are also available in their parsed form.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-palette-values
+type FontPaletteValuesRule struct {
+	Node
+
+	Name           string                      // The dashed-ident naming the palette
+	Descriptors    []*Declaration              // The valid descriptors, in source order
+	FontFamily     []string                    // The parsed value of the last font-family descriptor
+	OverrideColors []*FontPaletteOverrideColor // The parsed value of the last override-colors descriptor
+}
+
+// String returns the string representation of the @font-palette-values
+// rule.
+func (r *FontPaletteValuesRule) String() string {
+	return "@font-palette-values " + cssutil.SerializeIdentifier(r.Name) + " " + serializeBlock(r.Descriptors, nil)
+}
+
+// Equals compares two FontPaletteValuesRule instances.
+//
+// The parsed descriptor values are derived from the descriptors, so only
+// the name and the descriptors are compared.
+func (r *FontPaletteValuesRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*FontPaletteValuesRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Name == otherRule.Name && declarationListEquals(r.Descriptors, otherRule.Descriptors)
+}
+
+// Descriptor returns the last descriptor with the given name, or nil if
+// there is none.
+func (r *FontPaletteValuesRule) Descriptor(name string) *Declaration {
+	return lastDeclaration(r.Descriptors, name)
+}
+
+// ===== FontPaletteOverrideColor =====
+
+// FontPaletteOverrideColor represents an entry of the override-colors
+// descriptor, which replaces a color of the base palette.
+//
+// https://www.w3.org/TR/css-fonts-4/#override-color
+type FontPaletteOverrideColor struct {
+	Index int             // The non-negative index of the replaced color
+	Color *ComponentValue // The absolute color replacing it
+}
+
+func (c *FontPaletteOverrideColor) String() string {
+	return strconv.Itoa(c.Index) + " " + c.Color.String()
+}
@@ -0,0 +1,55 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestFontPaletteValuesRuleString(t *testing.T) {
+	rule := &FontPaletteValuesRule{
+		Name: "--warm",
+		Descriptors: []*Declaration{
+			{Property: "font-family", Value: "Bixa"},
+			{Property: "override-colors", Value: "0 red"},
+		},
+	}
+
+	expected := "@font-palette-values --warm { font-family: Bixa; override-colors: 0 red; }"
+	if result := rule.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	color := &FontPaletteOverrideColor{Index: 2, Color: newTestToken(csslexer.HashToken, "fff")}
+	if result := color.String(); result != "2 #fff" {
+		t.Errorf("expected %q, got %q", "2 #fff", result)
+	}
+}
+
+func TestFontPaletteValuesRuleEquals(t *testing.T) {
+	newRule := func(value string) *FontPaletteValuesRule {
+		return &FontPaletteValuesRule{
+			Name:        "--warm",
+			Descriptors: []*Declaration{{Property: "base-palette", Value: value}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{"equal", newRule("1"), true},
+		{"different descriptor", newRule("2"), false},
+		{"different name", &FontPaletteValuesRule{Name: "--cold", Descriptors: newRule("1").Descriptors}, false},
+		{"different type", &CounterStyleRule{Name: "--warm"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newRule("1").Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== FunctionRule =====
+
+// FunctionRule represents a @function rule, which defines a custom
+// function, e.g. "@function --double(--x <number>) returns <number> {
+// result: calc(var(--x) * 2); }".
+//
+// The body only holds local custom properties, the result descriptor and
+// conditional group rules such as @media, whose blocks hold the same kind
+// of declarations.
+//
+// https://drafts.csswg.org/css-mixins-1/#function-rule
+type FunctionRule struct {
+	Node
+
+	Name         string               // The dashed-ident naming the function
+	Parameters   []*FunctionParameter // The parameters, in order
+	ReturnType   *PropertySyntax      // The return type, nil if omitted
+	Declarations []*Declaration       // The local custom properties and result descriptors
+	Rules        []Rule               // The conditional group rules of the body
+}
+
+// String returns the string representation of the @function rule.
+func (r *FunctionRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@function ")
+	result.WriteString(cssutil.SerializeIdentifier(r.Name))
+	result.WriteString(serializeFunctionParameterList(r.Parameters))
+	if r.ReturnType != nil {
+		result.WriteString(" returns ")
+		result.WriteString(serializeCSSType(r.ReturnType))
+	}
+	result.WriteString(" ")
+	result.WriteString(serializeBlock(r.Declarations, r.Rules))
+
+	return result.String()
+}
+
+// Equals compares two FunctionRule instances.
+func (r *FunctionRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*FunctionRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	if r.ReturnType == nil || otherRule.ReturnType == nil {
+		if r.ReturnType != otherRule.ReturnType {
+			return false
+		}
+	} else if !r.ReturnType.Equals(otherRule.ReturnType) {
+		return false
+	}
+
+	return r.Name == otherRule.Name &&
+		functionParameterListEquals(r.Parameters, otherRule.Parameters) &&
+		declarationListEquals(r.Declarations, otherRule.Declarations) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
+
+// Result returns the last result descriptor of the function body, or nil
+// if there is none.
+func (r *FunctionRule) Result() *Declaration {
+	return lastDeclaration(r.Declarations, "result")
+}
+
+// ===== FunctionParameter =====
+
+// FunctionParameter represents a parameter of a @function or @mixin rule,
+// e.g. "--x <length>: 10px".
+//
+// https://drafts.csswg.org/css-mixins-1/#typedef-function-parameter
+type FunctionParameter struct {
+	Name         string            // The custom property name of the parameter
+	Type         *PropertySyntax   // The type of the parameter, nil if omitted
+	DefaultValue []*ComponentValue // The default value, nil if omitted
+}
+
+func (p *FunctionParameter) String() string {
+	var result strings.Builder
+
+	result.WriteString(cssutil.SerializeIdentifier(p.Name))
+	if p.Type != nil {
+		result.WriteString(" ")
+		result.WriteString(serializeCSSType(p.Type))
+	}
+	if p.DefaultValue != nil {
+		result.WriteString(": ")
+		result.WriteString(SerializeComponentValueList(p.DefaultValue))
+	}
+
+	return result.String()
+}
+
+func (p *FunctionParameter) Equals(other *FunctionParameter) bool {
+	if other == nil || p.Name != other.Name {
+		return false
+	}
+
+	if p.Type == nil || other.Type == nil {
+		if p.Type != other.Type {
+			return false
+		}
+	} else if !p.Type.Equals(other.Type) {
+		return false
+	}
+
+	if (p.DefaultValue == nil) != (other.DefaultValue == nil) {
+		return false
+	}
+
+	return ComponentValueListEquals(p.DefaultValue, other.DefaultValue)
+}
+
+// serializeFunctionParameterList returns the string representation of a
+// parenthesized list of parameters.
+func serializeFunctionParameterList(parameters []*FunctionParameter) string {
+	parameterStrs := make([]string, 0, len(parameters))
+	for _, parameter := range parameters {
+		parameterStrs = append(parameterStrs, parameter.String())
+	}
+	return "(" + cssutil.SerializeCommaSeparatedList(parameterStrs) + ")"
+}
+
+// functionParameterListEquals compares two lists of parameters.
+func functionParameterListEquals(a, b []*FunctionParameter) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, parameter := range a {
+		if !parameter.Equals(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// serializeCSSType returns the string representation of a <css-type>,
+// which is a single syntax component, or a type() function for other
+// syntaxes.
+//
+// https://drafts.csswg.org/css-mixins-1/#typedef-css-type
+func serializeCSSType(syntax *PropertySyntax) string {
+	if len(syntax.Components) == 1 {
+		return syntax.Components[0].String()
+	}
+	return "type(" + syntax.String() + ")"
+}
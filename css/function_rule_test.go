@@ -0,0 +1,92 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestFunctionRule(t *testing.T) {
+	rule := &FunctionRule{
+		Name: "--double",
+		Parameters: []*FunctionParameter{
+			{
+				Name: "--x",
+				Type: &PropertySyntax{Components: []*PropertySyntaxComponent{
+					{Type: PropertySyntaxComponentDataType, Name: "number"},
+				}},
+			},
+			{
+				Name: "--y",
+				Type: &PropertySyntax{Components: []*PropertySyntaxComponent{
+					{Type: PropertySyntaxComponentDataType, Name: "length"},
+					{Type: PropertySyntaxComponentIdent, Name: "auto"},
+				}},
+			},
+		},
+		ReturnType: &PropertySyntax{Components: []*PropertySyntaxComponent{
+			{Type: PropertySyntaxComponentDataType, Name: "number"},
+		}},
+		Declarations: []*Declaration{
+			{Property: "result", Value: "1"},
+			{Property: "result", Value: "calc(var(--x) * 2)"},
+		},
+	}
+
+	expected := "@function --double(--x <number>, --y type(<length> | auto)) returns <number> { result: 1; result: calc(var(--x) * 2); }"
+	if result := rule.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	if result := rule.Result(); result == nil || result.Value != "calc(var(--x) * 2)" {
+		t.Errorf("expected the last result descriptor, got %v", result)
+	}
+
+	if rule.Equals(&FunctionRule{Name: "--double", Parameters: rule.Parameters, Declarations: rule.Declarations}) {
+		t.Errorf("expected rules with different return types to differ")
+	}
+	if !rule.Equals(&FunctionRule{
+		Name:         "--double",
+		Parameters:   rule.Parameters,
+		ReturnType:   rule.ReturnType,
+		Declarations: rule.Declarations,
+	}) {
+		t.Errorf("expected rules with the same contents to be equal")
+	}
+}
+
+func TestFunctionParameter(t *testing.T) {
+	tests := []struct {
+		name      string
+		parameter *FunctionParameter
+		expected  string
+	}{
+		{
+			name:      "name only",
+			parameter: &FunctionParameter{Name: "--x"},
+			expected:  "--x",
+		},
+		{
+			name: "type and default value",
+			parameter: &FunctionParameter{
+				Name: "--x",
+				Type: &PropertySyntax{Components: []*PropertySyntaxComponent{
+					{Type: PropertySyntaxComponentDataType, Name: "length", Multiplier: PropertySyntaxMultiplierSpace},
+				}},
+				DefaultValue: []*ComponentValue{newTestToken(csslexer.IdentToken, "auto")},
+			},
+			expected: "--x <length>+: auto",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.parameter.String(); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+			if !tt.parameter.Equals(tt.parameter) {
+				t.Errorf("expected parameter to equal itself")
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== ImportRule =====
+
+// ImportRule represents an @import rule.
+//
+// https://www.w3.org/TR/css-cascade-5/#at-import
+type ImportRule struct {
+	Node
+
+	URL       string             // The URL of the imported stylesheet
+	Layer     bool               // Whether the stylesheet is imported into a cascade layer
+	LayerName LayerName          // The name of the layer, empty for an anonymous layer
+	Supports  *SupportsCondition // The condition of the supports() modifier, nil if omitted
+	Media     *MediaQueryList    // The media query list, empty if omitted
+}
+
+// String returns the string representation of the @import rule.
+func (r *ImportRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@import url(")
+	result.WriteString(cssutil.SerializeString(r.URL))
+	result.WriteString(")")
+
+	if r.Layer {
+		if len(r.LayerName) > 0 {
+			result.WriteString(" layer(")
+			result.WriteString(r.LayerName.String())
+			result.WriteString(")")
+		} else {
+			result.WriteString(" layer")
+		}
+	}
+
+	if r.Supports != nil {
+		result.WriteString(" supports(")
+		if r.Supports.Type == SupportsConditionDeclaration {
+			// A bare declaration doesn't need its own parentheses
+			result.WriteString(r.Supports.Declaration.String())
+		} else {
+			result.WriteString(r.Supports.String())
+		}
+		result.WriteString(")")
+	}
+
+	if media := r.Media.String(); media != "" {
+		result.WriteString(" ")
+		result.WriteString(media)
+	}
+
+	result.WriteString(";")
+
+	return result.String()
+}
+
+// Equals compares two ImportRule instances.
+func (r *ImportRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*ImportRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	if r.URL != otherRule.URL ||
+		r.Layer != otherRule.Layer ||
+		!r.LayerName.Equals(otherRule.LayerName) ||
+		!r.Media.Equals(otherRule.Media) {
+		return false
+	}
+
+	if r.Supports == nil || otherRule.Supports == nil {
+		return r.Supports == nil && otherRule.Supports == nil
+	}
+
+	return r.Supports.Equals(otherRule.Supports)
+}
@@ -0,0 +1,146 @@
+package css
+
+import "testing"
+
+func TestImportRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *ImportRule
+		expected string
+	}{
+		{
+			name:     "url only",
+			rule:     &ImportRule{URL: "foo.css", Media: &MediaQueryList{}},
+			expected: `@import url("foo.css");`,
+		},
+		{
+			name:     "nil media",
+			rule:     &ImportRule{URL: "foo.css"},
+			expected: `@import url("foo.css");`,
+		},
+		{
+			name:     "anonymous layer",
+			rule:     &ImportRule{URL: "foo.css", Layer: true, Media: &MediaQueryList{}},
+			expected: `@import url("foo.css") layer;`,
+		},
+		{
+			name:     "named layer",
+			rule:     &ImportRule{URL: "foo.css", Layer: true, LayerName: LayerName{"framework", "base"}, Media: &MediaQueryList{}},
+			expected: `@import url("foo.css") layer(framework.base);`,
+		},
+		{
+			name: "supports declaration",
+			rule: &ImportRule{
+				URL: "foo.css",
+				Supports: &SupportsCondition{
+					Type:        SupportsConditionDeclaration,
+					Declaration: &Declaration{Property: "display", Value: "grid"},
+				},
+				Media: &MediaQueryList{},
+			},
+			expected: `@import url("foo.css") supports(display: grid);`,
+		},
+		{
+			name: "supports condition",
+			rule: &ImportRule{
+				URL: "foo.css",
+				Supports: &SupportsCondition{
+					Type: SupportsConditionNot,
+					Children: []*SupportsCondition{
+						{Type: SupportsConditionFontFormat, Keyword: "woff2"},
+					},
+				},
+				Media: &MediaQueryList{},
+			},
+			expected: `@import url("foo.css") supports(not font-format(woff2));`,
+		},
+		{
+			name: "all modifiers",
+			rule: &ImportRule{
+				URL:       `a "b".css`,
+				Layer:     true,
+				LayerName: LayerName{"base"},
+				Supports: &SupportsCondition{
+					Type:        SupportsConditionDeclaration,
+					Declaration: &Declaration{Property: "display", Value: "grid"},
+				},
+				Media: &MediaQueryList{Queries: []*MediaQuery{{MediaType: "screen"}, {MediaType: "print"}}},
+			},
+			expected: `@import url("a \"b\".css") layer(base) supports(display: grid) screen, print;`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestImportRuleEquals(t *testing.T) {
+	rule := &ImportRule{
+		URL:       "foo.css",
+		Layer:     true,
+		LayerName: LayerName{"base"},
+		Media:     &MediaQueryList{},
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{
+			name:     "same rule",
+			other:    &ImportRule{URL: "foo.css", Layer: true, LayerName: LayerName{"base"}, Media: &MediaQueryList{}},
+			expected: true,
+		},
+		{
+			name:     "nil media",
+			other:    &ImportRule{URL: "foo.css", Layer: true, LayerName: LayerName{"base"}},
+			expected: true,
+		},
+		{
+			name:     "different media",
+			other:    &ImportRule{URL: "foo.css", Layer: true, LayerName: LayerName{"base"}, Media: &MediaQueryList{Queries: []*MediaQuery{{MediaType: "print"}}}},
+			expected: false,
+		},
+		{
+			name:     "different url",
+			other:    &ImportRule{URL: "bar.css", Layer: true, LayerName: LayerName{"base"}, Media: &MediaQueryList{}},
+			expected: false,
+		},
+		{
+			name:     "different layer",
+			other:    &ImportRule{URL: "foo.css", Layer: true, Media: &MediaQueryList{}},
+			expected: false,
+		},
+		{
+			name: "different supports",
+			other: &ImportRule{
+				URL:       "foo.css",
+				Layer:     true,
+				LayerName: LayerName{"base"},
+				Supports:  &SupportsCondition{Type: SupportsConditionFontTech, Keyword: "variations"},
+				Media:     &MediaQueryList{},
+			},
+			expected: false,
+		},
+		{
+			name:     "different at-rule type",
+			other:    &GenericAtRule{Name: "import"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rule.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
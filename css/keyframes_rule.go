@@ -0,0 +1,108 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+
+	"go.baoshuo.dev/cssparser/numeric"
+)
+
+// ===== KeyframesRule =====
+
+// KeyframesRule represents a @keyframes rule.
+//
+// https://www.w3.org/TR/css-animations-1/#keyframes
+type KeyframesRule struct {
+	Node
+
+	Name      string       // The name of the animation
+	Prefixed  bool         // Whether the rule was written as @-webkit-keyframes
+	Keyframes []*StyleRule // The keyframe rules, of type StyleRuleTypeKeyframe
+}
+
+// String returns the string representation of the @keyframes rule.
+func (r *KeyframesRule) String() string {
+	var result strings.Builder
+
+	if r.Prefixed {
+		result.WriteString("@-webkit-keyframes ")
+	} else {
+		result.WriteString("@keyframes ")
+	}
+
+	// Names which are not valid <custom-ident>s were written as strings
+	if IsValidKeyframesName(r.Name) {
+		result.WriteString(cssutil.SerializeIdentifier(r.Name))
+	} else {
+		result.WriteString(cssutil.SerializeString(r.Name))
+	}
+
+	keyframes := make([]Rule, 0, len(r.Keyframes))
+	for _, keyframe := range r.Keyframes {
+		keyframes = append(keyframes, keyframe)
+	}
+
+	result.WriteString(" ")
+	result.WriteString(serializeBlock(nil, keyframes))
+
+	return result.String()
+}
+
+// Equals compares two KeyframesRule instances.
+func (r *KeyframesRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*KeyframesRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Name == otherRule.Name &&
+		r.Prefixed == otherRule.Prefixed &&
+		styleRuleListEquals(r.Keyframes, otherRule.Keyframes)
+}
+
+// IsValidKeyframesName checks if a keyframes name can be written as a
+// <custom-ident>, i.e. if it is not empty and not a reserved keyword.
+//
+// https://www.w3.org/TR/css-animations-1/#typedef-keyframes-name
+func IsValidKeyframesName(name string) bool {
+	return name != "" && !strings.EqualFold(name, "none") && !IsReservedCustomIdent(name)
+}
+
+// ===== KeyframeSelector =====
+
+// KeyframeSelector represents a keyframe selector. The "from" and "to"
+// keywords are stored as 0% and 100%.
+//
+// https://drafts.csswg.org/css-animations-2/#typedef-keyframe-selector
+type KeyframeSelector struct {
+	Node
+
+	RangeName  string  // The lowercased timeline range name, e.g. "entry", empty if omitted
+	Percentage float64 // The offset in the range, from 0 to 100
+}
+
+func (s *KeyframeSelector) String() string {
+	percentage := numeric.FormatNumber(s.Percentage) + "%"
+	if s.RangeName != "" {
+		return cssutil.SerializeIdentifier(s.RangeName) + " " + percentage
+	}
+	return percentage
+}
+
+func (s *KeyframeSelector) Equals(other *KeyframeSelector) bool {
+	if other == nil {
+		return false
+	}
+	return s.RangeName == other.RangeName && s.Percentage == other.Percentage
+}
+
+// serializeKeyframeSelectorList returns the string representation of a
+// list of keyframe selectors.
+func serializeKeyframeSelectorList(selectors []*KeyframeSelector) string {
+	selectorStrs := make([]string, 0, len(selectors))
+	for _, sel := range selectors {
+		selectorStrs = append(selectorStrs, sel.String())
+	}
+	return cssutil.SerializeCommaSeparatedList(selectorStrs)
+}
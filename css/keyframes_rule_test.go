@@ -0,0 +1,106 @@
+package css
+
+import "testing"
+
+func TestKeyframesRuleString(t *testing.T) {
+	keyframe := &StyleRule{
+		Type:              StyleRuleTypeKeyframe,
+		KeyframeSelectors: []*KeyframeSelector{{Percentage: 0}, {RangeName: "entry", Percentage: 12.5}},
+		Declarations:      []*Declaration{{Property: "opacity", Value: "0"}},
+	}
+
+	tests := []struct {
+		name     string
+		rule     *KeyframesRule
+		expected string
+	}{
+		{
+			name:     "empty",
+			rule:     &KeyframesRule{Name: "fade"},
+			expected: "@keyframes fade { }",
+		},
+		{
+			name:     "with keyframes",
+			rule:     &KeyframesRule{Name: "fade", Keyframes: []*StyleRule{keyframe}},
+			expected: "@keyframes fade { 0%, entry 12.5% { opacity: 0; } }",
+		},
+		{
+			name:     "prefixed",
+			rule:     &KeyframesRule{Name: "fade", Prefixed: true},
+			expected: "@-webkit-keyframes fade { }",
+		},
+		{
+			name:     "reserved name",
+			rule:     &KeyframesRule{Name: "initial"},
+			expected: `@keyframes "initial" { }`,
+		},
+		{
+			name:     "empty name",
+			rule:     &KeyframesRule{},
+			expected: `@keyframes "" { }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestKeyframesRuleEquals(t *testing.T) {
+	rule := &KeyframesRule{
+		Name: "fade",
+		Keyframes: []*StyleRule{
+			{Type: StyleRuleTypeKeyframe, KeyframeSelectors: []*KeyframeSelector{{Percentage: 100}}},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{
+			name: "same rule",
+			other: &KeyframesRule{
+				Name: "fade",
+				Keyframes: []*StyleRule{
+					{Type: StyleRuleTypeKeyframe, KeyframeSelectors: []*KeyframeSelector{{Percentage: 100}}},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "different keyframe selector",
+			other: &KeyframesRule{
+				Name: "fade",
+				Keyframes: []*StyleRule{
+					{Type: StyleRuleTypeKeyframe, KeyframeSelectors: []*KeyframeSelector{{RangeName: "exit", Percentage: 100}}},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:     "different prefix",
+			other:    &KeyframesRule{Name: "fade", Prefixed: true},
+			expected: false,
+		},
+		{
+			name:     "different at-rule type",
+			other:    &GenericAtRule{Name: "keyframes"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rule.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+package css
+
+import "strings"
+
+// IsCSSWideKeyword checks if an identifier is one of the CSS-wide keywords,
+// which every property accepts.
+//
+// https://www.w3.org/TR/css-values-4/#common-keywords
+func IsCSSWideKeyword(name string) bool {
+	switch strings.ToLower(name) {
+	case "initial", "inherit", "unset", "revert", "revert-layer":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsReservedCustomIdent checks if an identifier is excluded from
+// <custom-ident> everywhere, i.e. if it is a CSS-wide keyword or
+// "default".
+//
+// https://www.w3.org/TR/css-values-4/#custom-idents
+func IsReservedCustomIdent(name string) bool {
+	return IsCSSWideKeyword(name) || strings.EqualFold(name, "default")
+}
@@ -0,0 +1,45 @@
+package css
+
+import "testing"
+
+func TestIsCSSWideKeyword(t *testing.T) {
+	testcases := []struct {
+		name     string
+		expected bool
+	}{
+		{"initial", true},
+		{"INHERIT", true},
+		{"revert-layer", true},
+		{"default", false},
+		{"none", false},
+		{"foo", false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := IsCSSWideKeyword(tc.name); result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestIsReservedCustomIdent(t *testing.T) {
+	testcases := []struct {
+		name     string
+		expected bool
+	}{
+		{"unset", true},
+		{"Default", true},
+		{"none", false},
+		{"foo", false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := IsReservedCustomIdent(tc.name); result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
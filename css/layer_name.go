@@ -0,0 +1,37 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== LayerName =====
+
+// LayerName represents a cascade layer name, split on its dots, e.g.
+// ["framework", "base"] for "framework.base".
+//
+// https://www.w3.org/TR/css-cascade-5/#typedef-layer-name
+type LayerName []string
+
+func (n LayerName) String() string {
+	identStrs := make([]string, 0, len(n))
+	for _, ident := range n {
+		identStrs = append(identStrs, cssutil.SerializeIdentifier(ident))
+	}
+	return strings.Join(identStrs, ".")
+}
+
+func (n LayerName) Equals(other LayerName) bool {
+	if len(n) != len(other) {
+		return false
+	}
+
+	for i, ident := range n {
+		if ident != other[i] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,24 @@
+package css
+
+import "testing"
+
+func TestLayerNameString(t *testing.T) {
+	tests := []struct {
+		name     string
+		layer    LayerName
+		expected string
+	}{
+		{"empty", LayerName{}, ""},
+		{"single identifier", LayerName{"base"}, "base"},
+		{"dotted name", LayerName{"framework", "base"}, "framework.base"},
+		{"escaped identifier", LayerName{"1st"}, `\31 st`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.layer.String(); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package css
+
+// ===== LayerOrder =====
+
+// layerNode is a node of the tree of cascade layers built by LayerOrder.
+type layerNode struct {
+	name      string       // The name of the layer, relative to its parent
+	anonymous bool         // Whether the layer is anonymous
+	children  []*layerNode // The sub-layers, in the order they were declared
+}
+
+// declare returns the sub-layer with the given name, creating it and its
+// ancestors if they don't exist yet.
+func (n *layerNode) declare(name LayerName) *layerNode {
+	node := n
+
+	for _, segment := range name {
+		var child *layerNode
+		for _, c := range node.children {
+			if !c.anonymous && c.name == segment {
+				child = c
+				break
+			}
+		}
+
+		if child == nil {
+			child = &layerNode{name: segment}
+			node.children = append(node.children, child)
+		}
+
+		node = child
+	}
+
+	return node
+}
+
+// declareAnonymous creates a new anonymous sub-layer.
+func (n *layerNode) declareAnonymous() *layerNode {
+	child := &layerNode{anonymous: true}
+	n.children = append(n.children, child)
+	return child
+}
+
+// LayerOrder returns the cascade layers declared in a list of rules, from
+// the lowest to the highest priority.
+//
+// Layers are ordered by their first declaration, whether it is a @layer
+// statement, a @layer block or an @import with layer(). The sub-layers of
+// a layer come before the layer itself, since the styles directly in a
+// layer win over the ones in its sub-layers. Each name is the full dotted
+// name of the layer, anonymous layers have an empty segment in their name.
+//
+// Conditions of @media, @supports and @container rules are not evaluated,
+// layers declared inside them, inside @scope and @starting-style rules and
+// nested inside style rules are always taken into account.
+//
+// https://www.w3.org/TR/css-cascade-5/#layer-ordering
+func LayerOrder(rules []Rule) []LayerName {
+	root := &layerNode{}
+	collectLayers(rules, root)
+
+	var order []LayerName
+	appendLayerOrder(root, nil, &order)
+	return order
+}
+
+// collectLayers adds the layers declared in rules to the tree of layers.
+func collectLayers(rules []Rule, parent *layerNode) {
+	for _, rule := range rules {
+		switch atRule := rule.(type) {
+		case *ImportRule:
+			if !atRule.Layer {
+				continue
+			}
+			if len(atRule.LayerName) == 0 {
+				parent.declareAnonymous()
+			} else {
+				parent.declare(atRule.LayerName)
+			}
+
+		case *LayerStatementRule:
+			for _, name := range atRule.Names {
+				parent.declare(name)
+			}
+
+		case *LayerBlockRule:
+			var node *layerNode
+			if len(atRule.Name) == 0 {
+				node = parent.declareAnonymous()
+			} else {
+				node = parent.declare(atRule.Name)
+			}
+			collectLayers(atRule.Rules, node)
+
+		case *MediaRule:
+			collectLayers(atRule.Rules, parent)
+
+		case *SupportsRule:
+			collectLayers(atRule.Rules, parent)
+
+		case *ContainerRule:
+			collectLayers(atRule.Rules, parent)
+
+		case *ScopeRule:
+			collectLayers(atRule.Rules, parent)
+
+		case *StartingStyleRule:
+			collectLayers(atRule.Rules, parent)
+
+		case *StyleRule:
+			collectLayers(atRule.Rules, parent)
+		}
+	}
+}
+
+// appendLayerOrder appends the full names of the sub-layers of node to
+// order, each one after its own sub-layers.
+func appendLayerOrder(node *layerNode, prefix LayerName, order *[]LayerName) {
+	for _, child := range node.children {
+		name := make(LayerName, len(prefix), len(prefix)+1)
+		copy(name, prefix)
+		name = append(name, child.name)
+
+		appendLayerOrder(child, name, order)
+		*order = append(*order, name)
+	}
+}
@@ -0,0 +1,108 @@
+package css
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLayerOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		rules    []Rule
+		expected []string
+	}{
+		{
+			name:     "no layers",
+			rules:    []Rule{&StyleRule{Type: StyleRuleTypeQualifiedRule}},
+			expected: nil,
+		},
+		{
+			name: "statement then blocks",
+			rules: []Rule{
+				&LayerStatementRule{Names: []LayerName{{"reset"}, {"base"}}},
+				&LayerBlockRule{Name: LayerName{"theme"}},
+				&LayerBlockRule{Name: LayerName{"reset"}},
+			},
+			expected: []string{"reset", "base", "theme"},
+		},
+		{
+			name: "import layers",
+			rules: []Rule{
+				&ImportRule{URL: "a.css", Layer: true, LayerName: LayerName{"framework"}},
+				&ImportRule{URL: "b.css"},
+				&ImportRule{URL: "c.css", Layer: true},
+				&LayerBlockRule{Name: LayerName{"app"}},
+			},
+			expected: []string{"framework", "", "app"},
+		},
+		{
+			name: "sub-layers come before their parent",
+			rules: []Rule{
+				&LayerStatementRule{Names: []LayerName{{"framework", "base"}}},
+				&LayerBlockRule{
+					Name: LayerName{"framework"},
+					Rules: []Rule{
+						&LayerBlockRule{Name: LayerName{"theme"}},
+						&LayerBlockRule{Name: LayerName{"base"}},
+					},
+				},
+				&LayerStatementRule{Names: []LayerName{{"app"}}},
+			},
+			expected: []string{"framework.base", "framework.theme", "framework", "app"},
+		},
+		{
+			name: "anonymous layers are distinct",
+			rules: []Rule{
+				&LayerBlockRule{Rules: []Rule{
+					&LayerBlockRule{Name: LayerName{"a"}},
+				}},
+				&LayerBlockRule{},
+			},
+			expected: []string{".a", "", ""},
+		},
+		{
+			name: "inside conditional rules",
+			rules: []Rule{
+				&MediaRule{Queries: &MediaQueryList{}, Rules: []Rule{
+					&LayerStatementRule{Names: []LayerName{{"print"}}},
+				}},
+				&SupportsRule{Rules: []Rule{
+					&LayerBlockRule{Name: LayerName{"grid"}},
+				}},
+			},
+			expected: []string{"print", "grid"},
+		},
+		{
+			name: "nested in style and @starting-style rules",
+			rules: []Rule{
+				&LayerStatementRule{Names: []LayerName{{"a"}}},
+				&StyleRule{Type: StyleRuleTypeQualifiedRule, Rules: []Rule{
+					&LayerBlockRule{Name: LayerName{"b"}},
+				}},
+				&StartingStyleRule{Rules: []Rule{
+					&LayerBlockRule{Name: LayerName{"c"}, Rules: []Rule{
+						&StyleRule{Type: StyleRuleTypeQualifiedRule, Rules: []Rule{
+							&LayerBlockRule{Name: LayerName{"d"}},
+						}},
+					}},
+				}},
+			},
+			expected: []string{"a", "b", "c.d", "c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			order := LayerOrder(tt.rules)
+
+			result := make([]string, 0, len(order))
+			for _, name := range order {
+				result = append(result, strings.Join(name, "."))
+			}
+
+			if strings.Join(result, ", ") != strings.Join(tt.expected, ", ") || len(result) != len(tt.expected) {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package css
+
+import (
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== LayerStatementRule =====
+
+// LayerStatementRule represents a @layer rule without a block, which
+// declares the order of one or more layers.
+//
+// https://www.w3.org/TR/css-cascade-5/#layer-empty
+type LayerStatementRule struct {
+	Node
+
+	Names []LayerName // The names of the declared layers
+}
+
+// String returns the string representation of the @layer rule.
+func (r *LayerStatementRule) String() string {
+	nameStrs := make([]string, 0, len(r.Names))
+	for _, name := range r.Names {
+		nameStrs = append(nameStrs, name.String())
+	}
+	return "@layer " + cssutil.SerializeCommaSeparatedList(nameStrs) + ";"
+}
+
+// Equals compares two LayerStatementRule instances.
+func (r *LayerStatementRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*LayerStatementRule)
+	if !ok || otherRule == nil || len(r.Names) != len(otherRule.Names) {
+		return false
+	}
+
+	for i, name := range r.Names {
+		if !name.Equals(otherRule.Names[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ===== LayerBlockRule =====
+
+// LayerBlockRule represents a @layer rule with a block.
+//
+// https://www.w3.org/TR/css-cascade-5/#layer-block
+type LayerBlockRule struct {
+	Node
+
+	Name         LayerName      // The name of the layer, empty for an anonymous layer
+	Declarations []*Declaration // Declarations directly inside a @layer nested in a style rule
+	Rules        []Rule         // Child rules
+}
+
+// String returns the string representation of the @layer rule.
+func (r *LayerBlockRule) String() string {
+	result := "@layer "
+	if len(r.Name) > 0 {
+		result += r.Name.String() + " "
+	}
+	return result + serializeBlock(r.Declarations, r.Rules)
+}
+
+// Equals compares two LayerBlockRule instances.
+func (r *LayerBlockRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*LayerBlockRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Name.Equals(otherRule.Name) &&
+		declarationListEquals(r.Declarations, otherRule.Declarations) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
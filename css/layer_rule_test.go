@@ -0,0 +1,91 @@
+package css
+
+import "testing"
+
+func TestLayerBlockRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *LayerBlockRule
+		expected string
+	}{
+		{
+			name:     "anonymous layer",
+			rule:     &LayerBlockRule{},
+			expected: "@layer { }",
+		},
+		{
+			name: "named layer with rules",
+			rule: &LayerBlockRule{
+				Name: LayerName{"framework", "base"},
+				Rules: []Rule{
+					&StyleRule{
+						Type:         StyleRuleTypeQualifiedRule,
+						Selectors:    []*Selector{{Selectors: []*SimpleSelector{{Match: SelectorMatchTag, Data: NewSelectorDataTag("", "div")}}}},
+						Declarations: []*Declaration{{Property: "color", Value: "red"}},
+					},
+				},
+			},
+			expected: "@layer framework.base { div { color: red; } }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLayerStatementRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *LayerStatementRule
+		expected string
+	}{
+		{
+			name:     "single name",
+			rule:     &LayerStatementRule{Names: []LayerName{{"base"}}},
+			expected: "@layer base;",
+		},
+		{
+			name:     "multiple names",
+			rule:     &LayerStatementRule{Names: []LayerName{{"reset"}, {"framework", "base"}}},
+			expected: "@layer reset, framework.base;",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestLayerStatementRuleEquals(t *testing.T) {
+	rule := &LayerStatementRule{Names: []LayerName{{"a"}, {"b", "c"}}}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{"equal", &LayerStatementRule{Names: []LayerName{{"a"}, {"b", "c"}}}, true},
+		{"different names", &LayerStatementRule{Names: []LayerName{{"a"}, {"b"}}}, false},
+		{"different count", &LayerStatementRule{Names: []LayerName{{"a"}}}, false},
+		{"block", &LayerBlockRule{Name: LayerName{"a"}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rule.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,417 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+
+	"go.baoshuo.dev/cssparser/numeric"
+)
+
+// ===== MediaQueryList =====
+
+// MediaQueryList represents a comma-separated list of media queries.
+//
+// An empty list matches all media. A nil list is the same as an empty
+// one.
+//
+// https://www.w3.org/TR/mediaqueries-5/#media
+type MediaQueryList struct {
+	Queries []*MediaQuery // The media queries in this list
+}
+
+func (l *MediaQueryList) String() string {
+	queries := l.queries()
+	queryStrs := make([]string, 0, len(queries))
+	for _, query := range queries {
+		queryStrs = append(queryStrs, query.String())
+	}
+	return cssutil.SerializeCommaSeparatedList(queryStrs)
+}
+
+func (l *MediaQueryList) Equals(other *MediaQueryList) bool {
+	queries, otherQueries := l.queries(), other.queries()
+	if len(queries) != len(otherQueries) {
+		return false
+	}
+
+	for i, query := range queries {
+		if !query.Equals(otherQueries[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// queries returns the media queries of the list, or nil if the list is
+// nil.
+func (l *MediaQueryList) queries() []*MediaQuery {
+	if l == nil {
+		return nil
+	}
+	return l.Queries
+}
+
+// ===== MediaQueryRestrictorType =====
+
+type MediaQueryRestrictorType int
+
+const (
+	MediaQueryRestrictorNone MediaQueryRestrictorType = iota
+	MediaQueryRestrictorNot                           // Example: not print
+	MediaQueryRestrictorOnly                          // Example: only screen
+)
+
+func (r MediaQueryRestrictorType) String() string {
+	switch r {
+	case MediaQueryRestrictorNot:
+		return "not"
+	case MediaQueryRestrictorOnly:
+		return "only"
+	default:
+		return ""
+	}
+}
+
+// ===== MediaQuery =====
+
+// MediaQuery represents a single media query.
+//
+// Queries which fail to parse are represented as "not all", as required
+// by the specification.
+//
+// https://www.w3.org/TR/mediaqueries-5/#mq-syntax
+type MediaQuery struct {
+	Node
+
+	Restrictor MediaQueryRestrictorType // The "not" or "only" keyword before the media type
+	MediaType  string                   // The lowercased media type, empty if omitted
+	Condition  *MediaCondition          // The media condition, nil if omitted
+}
+
+// NewNotAllMediaQuery returns the "not all" media query, which is what an
+// invalid media query evaluates to.
+func NewNotAllMediaQuery() *MediaQuery {
+	return &MediaQuery{
+		Restrictor: MediaQueryRestrictorNot,
+		MediaType:  "all",
+	}
+}
+
+func (q *MediaQuery) String() string {
+	var result strings.Builder
+
+	if q.Restrictor != MediaQueryRestrictorNone {
+		result.WriteString(q.Restrictor.String())
+		result.WriteString(" ")
+	}
+
+	if q.MediaType != "" {
+		result.WriteString(cssutil.SerializeIdentifier(q.MediaType))
+		if q.Condition != nil {
+			result.WriteString(" and ")
+		}
+	}
+
+	if q.Condition != nil {
+		result.WriteString(q.Condition.String())
+	}
+
+	return result.String()
+}
+
+func (q *MediaQuery) Equals(other *MediaQuery) bool {
+	if other == nil {
+		return false
+	}
+
+	if q.Restrictor != other.Restrictor || q.MediaType != other.MediaType {
+		return false
+	}
+
+	if q.Condition == nil || other.Condition == nil {
+		return q.Condition == nil && other.Condition == nil
+	}
+
+	return q.Condition.Equals(other.Condition)
+}
+
+// ===== MediaConditionType =====
+
+type MediaConditionType int
+
+const (
+	MediaConditionFeature         MediaConditionType = iota // Example: (min-width: 100px)
+	MediaConditionNot                                       // Example: not (color)
+	MediaConditionAnd                                       // Example: (color) and (hover)
+	MediaConditionOr                                        // Example: (color) or (hover)
+	MediaConditionGeneralEnclosed                           // Example: (unknown-thing), foo(bar)
+)
+
+// ===== MediaCondition =====
+
+// MediaCondition represents a node of a media condition tree.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-media-condition
+type MediaCondition struct {
+	Node
+
+	Type            MediaConditionType // The type of the condition node
+	Children        []*MediaCondition  // The operands of not, and & or conditions
+	Feature         *MediaFeature      // The media feature, for feature conditions
+	GeneralEnclosed *ComponentValue    // The function or block, for general-enclosed conditions
+}
+
+func (c *MediaCondition) String() string {
+	switch c.Type {
+	case MediaConditionFeature:
+		return c.Feature.String()
+
+	case MediaConditionNot:
+		return "not " + c.Children[0].operandString()
+
+	case MediaConditionAnd, MediaConditionOr:
+		separator := " and "
+		if c.Type == MediaConditionOr {
+			separator = " or "
+		}
+
+		operandStrs := make([]string, 0, len(c.Children))
+		for _, child := range c.Children {
+			operandStrs = append(operandStrs, child.operandString())
+		}
+		return strings.Join(operandStrs, separator)
+
+	case MediaConditionGeneralEnclosed:
+		return c.GeneralEnclosed.String()
+
+	default:
+		return ""
+	}
+}
+
+// operandString returns the string representation of the condition when
+// it is an operand of another condition, wrapping it in parentheses if
+// needed.
+func (c *MediaCondition) operandString() string {
+	switch c.Type {
+	case MediaConditionNot, MediaConditionAnd, MediaConditionOr:
+		return "(" + c.String() + ")"
+	default:
+		return c.String()
+	}
+}
+
+func (c *MediaCondition) Equals(other *MediaCondition) bool {
+	if other == nil || c.Type != other.Type || len(c.Children) != len(other.Children) {
+		return false
+	}
+
+	for i, child := range c.Children {
+		if !child.Equals(other.Children[i]) {
+			return false
+		}
+	}
+
+	switch c.Type {
+	case MediaConditionFeature:
+		return c.Feature.Equals(other.Feature)
+	case MediaConditionGeneralEnclosed:
+		return c.GeneralEnclosed.Equals(other.GeneralEnclosed)
+	default:
+		return true
+	}
+}
+
+// ===== MediaFeatureType =====
+
+type MediaFeatureType int
+
+const (
+	MediaFeatureBoolean MediaFeatureType = iota // Example: (color)
+	MediaFeaturePlain                           // Example: (min-width: 100px)
+	MediaFeatureRange                           // Example: (100px <= width < 200px)
+)
+
+// ===== MediaComparisonType =====
+
+type MediaComparisonType int
+
+const (
+	MediaComparisonNone MediaComparisonType = iota
+	MediaComparisonEqual
+	MediaComparisonLess
+	MediaComparisonLessOrEqual
+	MediaComparisonGreater
+	MediaComparisonGreaterOrEqual
+)
+
+func (c MediaComparisonType) String() string {
+	switch c {
+	case MediaComparisonEqual:
+		return "="
+	case MediaComparisonLess:
+		return "<"
+	case MediaComparisonLessOrEqual:
+		return "<="
+	case MediaComparisonGreater:
+		return ">"
+	case MediaComparisonGreaterOrEqual:
+		return ">="
+	default:
+		return ""
+	}
+}
+
+// Reverse returns the comparison with its operands swapped, e.g. ">" for
+// "<", so that "100px < width" can be read as "width > 100px".
+func (c MediaComparisonType) Reverse() MediaComparisonType {
+	switch c {
+	case MediaComparisonLess:
+		return MediaComparisonGreater
+	case MediaComparisonLessOrEqual:
+		return MediaComparisonGreaterOrEqual
+	case MediaComparisonGreater:
+		return MediaComparisonLess
+	case MediaComparisonGreaterOrEqual:
+		return MediaComparisonLessOrEqual
+	default:
+		return c
+	}
+}
+
+// ===== MediaFeature =====
+
+// MediaFeature represents a media feature test.
+//
+// For range features, the comparisons are stored as written: in
+// "(100px <= width < 200px)", Left is 100px with LeftComparison "<=" and
+// Right is 200px with RightComparison "<". Either side may be absent.
+//
+// https://www.w3.org/TR/mediaqueries-5/#mq-features
+type MediaFeature struct {
+	Node
+
+	Type            MediaFeatureType    // The type of the feature test
+	Name            string              // The lowercased feature name, including any min-/max- prefix
+	Value           *MediaFeatureValue  // The value, for plain features
+	Left            *MediaFeatureValue  // The value on the left of the name, for range features
+	LeftComparison  MediaComparisonType // The comparison between Left and the name
+	Right           *MediaFeatureValue  // The value on the right of the name, for range features
+	RightComparison MediaComparisonType // The comparison between the name and Right
+}
+
+func (f *MediaFeature) String() string {
+	var result strings.Builder
+
+	result.WriteString("(")
+
+	switch f.Type {
+	case MediaFeatureBoolean:
+		result.WriteString(cssutil.SerializeIdentifier(f.Name))
+
+	case MediaFeaturePlain:
+		result.WriteString(cssutil.SerializeIdentifier(f.Name))
+		result.WriteString(": ")
+		result.WriteString(f.Value.String())
+
+	case MediaFeatureRange:
+		if f.Left != nil {
+			result.WriteString(f.Left.String())
+			result.WriteString(" ")
+			result.WriteString(f.LeftComparison.String())
+			result.WriteString(" ")
+		}
+		result.WriteString(cssutil.SerializeIdentifier(f.Name))
+		if f.Right != nil {
+			result.WriteString(" ")
+			result.WriteString(f.RightComparison.String())
+			result.WriteString(" ")
+			result.WriteString(f.Right.String())
+		}
+	}
+
+	result.WriteString(")")
+
+	return result.String()
+}
+
+func (f *MediaFeature) Equals(other *MediaFeature) bool {
+	if other == nil {
+		return false
+	}
+
+	return f.Type == other.Type &&
+		f.Name == other.Name &&
+		f.Value.Equals(other.Value) &&
+		f.Left.Equals(other.Left) &&
+		f.LeftComparison == other.LeftComparison &&
+		f.Right.Equals(other.Right) &&
+		f.RightComparison == other.RightComparison
+}
+
+// ===== MediaFeatureValueType =====
+
+type MediaFeatureValueType int
+
+const (
+	MediaFeatureValueNumber    MediaFeatureValueType = iota // Example: 2
+	MediaFeatureValueDimension                              // Example: 100px
+	MediaFeatureValueRatio                                  // Example: 16/9
+	MediaFeatureValueIdent                                  // Example: landscape
+	MediaFeatureValueOther                                  // Example: calc(100px + 2em)
+)
+
+// ===== MediaFeatureValue =====
+
+// MediaFeatureValue represents the value of a media feature.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-mf-value
+type MediaFeatureValue struct {
+	Type        MediaFeatureValueType // The type of the value
+	Number      float64               // The number, or the numerator of a ratio
+	Denominator float64               // The denominator of a ratio
+	Unit        string                // The lowercased unit of a dimension
+	Ident       string                // The lowercased identifier
+	Other       *ComponentValue       // The unparsed value, e.g. a math function
+}
+
+func (v *MediaFeatureValue) String() string {
+	switch v.Type {
+	case MediaFeatureValueNumber:
+		return numeric.FormatNumber(v.Number)
+	case MediaFeatureValueDimension:
+		return numeric.FormatNumber(v.Number) + v.Unit
+	case MediaFeatureValueRatio:
+		return numeric.FormatNumber(v.Number) + " / " + numeric.FormatNumber(v.Denominator)
+	case MediaFeatureValueIdent:
+		return cssutil.SerializeIdentifier(v.Ident)
+	case MediaFeatureValueOther:
+		return v.Other.String()
+	default:
+		return ""
+	}
+}
+
+// Equals compares two MediaFeatureValue instances. Two nil values are
+// considered equal.
+func (v *MediaFeatureValue) Equals(other *MediaFeatureValue) bool {
+	if v == nil || other == nil {
+		return v == nil && other == nil
+	}
+
+	if v.Type != other.Type {
+		return false
+	}
+
+	switch v.Type {
+	case MediaFeatureValueOther:
+		return v.Other.Equals(other.Other)
+	default:
+		return v.Number == other.Number &&
+			v.Denominator == other.Denominator &&
+			v.Unit == other.Unit &&
+			v.Ident == other.Ident
+	}
+}
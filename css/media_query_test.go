@@ -0,0 +1,246 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestMediaQueryListString(t *testing.T) {
+	widthFeature := &MediaCondition{
+		Type: MediaConditionFeature,
+		Feature: &MediaFeature{
+			Type:  MediaFeaturePlain,
+			Name:  "min-width",
+			Value: &MediaFeatureValue{Type: MediaFeatureValueDimension, Number: 100, Unit: "px"},
+		},
+	}
+	colorFeature := &MediaCondition{
+		Type:    MediaConditionFeature,
+		Feature: &MediaFeature{Type: MediaFeatureBoolean, Name: "color"},
+	}
+
+	tests := []struct {
+		name     string
+		list     *MediaQueryList
+		expected string
+	}{
+		{
+			name:     "empty list",
+			list:     &MediaQueryList{},
+			expected: "",
+		},
+		{
+			name:     "nil list",
+			list:     nil,
+			expected: "",
+		},
+		{
+			name: "media type with restrictor",
+			list: &MediaQueryList{
+				Queries: []*MediaQuery{{Restrictor: MediaQueryRestrictorOnly, MediaType: "screen"}},
+			},
+			expected: "only screen",
+		},
+		{
+			name: "media type and condition",
+			list: &MediaQueryList{
+				Queries: []*MediaQuery{{MediaType: "screen", Condition: widthFeature}},
+			},
+			expected: "screen and (min-width: 100px)",
+		},
+		{
+			name: "nested conditions",
+			list: &MediaQueryList{
+				Queries: []*MediaQuery{
+					{
+						Condition: &MediaCondition{
+							Type: MediaConditionOr,
+							Children: []*MediaCondition{
+								{Type: MediaConditionAnd, Children: []*MediaCondition{widthFeature, colorFeature}},
+								{Type: MediaConditionNot, Children: []*MediaCondition{colorFeature}},
+							},
+						},
+					},
+				},
+			},
+			expected: "((min-width: 100px) and (color)) or (not (color))",
+		},
+		{
+			name: "multiple queries",
+			list: &MediaQueryList{
+				Queries: []*MediaQuery{{MediaType: "print"}, NewNotAllMediaQuery()},
+			},
+			expected: "print, not all",
+		},
+		{
+			name: "general enclosed",
+			list: &MediaQueryList{
+				Queries: []*MediaQuery{
+					{
+						Condition: &MediaCondition{
+							Type: MediaConditionGeneralEnclosed,
+							GeneralEnclosed: &ComponentValue{
+								Type:     ComponentValueTypeFunction,
+								Token:    csslexer.Token{Type: csslexer.FunctionToken, Value: "foo"},
+								Children: []*ComponentValue{newTestToken(csslexer.IdentToken, "bar")},
+							},
+						},
+					},
+				},
+			},
+			expected: "foo(bar)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.list.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMediaFeatureString(t *testing.T) {
+	tests := []struct {
+		name     string
+		feature  *MediaFeature
+		expected string
+	}{
+		{
+			name:     "boolean",
+			feature:  &MediaFeature{Type: MediaFeatureBoolean, Name: "hover"},
+			expected: "(hover)",
+		},
+		{
+			name: "plain ratio",
+			feature: &MediaFeature{
+				Type:  MediaFeaturePlain,
+				Name:  "aspect-ratio",
+				Value: &MediaFeatureValue{Type: MediaFeatureValueRatio, Number: 16, Denominator: 9},
+			},
+			expected: "(aspect-ratio: 16 / 9)",
+		},
+		{
+			name: "plain ident",
+			feature: &MediaFeature{
+				Type:  MediaFeaturePlain,
+				Name:  "orientation",
+				Value: &MediaFeatureValue{Type: MediaFeatureValueIdent, Ident: "portrait"},
+			},
+			expected: "(orientation: portrait)",
+		},
+		{
+			name: "range with right value",
+			feature: &MediaFeature{
+				Type:            MediaFeatureRange,
+				Name:            "width",
+				Right:           &MediaFeatureValue{Type: MediaFeatureValueDimension, Number: 1.5, Unit: "em"},
+				RightComparison: MediaComparisonGreaterOrEqual,
+			},
+			expected: "(width >= 1.5em)",
+		},
+		{
+			name: "range with both values",
+			feature: &MediaFeature{
+				Type:            MediaFeatureRange,
+				Name:            "resolution",
+				Left:            &MediaFeatureValue{Type: MediaFeatureValueNumber, Number: 1},
+				LeftComparison:  MediaComparisonLess,
+				Right:           &MediaFeatureValue{Type: MediaFeatureValueNumber, Number: 3},
+				RightComparison: MediaComparisonLessOrEqual,
+			},
+			expected: "(1 < resolution <= 3)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.feature.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMediaComparisonTypeReverse(t *testing.T) {
+	tests := []struct {
+		comparison MediaComparisonType
+		expected   MediaComparisonType
+	}{
+		{MediaComparisonNone, MediaComparisonNone},
+		{MediaComparisonEqual, MediaComparisonEqual},
+		{MediaComparisonLess, MediaComparisonGreater},
+		{MediaComparisonLessOrEqual, MediaComparisonGreaterOrEqual},
+		{MediaComparisonGreater, MediaComparisonLess},
+		{MediaComparisonGreaterOrEqual, MediaComparisonLessOrEqual},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.comparison.String(), func(t *testing.T) {
+			if result := tt.comparison.Reverse(); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMediaQueryEquals(t *testing.T) {
+	query := &MediaQuery{
+		MediaType: "screen",
+		Condition: &MediaCondition{
+			Type:    MediaConditionFeature,
+			Feature: &MediaFeature{Type: MediaFeatureBoolean, Name: "color"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		other    *MediaQuery
+		expected bool
+	}{
+		{
+			name: "same query",
+			other: &MediaQuery{
+				MediaType: "screen",
+				Condition: &MediaCondition{
+					Type:    MediaConditionFeature,
+					Feature: &MediaFeature{Type: MediaFeatureBoolean, Name: "color"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "different feature",
+			other: &MediaQuery{
+				MediaType: "screen",
+				Condition: &MediaCondition{
+					Type:    MediaConditionFeature,
+					Feature: &MediaFeature{Type: MediaFeatureBoolean, Name: "hover"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:     "missing condition",
+			other:    &MediaQuery{MediaType: "screen"},
+			expected: false,
+		},
+		{
+			name:     "nil",
+			other:    nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := query.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package css
+
+// ===== MediaRule =====
+
+// MediaRule represents a @media rule.
+//
+// https://www.w3.org/TR/css-conditional-3/#at-media
+type MediaRule struct {
+	Node
+
+	Queries      *MediaQueryList // The media query list of the rule
+	Declarations []*Declaration  // Declarations directly inside a @media nested in a style rule
+	Rules        []Rule          // Child rules
+}
+
+// String returns the string representation of the @media rule.
+func (r *MediaRule) String() string {
+	result := "@media "
+	if queries := r.Queries.String(); queries != "" {
+		result += queries + " "
+	}
+	return result + serializeBlock(r.Declarations, r.Rules)
+}
+
+// Equals compares two MediaRule instances.
+func (r *MediaRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*MediaRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Queries.Equals(otherRule.Queries) &&
+		declarationListEquals(r.Declarations, otherRule.Declarations) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
@@ -0,0 +1,110 @@
+package css
+
+import "testing"
+
+func TestMediaRuleString(t *testing.T) {
+	screen := &MediaQueryList{Queries: []*MediaQuery{{MediaType: "screen"}}}
+
+	tests := []struct {
+		name     string
+		rule     *MediaRule
+		expected string
+	}{
+		{
+			name:     "empty block",
+			rule:     &MediaRule{Queries: screen},
+			expected: "@media screen { }",
+		},
+		{
+			name:     "empty query list",
+			rule:     &MediaRule{Queries: &MediaQueryList{}},
+			expected: "@media { }",
+		},
+		{
+			name:     "nil query list",
+			rule:     &MediaRule{},
+			expected: "@media { }",
+		},
+		{
+			name: "with rules",
+			rule: &MediaRule{
+				Queries: screen,
+				Rules: []Rule{
+					&StyleRule{
+						Type:         StyleRuleTypeQualifiedRule,
+						Selectors:    []*Selector{{Selectors: []*SimpleSelector{{Match: SelectorMatchTag, Data: NewSelectorDataTag("", "div")}}}},
+						Declarations: []*Declaration{{Property: "color", Value: "red"}},
+					},
+				},
+			},
+			expected: "@media screen { div { color: red; } }",
+		},
+		{
+			name: "with declarations",
+			rule: &MediaRule{
+				Queries:      screen,
+				Declarations: []*Declaration{{Property: "color", Value: "red", Important: true}},
+			},
+			expected: "@media screen { color: red !important; }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestMediaRuleEquals(t *testing.T) {
+	rule := &MediaRule{
+		Queries:      &MediaQueryList{Queries: []*MediaQuery{{MediaType: "screen"}}},
+		Declarations: []*Declaration{{Property: "color", Value: "red"}},
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{
+			name: "same rule",
+			other: &MediaRule{
+				Queries:      &MediaQueryList{Queries: []*MediaQuery{{MediaType: "screen"}}},
+				Declarations: []*Declaration{{Property: "color", Value: "red"}},
+			},
+			expected: true,
+		},
+		{
+			name: "different queries",
+			other: &MediaRule{
+				Queries:      &MediaQueryList{Queries: []*MediaQuery{{MediaType: "print"}}},
+				Declarations: []*Declaration{{Property: "color", Value: "red"}},
+			},
+			expected: false,
+		},
+		{
+			name: "different declarations",
+			other: &MediaRule{
+				Queries: &MediaQueryList{Queries: []*MediaQuery{{MediaType: "screen"}}},
+			},
+			expected: false,
+		},
+		{
+			name:     "different at-rule type",
+			other:    &GenericAtRule{Name: "media"},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rule.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
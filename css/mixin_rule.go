@@ -0,0 +1,104 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== MixinRule =====
+
+// MixinRule represents a @mixin rule, which defines a reusable block of
+// declarations and nested rules, e.g. "@mixin --gap(--size) { gap:
+// var(--size); }".
+//
+// https://drafts.csswg.org/css-mixins-1/#mixin-rule
+type MixinRule struct {
+	Node
+
+	Name         string               // The dashed-ident naming the mixin
+	Parameters   []*FunctionParameter // The parameters, nil if the name has no parentheses
+	Declarations []*Declaration       // The declarations of the mixin body
+	Rules        []Rule               // The nested rules of the mixin body
+}
+
+// String returns the string representation of the @mixin rule.
+func (r *MixinRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@mixin ")
+	result.WriteString(cssutil.SerializeIdentifier(r.Name))
+	if r.Parameters != nil {
+		result.WriteString(serializeFunctionParameterList(r.Parameters))
+	}
+	result.WriteString(" ")
+	result.WriteString(serializeBlock(r.Declarations, r.Rules))
+
+	return result.String()
+}
+
+// Equals compares two MixinRule instances.
+func (r *MixinRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*MixinRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Name == otherRule.Name &&
+		(r.Parameters == nil) == (otherRule.Parameters == nil) &&
+		functionParameterListEquals(r.Parameters, otherRule.Parameters) &&
+		declarationListEquals(r.Declarations, otherRule.Declarations) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
+
+// ===== ApplyRule =====
+
+// ApplyRule represents an @apply rule, which inserts the contents of a
+// mixin, e.g. "@apply --gap(1em);".
+//
+// https://drafts.csswg.org/css-mixins-1/#apply-rule
+type ApplyRule struct {
+	Node
+
+	Name      string              // The dashed-ident naming the mixin
+	Arguments [][]*ComponentValue // The arguments, nil if the name has no parentheses
+}
+
+// String returns the string representation of the @apply rule.
+func (r *ApplyRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@apply ")
+	result.WriteString(cssutil.SerializeIdentifier(r.Name))
+	if r.Arguments != nil {
+		argumentStrs := make([]string, 0, len(r.Arguments))
+		for _, argument := range r.Arguments {
+			argumentStrs = append(argumentStrs, SerializeComponentValueList(argument))
+		}
+		result.WriteString("(")
+		result.WriteString(cssutil.SerializeCommaSeparatedList(argumentStrs))
+		result.WriteString(")")
+	}
+	result.WriteString(";")
+
+	return result.String()
+}
+
+// Equals compares two ApplyRule instances.
+func (r *ApplyRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*ApplyRule)
+	if !ok || otherRule == nil ||
+		r.Name != otherRule.Name ||
+		(r.Arguments == nil) != (otherRule.Arguments == nil) ||
+		len(r.Arguments) != len(otherRule.Arguments) {
+		return false
+	}
+
+	for i, argument := range r.Arguments {
+		if !ComponentValueListEquals(argument, otherRule.Arguments[i]) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,75 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestMixinRule(t *testing.T) {
+	rule := &MixinRule{
+		Name:         "--gap",
+		Parameters:   []*FunctionParameter{{Name: "--size"}},
+		Declarations: []*Declaration{{Property: "gap", Value: "var(--size)"}},
+	}
+
+	expected := "@mixin --gap(--size) { gap: var(--size); }"
+	if result := rule.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	withoutParameters := &MixinRule{Name: "--gap", Declarations: rule.Declarations}
+	expected = "@mixin --gap { gap: var(--size); }"
+	if result := withoutParameters.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	if rule.Equals(withoutParameters) {
+		t.Errorf("expected rules with and without parameters to differ")
+	}
+	if !rule.Equals(&MixinRule{Name: "--gap", Parameters: rule.Parameters, Declarations: rule.Declarations}) {
+		t.Errorf("expected rules with the same contents to be equal")
+	}
+}
+
+func TestApplyRule(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *ApplyRule
+		expected string
+	}{
+		{
+			name:     "without arguments",
+			rule:     &ApplyRule{Name: "--center"},
+			expected: "@apply --center;",
+		},
+		{
+			name:     "empty arguments",
+			rule:     &ApplyRule{Name: "--center", Arguments: [][]*ComponentValue{}},
+			expected: "@apply --center();",
+		},
+		{
+			name: "with arguments",
+			rule: &ApplyRule{Name: "--gap", Arguments: [][]*ComponentValue{
+				{newTestToken(csslexer.IdentToken, "a")},
+				{newTestToken(csslexer.IdentToken, "b")},
+			}},
+			expected: "@apply --gap(a, b);",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.rule.String(); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+			if !tt.rule.Equals(tt.rule) {
+				t.Errorf("expected rule to equal itself")
+			}
+		})
+	}
+
+	if (&ApplyRule{Name: "--center"}).Equals(&ApplyRule{Name: "--center", Arguments: [][]*ComponentValue{}}) {
+		t.Errorf("expected rules with and without arguments to differ")
+	}
+}
@@ -0,0 +1,59 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// AnyNamespace is the namespace URI of names matching elements or
+// attributes in any namespace, e.g. "*|div", or "div" when no default
+// namespace is declared.
+const AnyNamespace = "*"
+
+// ===== NamespaceMap =====
+
+// NamespaceMap maps the namespace prefixes declared by @namespace rules to
+// their namespace URIs. The default namespace is stored with an empty
+// prefix.
+//
+// https://www.w3.org/TR/css-namespaces-3/#declaration
+type NamespaceMap map[string]string
+
+// ===== NamespaceRule =====
+
+// NamespaceRule represents a @namespace rule.
+//
+// https://www.w3.org/TR/css-namespaces-3/#declaration
+type NamespaceRule struct {
+	Node
+
+	Prefix string // The declared prefix, empty for the default namespace
+	URI    string // The namespace URI
+}
+
+// String returns the string representation of the @namespace rule.
+func (r *NamespaceRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@namespace ")
+	if r.Prefix != "" {
+		result.WriteString(cssutil.SerializeIdentifier(r.Prefix))
+		result.WriteString(" ")
+	}
+	result.WriteString("url(")
+	result.WriteString(cssutil.SerializeString(r.URI))
+	result.WriteString(");")
+
+	return result.String()
+}
+
+// Equals compares two NamespaceRule instances.
+func (r *NamespaceRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*NamespaceRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Prefix == otherRule.Prefix && r.URI == otherRule.URI
+}
@@ -0,0 +1,69 @@
+package css
+
+import "testing"
+
+func TestNamespaceRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *NamespaceRule
+		expected string
+	}{
+		{
+			name:     "default namespace",
+			rule:     &NamespaceRule{URI: "http://www.w3.org/1999/xhtml"},
+			expected: `@namespace url("http://www.w3.org/1999/xhtml");`,
+		},
+		{
+			name:     "prefixed namespace",
+			rule:     &NamespaceRule{Prefix: "svg", URI: "http://www.w3.org/2000/svg"},
+			expected: `@namespace svg url("http://www.w3.org/2000/svg");`,
+		},
+		{
+			name:     "empty URI",
+			rule:     &NamespaceRule{Prefix: "none"},
+			expected: `@namespace none url("");`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNamespaceRuleEquals(t *testing.T) {
+	rule := &NamespaceRule{Prefix: "svg", URI: "http://www.w3.org/2000/svg"}
+
+	if !rule.Equals(&NamespaceRule{Prefix: "svg", URI: "http://www.w3.org/2000/svg"}) {
+		t.Error("expected identical rules to be equal")
+	}
+
+	if rule.Equals(&NamespaceRule{URI: "http://www.w3.org/2000/svg"}) {
+		t.Error("expected rules with different prefixes to not be equal")
+	}
+
+	if rule.Equals(&NamespaceRule{Prefix: "svg", URI: "http://www.w3.org/1999/xhtml"}) {
+		t.Error("expected rules with different URIs to not be equal")
+	}
+
+	if rule.Equals(&GenericAtRule{Name: "namespace"}) {
+		t.Error("expected rules of different types to not be equal")
+	}
+}
+
+func TestSelectorDataTagNoNamespace(t *testing.T) {
+	data := NewSelectorDataTag("", "div")
+	data.NamespaceURI = ""
+
+	if result := data.String(SelectorMatchTag); result != "|div" {
+		t.Errorf("expected %q, got %q", "|div", result)
+	}
+
+	if data.Equals(NewSelectorDataTag("", "div")) {
+		t.Error("expected tags in different namespaces to not be equal")
+	}
+}
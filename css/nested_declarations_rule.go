@@ -0,0 +1,33 @@
+package css
+
+// ===== NestedDeclarationsRule =====
+
+// NestedDeclarationsRule represents declarations which are directly inside
+// a nesting context but are not at the start of a style rule, e.g. the
+// declarations directly inside a @scope rule. They apply as if they were
+// in a style rule with the given implicit selectors.
+//
+// https://www.w3.org/TR/css-nesting-1/#nested-declarations-rule
+type NestedDeclarationsRule struct {
+	Node
+
+	Selectors    []*Selector    // The implicit selectors of the declarations
+	Declarations []*Declaration // The declarations
+}
+
+// String returns the string representation of the nested declarations.
+// The selectors are implicit, only the declarations are written.
+func (r *NestedDeclarationsRule) String() string {
+	return serializeDeclarationList(r.Declarations)
+}
+
+// Equals compares two NestedDeclarationsRule instances.
+func (r *NestedDeclarationsRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*NestedDeclarationsRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return selectorListEquals(r.Selectors, otherRule.Selectors) &&
+		declarationListEquals(r.Declarations, otherRule.Declarations)
+}
@@ -0,0 +1,44 @@
+package css
+
+import "testing"
+
+func TestNestedDeclarationsRuleString(t *testing.T) {
+	rule := &NestedDeclarationsRule{
+		Selectors: []*Selector{
+			{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("a")}}},
+		},
+		Declarations: []*Declaration{
+			{Property: "color", Value: "red"},
+			{Property: "margin", Value: "0", Important: true},
+		},
+	}
+
+	expected := "color: red; margin: 0 !important;"
+	if result := rule.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+}
+
+func TestNestedDeclarationsRuleEquals(t *testing.T) {
+	rule := &NestedDeclarationsRule{Declarations: []*Declaration{{Property: "color", Value: "red"}}}
+
+	tests := []struct {
+		name     string
+		other    Rule
+		expected bool
+	}{
+		{"identical", &NestedDeclarationsRule{Declarations: []*Declaration{{Property: "color", Value: "red"}}}, true},
+		{"different declarations", &NestedDeclarationsRule{Declarations: []*Declaration{{Property: "color", Value: "blue"}}}, false},
+		{"different type", &StyleRule{Declarations: []*Declaration{{Property: "color", Value: "red"}}}, false},
+		{"nil", (*NestedDeclarationsRule)(nil), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := rule.Equals(tt.other)
+			if result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
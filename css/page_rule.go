@@ -0,0 +1,140 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== PageRule =====
+
+// PageRule represents a @page rule.
+//
+// Each page selector is a Selector made of an optional type selector
+// holding the page type name, followed by page pseudo-classes.
+//
+// https://www.w3.org/TR/css-page-3/#at-page-rule
+type PageRule struct {
+	Node
+
+	Selectors   []*Selector       // The page selectors, empty if the rule applies to all pages
+	Descriptors []*Declaration    // The page properties and descriptors
+	MarginRules []*PageMarginRule // The margin rules, in source order
+}
+
+// String returns the string representation of the @page rule.
+func (r *PageRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@page ")
+
+	if len(r.Selectors) > 0 {
+		selectorStrs := make([]string, 0, len(r.Selectors))
+		for _, sel := range r.Selectors {
+			selectorStrs = append(selectorStrs, serializePageSelector(sel))
+		}
+		result.WriteString(cssutil.SerializeCommaSeparatedList(selectorStrs))
+		result.WriteString(" ")
+	}
+
+	result.WriteString("{")
+	for _, decl := range r.Descriptors {
+		result.WriteString(" ")
+		result.WriteString(decl.String())
+		result.WriteString(";")
+	}
+	for _, marginRule := range r.MarginRules {
+		result.WriteString(" ")
+		result.WriteString(marginRule.String())
+	}
+	result.WriteString(" }")
+
+	return result.String()
+}
+
+// Equals compares two PageRule instances.
+func (r *PageRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*PageRule)
+	if !ok || otherRule == nil ||
+		len(r.Selectors) != len(otherRule.Selectors) ||
+		len(r.MarginRules) != len(otherRule.MarginRules) {
+		return false
+	}
+
+	for i, sel := range r.Selectors {
+		if !sel.Equals(otherRule.Selectors[i]) {
+			return false
+		}
+	}
+
+	for i, marginRule := range r.MarginRules {
+		if !marginRule.Equals(otherRule.MarginRules[i]) {
+			return false
+		}
+	}
+
+	return declarationListEquals(r.Descriptors, otherRule.Descriptors)
+}
+
+// serializePageSelector returns the string representation of a page
+// selector, e.g. "chapter:first".
+func serializePageSelector(sel *Selector) string {
+	var result strings.Builder
+
+	for _, simple := range sel.Selectors {
+		switch data := simple.Data.(type) {
+		case *SelectorDataTag:
+			result.WriteString(cssutil.SerializeIdentifier(data.TagName))
+		case *SelectorDataPseudo:
+			result.WriteString(":")
+			result.WriteString(cssutil.SerializeIdentifier(data.PseudoName))
+		}
+	}
+
+	return result.String()
+}
+
+// ===== PageMarginRule =====
+
+// PageMarginRule represents a margin rule inside a @page rule, e.g.
+// "@top-left { content: 'Chapter 1' }".
+//
+// https://www.w3.org/TR/css-page-3/#margin-at-rules
+type PageMarginRule struct {
+	Node
+
+	Name         string         // The lowercased name of the margin box, without the leading '@'
+	Declarations []*Declaration // The declarations of the margin box
+}
+
+// String returns the string representation of the margin rule.
+func (r *PageMarginRule) String() string {
+	return "@" + r.Name + " " + serializeBlock(r.Declarations, nil)
+}
+
+// Equals compares two PageMarginRule instances.
+func (r *PageMarginRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*PageMarginRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Name == otherRule.Name &&
+		declarationListEquals(r.Declarations, otherRule.Declarations)
+}
+
+// IsPageMarginBoxName checks if a lowercased at-rule name is the name of
+// one of the 16 page margin boxes.
+//
+// https://www.w3.org/TR/css-page-3/#margin-boxes
+func IsPageMarginBoxName(name string) bool {
+	switch name {
+	case "top-left-corner", "top-left", "top-center", "top-right", "top-right-corner",
+		"bottom-left-corner", "bottom-left", "bottom-center", "bottom-right", "bottom-right-corner",
+		"left-top", "left-middle", "left-bottom",
+		"right-top", "right-middle", "right-bottom":
+		return true
+	default:
+		return false
+	}
+}
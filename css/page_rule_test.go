@@ -0,0 +1,84 @@
+package css
+
+import "testing"
+
+func TestPageRuleString(t *testing.T) {
+	firstPage := &Selector{Selectors: []*SimpleSelector{
+		{Match: SelectorMatchTag, Data: NewSelectorDataTag("", "chapter")},
+		{Match: SelectorMatchPagePseudoClass, Data: NewSelectorDataPseudo("first", SelectorPseudoFirstPage)},
+	}}
+	leftPage := &Selector{Selectors: []*SimpleSelector{
+		{Match: SelectorMatchPagePseudoClass, Data: NewSelectorDataPseudo("left", SelectorPseudoLeftPage)},
+	}}
+
+	tests := []struct {
+		name     string
+		rule     *PageRule
+		expected string
+	}{
+		{
+			name:     "empty",
+			rule:     &PageRule{},
+			expected: "@page { }",
+		},
+		{
+			name: "selectors and descriptors",
+			rule: &PageRule{
+				Selectors:   []*Selector{firstPage, leftPage},
+				Descriptors: []*Declaration{{Property: "size", Value: "A4"}, {Property: "margin", Value: "2cm", Important: true}},
+			},
+			expected: "@page chapter:first, :left { size: A4; margin: 2cm !important; }",
+		},
+		{
+			name: "margin rules",
+			rule: &PageRule{
+				Descriptors: []*Declaration{{Property: "size", Value: "A4"}},
+				MarginRules: []*PageMarginRule{
+					{Name: "top-center", Declarations: []*Declaration{{Property: "content", Value: `"Title"`}}},
+					{Name: "bottom-right-corner"},
+				},
+			},
+			expected: `@page { size: A4; @top-center { content: "Title"; } @bottom-right-corner { } }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPageRuleEquals(t *testing.T) {
+	newRule := func(marginBox string) *PageRule {
+		return &PageRule{
+			Selectors: []*Selector{{Selectors: []*SimpleSelector{
+				{Match: SelectorMatchPagePseudoClass, Data: NewSelectorDataPseudo("first", SelectorPseudoFirstPage)},
+			}}},
+			Descriptors: []*Declaration{{Property: "size", Value: "A4"}},
+			MarginRules: []*PageMarginRule{{Name: marginBox}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{"equal", newRule("top-left"), true},
+		{"different margin rule", newRule("top-right"), false},
+		{"different selectors", &PageRule{Descriptors: []*Declaration{{Property: "size", Value: "A4"}}, MarginRules: []*PageMarginRule{{Name: "top-left"}}}, false},
+		{"different type", &PageMarginRule{Name: "top-left"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newRule("top-left").Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package css
+
+import (
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== PositionTryRule =====
+
+// PositionTryRule represents a @position-try rule, which defines a
+// fallback position for an anchor-positioned element.
+//
+// Only declarations of the properties accepted by IsPositionTryProperty
+// are kept, and they can't be !important.
+//
+// https://www.w3.org/TR/css-anchor-position-1/#fallback-rule
+type PositionTryRule struct {
+	Node
+
+	Name         string         // The dashed-ident naming the fallback position
+	Declarations []*Declaration // The valid declarations, in source order
+}
+
+// String returns the string representation of the @position-try rule.
+func (r *PositionTryRule) String() string {
+	return "@position-try " + cssutil.SerializeIdentifier(r.Name) + " " + serializeBlock(r.Declarations, nil)
+}
+
+// Equals compares two PositionTryRule instances.
+func (r *PositionTryRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*PositionTryRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Name == otherRule.Name && declarationListEquals(r.Declarations, otherRule.Declarations)
+}
+
+// IsPositionTryProperty checks if a lowercased property can be used in a
+// @position-try rule, i.e. if it is an inset, margin, sizing or
+// self-alignment property, or position-anchor or position-area.
+//
+// https://www.w3.org/TR/css-anchor-position-1/#fallback-rule
+func IsPositionTryProperty(name string) bool {
+	switch name {
+	case "top", "left", "right", "bottom", "inset",
+		"inset-block", "inset-block-start", "inset-block-end",
+		"inset-inline", "inset-inline-start", "inset-inline-end",
+		"margin", "margin-top", "margin-left", "margin-right", "margin-bottom",
+		"margin-block", "margin-block-start", "margin-block-end",
+		"margin-inline", "margin-inline-start", "margin-inline-end",
+		"width", "height", "min-width", "min-height", "max-width", "max-height",
+		"block-size", "inline-size", "min-block-size", "min-inline-size", "max-block-size", "max-inline-size",
+		"align-self", "justify-self", "place-self",
+		"position-anchor", "position-area":
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,46 @@
+package css
+
+import "testing"
+
+func TestPositionTryRule(t *testing.T) {
+	rule := &PositionTryRule{
+		Name:         "--flip",
+		Declarations: []*Declaration{{Property: "top", Value: "anchor(bottom)"}},
+	}
+
+	expected := "@position-try --flip { top: anchor(bottom); }"
+	if result := rule.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	if rule.Equals(&PositionTryRule{Name: "--other", Declarations: rule.Declarations}) {
+		t.Errorf("expected rules with different names to differ")
+	}
+	if !rule.Equals(&PositionTryRule{Name: "--flip", Declarations: rule.Declarations}) {
+		t.Errorf("expected rules with the same contents to be equal")
+	}
+}
+
+func TestIsPositionTryProperty(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected bool
+	}{
+		{"inset-block-start", true},
+		{"margin", true},
+		{"max-inline-size", true},
+		{"place-self", true},
+		{"position-area", true},
+		{"color", false},
+		{"position", false},
+		{"transform", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsPositionTryProperty(tt.name); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== PropertyRule =====
+
+// PropertyRule represents a @property rule, which registers a custom
+// property.
+//
+// Only valid rules are kept: the syntax and inherits descriptors are
+// present, and the initial value matches the syntax.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#at-property-rule
+type PropertyRule struct {
+	Node
+
+	Name         string            // The name of the custom property, including the leading "--"
+	Syntax       *PropertySyntax   // The parsed syntax descriptor
+	Inherits     bool              // The inherits descriptor
+	InitialValue []*ComponentValue // The initial-value descriptor, nil if omitted
+}
+
+// String returns the string representation of the @property rule.
+func (r *PropertyRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@property ")
+	result.WriteString(cssutil.SerializeIdentifier(r.Name))
+	result.WriteString(" { syntax: ")
+	result.WriteString(cssutil.SerializeString(r.Syntax.String()))
+	result.WriteString("; inherits: ")
+	if r.Inherits {
+		result.WriteString("true")
+	} else {
+		result.WriteString("false")
+	}
+	result.WriteString(";")
+	if r.InitialValue != nil {
+		result.WriteString(" initial-value: ")
+		result.WriteString(SerializeComponentValueList(r.InitialValue))
+		result.WriteString(";")
+	}
+	result.WriteString(" }")
+
+	return result.String()
+}
+
+// Equals compares two PropertyRule instances.
+func (r *PropertyRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*PropertyRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	if (r.InitialValue == nil) != (otherRule.InitialValue == nil) {
+		return false
+	}
+
+	return r.Name == otherRule.Name &&
+		r.Syntax.Equals(otherRule.Syntax) &&
+		r.Inherits == otherRule.Inherits &&
+		ComponentValueListEquals(r.InitialValue, otherRule.InitialValue)
+}
@@ -0,0 +1,88 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestPropertyRuleString(t *testing.T) {
+	lengthSyntax := &PropertySyntax{Components: []*PropertySyntaxComponent{
+		{Type: PropertySyntaxComponentDataType, Name: "length"},
+	}}
+	listSyntax := &PropertySyntax{Components: []*PropertySyntaxComponent{
+		{Type: PropertySyntaxComponentDataType, Name: "color", Multiplier: PropertySyntaxMultiplierComma},
+		{Type: PropertySyntaxComponentIdent, Name: "none"},
+	}}
+
+	tests := []struct {
+		name     string
+		rule     *PropertyRule
+		expected string
+	}{
+		{
+			name:     "universal syntax",
+			rule:     &PropertyRule{Name: "--x", Syntax: &PropertySyntax{}, Inherits: true},
+			expected: `@property --x { syntax: "*"; inherits: true; }`,
+		},
+		{
+			name: "initial value",
+			rule: &PropertyRule{
+				Name:         "--x",
+				Syntax:       lengthSyntax,
+				InitialValue: []*ComponentValue{newTestToken(csslexer.DimensionToken, "0px")},
+			},
+			expected: `@property --x { syntax: "<length>"; inherits: false; initial-value: 0px; }`,
+		},
+		{
+			name: "multiple components",
+			rule: &PropertyRule{
+				Name:         "--colors",
+				Syntax:       listSyntax,
+				InitialValue: []*ComponentValue{newTestToken(csslexer.IdentToken, "none")},
+			},
+			expected: `@property --colors { syntax: "<color># | none"; inherits: false; initial-value: none; }`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestPropertyRuleEquals(t *testing.T) {
+	newRule := func(multiplier PropertySyntaxMultiplier, initialValue []*ComponentValue) *PropertyRule {
+		return &PropertyRule{
+			Name: "--x",
+			Syntax: &PropertySyntax{Components: []*PropertySyntaxComponent{
+				{Type: PropertySyntaxComponentDataType, Name: "length", Multiplier: multiplier},
+			}},
+			InitialValue: initialValue,
+		}
+	}
+	zero := []*ComponentValue{newTestToken(csslexer.DimensionToken, "0px")}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{"equal", newRule(PropertySyntaxMultiplierNone, zero), true},
+		{"different multiplier", newRule(PropertySyntaxMultiplierSpace, zero), false},
+		{"missing initial value", newRule(PropertySyntaxMultiplierNone, nil), false},
+		{"different type", &GenericAtRule{Name: "property"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newRule(PropertySyntaxMultiplierNone, zero).Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== PropertySyntax =====
+
+// PropertySyntax represents the value of the syntax descriptor of a
+// @property rule, e.g. "<length> | <percentage>+".
+//
+// The universal syntax definition "*" has no components.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#syntax-strings
+type PropertySyntax struct {
+	Components []*PropertySyntaxComponent // The alternatives, in the order they are tried
+}
+
+// IsUniversal returns true for the universal syntax definition "*", which
+// accepts any value.
+func (s *PropertySyntax) IsUniversal() bool {
+	return len(s.Components) == 0
+}
+
+func (s *PropertySyntax) String() string {
+	if s.IsUniversal() {
+		return "*"
+	}
+
+	componentStrs := make([]string, 0, len(s.Components))
+	for _, component := range s.Components {
+		componentStrs = append(componentStrs, component.String())
+	}
+	return strings.Join(componentStrs, " | ")
+}
+
+func (s *PropertySyntax) Equals(other *PropertySyntax) bool {
+	if other == nil || len(s.Components) != len(other.Components) {
+		return false
+	}
+
+	for i, component := range s.Components {
+		if *component != *other.Components[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ===== PropertySyntaxComponentType =====
+
+type PropertySyntaxComponentType int
+
+const (
+	PropertySyntaxComponentDataType PropertySyntaxComponentType = iota // Example: <length>
+	PropertySyntaxComponentIdent                                       // Example: auto
+)
+
+// ===== PropertySyntaxMultiplier =====
+
+type PropertySyntaxMultiplier int
+
+const (
+	PropertySyntaxMultiplierNone  PropertySyntaxMultiplier = iota
+	PropertySyntaxMultiplierSpace                          // Example: <length>+
+	PropertySyntaxMultiplierComma                          // Example: <length>#
+)
+
+func (m PropertySyntaxMultiplier) String() string {
+	switch m {
+	case PropertySyntaxMultiplierSpace:
+		return "+"
+	case PropertySyntaxMultiplierComma:
+		return "#"
+	default:
+		return ""
+	}
+}
+
+// ===== PropertySyntaxComponent =====
+
+// PropertySyntaxComponent represents a single alternative of a syntax
+// definition: a data type name or an identifier, with an optional
+// multiplier.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#syntax-component
+type PropertySyntaxComponent struct {
+	Type       PropertySyntaxComponentType // The type of the component
+	Name       string                      // The data type name without '<' and '>', or the identifier
+	Multiplier PropertySyntaxMultiplier    // The multiplier of the component
+}
+
+func (c *PropertySyntaxComponent) String() string {
+	var name string
+	if c.Type == PropertySyntaxComponentDataType {
+		name = "<" + c.Name + ">"
+	} else {
+		name = cssutil.SerializeIdentifier(c.Name)
+	}
+	return name + c.Multiplier.String()
+}
@@ -0,0 +1,27 @@
+package css
+
+// ===== Rule =====
+
+// Rule is implemented by every node of a list of rules: style rules,
+// keyframe rules, nested declarations rules and at-rules.
+//
+// https://www.w3.org/TR/cssom-1/#the-cssrule-interface
+type Rule interface {
+	String() string
+	Equals(other Rule) bool
+}
+
+// ruleListEquals compares two lists of rules.
+func ruleListEquals(a, b []Rule) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, rule := range a {
+		if !rule.Equals(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
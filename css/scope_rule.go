@@ -0,0 +1,53 @@
+package css
+
+import (
+	"strings"
+)
+
+// ===== ScopeRule =====
+
+// ScopeRule represents a @scope rule.
+//
+// Declarations directly inside the rule are kept as a NestedDeclarationsRule
+// in Rules, which matches like ":where(:scope)".
+//
+// https://www.w3.org/TR/css-cascade-6/#scope-atrule
+type ScopeRule struct {
+	Node
+
+	Start []*Selector // The <scope-start> selectors, empty if omitted
+	End   []*Selector // The <scope-end> selectors, empty if omitted
+	Rules []Rule      // Child rules
+}
+
+// String returns the string representation of the @scope rule.
+func (r *ScopeRule) String() string {
+	var result strings.Builder
+
+	result.WriteString("@scope ")
+	if len(r.Start) > 0 {
+		result.WriteString("(")
+		result.WriteString(serializeSelectorList(r.Start))
+		result.WriteString(") ")
+	}
+	if len(r.End) > 0 {
+		result.WriteString("to (")
+		result.WriteString(serializeSelectorList(r.End))
+		result.WriteString(") ")
+	}
+	result.WriteString(serializeBlock(nil, r.Rules))
+
+	return result.String()
+}
+
+// Equals compares two ScopeRule instances.
+func (r *ScopeRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*ScopeRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return selectorListEquals(r.Start, otherRule.Start) &&
+		selectorListEquals(r.End, otherRule.End) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
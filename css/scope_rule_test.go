@@ -0,0 +1,91 @@
+package css
+
+import "testing"
+
+func TestScopeRuleString(t *testing.T) {
+	classSelector := func(name string) *Selector {
+		return &Selector{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData(name)}}}
+	}
+	styleRule := &StyleRule{
+		Type:         StyleRuleTypeQualifiedRule,
+		Selectors:    []*Selector{{Selectors: []*SimpleSelector{{Match: SelectorMatchTag, Data: NewSelectorDataTag("", "img")}}}},
+		Declarations: []*Declaration{{Property: "border", Value: "none"}},
+	}
+
+	tests := []struct {
+		name     string
+		rule     *ScopeRule
+		expected string
+	}{
+		{
+			name:     "empty prelude",
+			rule:     &ScopeRule{},
+			expected: "@scope { }",
+		},
+		{
+			name: "start and end",
+			rule: &ScopeRule{
+				Start: []*Selector{classSelector("card"), classSelector("panel")},
+				End:   []*Selector{classSelector("content")},
+				Rules: []Rule{styleRule},
+			},
+			expected: "@scope (.card, .panel) to (.content) { img { border: none; } }",
+		},
+		{
+			name: "end only",
+			rule: &ScopeRule{
+				End: []*Selector{classSelector("content")},
+			},
+			expected: "@scope to (.content) { }",
+		},
+		{
+			name: "nested declarations",
+			rule: &ScopeRule{
+				Start: []*Selector{classSelector("card")},
+				Rules: []Rule{
+					&NestedDeclarationsRule{
+						Declarations: []*Declaration{
+							{Property: "color", Value: "red"},
+							{Property: "margin", Value: "0", Important: true},
+						},
+					},
+					styleRule,
+				},
+			},
+			expected: "@scope (.card) { color: red; margin: 0 !important; img { border: none; } }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestScopeRuleEquals(t *testing.T) {
+	newRule := func(start string) *ScopeRule {
+		return &ScopeRule{
+			Start: []*Selector{{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData(start)}}}},
+		}
+	}
+
+	if !newRule("card").Equals(newRule("card")) {
+		t.Error("expected identical rules to be equal")
+	}
+
+	if newRule("card").Equals(newRule("panel")) {
+		t.Error("expected rules with different scope starts to not be equal")
+	}
+
+	if newRule("card").Equals(&ScopeRule{}) {
+		t.Error("expected rules with and without scope start to not be equal")
+	}
+
+	if newRule("card").Equals(&MediaRule{}) {
+		t.Error("expected rules of different types to not be equal")
+	}
+}
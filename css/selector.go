@@ -2,6 +2,8 @@ package css
 
 import (
 	"strings"
+
+	"go.baoshuo.dev/cssutil"
 )
 
 // ===== SelectorListFlagType =====
@@ -34,6 +36,8 @@ func (s *SelectorListFlagType) Set(flag SelectorListFlagType) {
 //
 // The selectors are stored in the order they appear in the CSS.
 type Selector struct {
+	Node
+
 	Flag      SelectorListFlagType // Flags for the selector
 	Selectors []*SimpleSelector    // The list of selectors in this selector list
 }
@@ -62,7 +66,12 @@ func (s *Selector) Prepend(sel *SimpleSelector) {
 func (s *Selector) String() string {
 	var result strings.Builder
 
-	for _, sel := range s.Selectors {
+	for i, sel := range s.Selectors {
+		if i+1 < len(s.Selectors) && sel.isOmittableUniversal(s.Selectors[i+1]) {
+			result.WriteString(sel.Relation.String())
+			continue
+		}
+
 		result.WriteString(sel.String())
 	}
 
@@ -83,11 +92,38 @@ func (s *Selector) Equals(other *Selector) bool {
 	return true
 }
 
+// serializeSelectorList returns the string representation of a
+// comma-separated list of selectors.
+func serializeSelectorList(selectors []*Selector) string {
+	selectorStrs := make([]string, 0, len(selectors))
+	for _, sel := range selectors {
+		selectorStrs = append(selectorStrs, sel.String())
+	}
+	return cssutil.SerializeCommaSeparatedList(selectorStrs)
+}
+
+// selectorListEquals compares two lists of selectors.
+func selectorListEquals(a, b []*Selector) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i, sel := range a {
+		if !sel.Equals(b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
+
 // ===== SimpleSelector =====
 
 // SimpleSelector represents a single simple selector within a compound selector.
 // It can represent various types of selectors such as tag, class, id, attribute, etc.
 type SimpleSelector struct {
+	Node
+
 	Match    SelectorMatchType    // The type of selector match.
 	Relation SelectorRelationType // The relation to the previous selector in the list.
 	Data     SelectorDataType
@@ -108,6 +144,21 @@ func (s *SimpleSelector) String() string {
 	return result.String()
 }
 
+// isOmittableUniversal returns true if the simple selector is a universal
+// selector without a namespace prefix followed by other simple selectors of
+// the same compound selector, e.g. the implicit "*" of ".note" when a
+// default namespace is declared. Such a selector is not serialized.
+//
+// https://drafts.csswg.org/cssom/#serializing-selectors
+func (s *SimpleSelector) isOmittableUniversal(next *SimpleSelector) bool {
+	if s.Match != SelectorMatchUniversalTag || next.Relation != SelectorRelationSubSelector {
+		return false
+	}
+
+	tag, ok := s.Data.(*SelectorDataTag)
+	return ok && tag.Namespace == "" && tag.NamespaceURI != ""
+}
+
 func (s *SimpleSelector) Equals(other *SimpleSelector) bool {
 	if other == nil {
 		return false
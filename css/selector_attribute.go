@@ -7,10 +7,11 @@ import (
 // ===== SelectorDataAttribute =====
 
 type SelectorDataAttr struct {
-	AttrNamespace string                // The namespace of the attribute, if any.
-	AttrName      string                // The name of the attribute.
-	AttrValue     string                // The value of the attribute.
-	AttrMatch     SelectorAttrMatchType // The match type for attribute selectors.
+	AttrNamespace    string                // The namespace prefix of the attribute as written, "*" for any namespace, if any.
+	AttrNamespaceURI string                // The namespace URI of the attribute, AnyNamespace for any namespace, empty for no namespace.
+	AttrName         string                // The name of the attribute.
+	AttrValue        string                // The value of the attribute.
+	AttrMatch        SelectorAttrMatchType // The match type for attribute selectors.
 }
 
 func NewSelectorDataAttr(name, value string, match SelectorAttrMatchType) *SelectorDataAttr {
@@ -23,6 +24,13 @@ func NewSelectorDataAttr(name, value string, match SelectorAttrMatchType) *Selec
 
 func (d *SelectorDataAttr) String(match SelectorMatchType) string {
 	attrName := cssutil.SerializeIdentifier(d.AttrName)
+	switch d.AttrNamespace {
+	case "":
+	case "*":
+		attrName = "*|" + attrName
+	default:
+		attrName = cssutil.SerializeIdentifier(d.AttrNamespace) + "|" + attrName
+	}
 	attrValue := cssutil.SerializeString(d.AttrValue)
 
 	switch match {
@@ -52,6 +60,7 @@ func (d *SelectorDataAttr) Equals(other SelectorDataType) bool {
 	}
 
 	return d.AttrNamespace == otherData.AttrNamespace &&
+		d.AttrNamespaceURI == otherData.AttrNamespaceURI &&
 		d.AttrName == otherData.AttrName &&
 		d.AttrValue == otherData.AttrValue &&
 		d.AttrMatch == otherData.AttrMatch
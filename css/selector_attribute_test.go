@@ -180,6 +180,16 @@ func TestSelectorDataAttrWithNamespace(t *testing.T) {
 	if attr.Equals(attr3) {
 		t.Error("expected attributes with different namespaces to not be equal")
 	}
+
+	if result := attr.String(SelectorMatchAttributeExact); result != `[xml|lang="en"]` {
+		t.Errorf("expected %q, got %q", `[xml|lang="en"]`, result)
+	}
+
+	attr4 := NewSelectorDataAttr("lang", "", SelectorAttrMatchCaseSensitive)
+	attr4.AttrNamespace = "*"
+	if result := attr4.String(SelectorMatchAttributeSet); result != "[*|lang]" {
+		t.Errorf("expected %q, got %q", "[*|lang]", result)
+	}
 }
 
 func TestSelectorAttrMatchTypes(t *testing.T) {
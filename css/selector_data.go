@@ -57,14 +57,19 @@ func (d *SelectorData) Equals(other SelectorDataType) bool {
 // ===== SelectorDataTag =====
 
 type SelectorDataTag struct {
-	Namespace string // The namespace of the tag, if any.
-	TagName   string // The tag name.
+	Namespace    string // The namespace prefix of the tag as written, "*" for any namespace, if any.
+	TagName      string // The tag name.
+	NamespaceURI string // The namespace URI of the tag, AnyNamespace for any namespace, empty for no namespace.
 }
 
+// NewSelectorDataTag returns the data of a type or universal selector. The
+// namespace URI is left as AnyNamespace, which is what names resolve to
+// when no namespace is declared.
 func NewSelectorDataTag(namespace, tagName string) *SelectorDataTag {
 	return &SelectorDataTag{
-		Namespace: namespace,
-		TagName:   tagName,
+		Namespace:    namespace,
+		TagName:      tagName,
+		NamespaceURI: AnyNamespace,
 	}
 }
 
@@ -76,9 +81,15 @@ func (d *SelectorDataTag) String(match SelectorMatchType) string {
 		tagName = cssutil.SerializeIdentifier(d.TagName)
 	}
 
-	if d.Namespace != "" {
+	switch {
+	case d.Namespace == "*":
+		return "*|" + tagName
+	case d.Namespace != "":
 		return cssutil.SerializeIdentifier(d.Namespace) + "|" + tagName
-	} else {
+	case d.NamespaceURI == "":
+		// Explicitly in no namespace, e.g. "|div"
+		return "|" + tagName
+	default:
 		return tagName
 	}
 }
@@ -88,5 +99,7 @@ func (d *SelectorDataTag) Equals(other SelectorDataType) bool {
 	if !ok {
 		return false
 	}
-	return d.Namespace == otherData.Namespace && d.TagName == otherData.TagName
+	return d.Namespace == otherData.Namespace &&
+		d.TagName == otherData.TagName &&
+		d.NamespaceURI == otherData.NamespaceURI
 }
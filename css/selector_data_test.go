@@ -104,6 +104,13 @@ func TestSelectorDataTagString(t *testing.T) {
 			match:     SelectorMatchUniversalTag,
 			expected:  "xml|*",
 		},
+		{
+			name:      "any namespace",
+			namespace: "*",
+			tagName:   "rect",
+			match:     SelectorMatchTag,
+			expected:  "*|rect",
+		},
 		{
 			name:     "complex tag name",
 			tagName:  "custom-element",
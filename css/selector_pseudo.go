@@ -27,6 +27,10 @@ func NewSelectorDataPseudo(pseudoName string, pseudoType SelectorPseudoType) *Se
 }
 
 func (d *SelectorDataPseudo) String(match SelectorMatchType) string {
+	if d.PseudoType == SelectorPseudoParent {
+		return "&"
+	}
+
 	var prefix string
 	switch match {
 	case SelectorMatchPseudoClass:
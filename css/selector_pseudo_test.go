@@ -29,6 +29,15 @@ func TestSelectorDataPseudoString(t *testing.T) {
 			match:    SelectorMatchPseudoElement,
 			expected: "::before",
 		},
+		{
+			name: "nesting parent",
+			pseudo: &SelectorDataPseudo{
+				PseudoType: SelectorPseudoParent,
+				PseudoName: "parent",
+			},
+			match:    SelectorMatchPseudoClass,
+			expected: "&",
+		},
 		{
 			name: "page pseudo class",
 			pseudo: &SelectorDataPseudo{
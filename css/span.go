@@ -0,0 +1,92 @@
+package css
+
+import (
+	"strconv"
+	"unicode/utf8"
+)
+
+// ===== Position =====
+
+// Position is a position in the source text of a stylesheet.
+//
+// The source text is the text given to the lexer, so offsets in a
+// stylesheet decoded from bytes are offsets in its UTF-8 form.
+type Position struct {
+	Offset int // Byte offset, starting at 0
+	Line   int // Line number, starting at 1
+	Column int // Column number in characters, starting at 1
+}
+
+// StartPosition is the position of the start of a source text.
+var StartPosition = Position{Offset: 0, Line: 1, Column: 1}
+
+// IsValid returns true if the position is set, i.e. not the zero value.
+func (p Position) IsValid() bool {
+	return p.Line > 0
+}
+
+// String returns the position as "line:column".
+func (p Position) String() string {
+	return strconv.Itoa(p.Line) + ":" + strconv.Itoa(p.Column)
+}
+
+// Advance returns the position after the given text, when the text starts
+// at this position. "\r\n", "\r", "\n" and "\f" end a line.
+//
+// https://www.w3.org/TR/css-syntax-3/#newline
+func (p Position) Advance(text []rune) Position {
+	for i, r := range text {
+		p.Offset += utf8.RuneLen(r)
+
+		switch {
+		case r == '\n' && i > 0 && text[i-1] == '\r':
+			// The line was already ended by the '\r'
+		case r == '\n' || r == '\r' || r == '\f':
+			p.Line++
+			p.Column = 1
+		default:
+			p.Column++
+		}
+	}
+
+	return p
+}
+
+// ===== Span =====
+
+// Span is the range of the source text a node was parsed from, from Start
+// up to, but not including, End.
+type Span struct {
+	Start Position
+	End   Position
+}
+
+// IsValid returns true if the span is set, i.e. not the zero value.
+func (s Span) IsValid() bool {
+	return s.Start.IsValid() && s.End.IsValid()
+}
+
+// String returns the span as "line:column-line:column".
+func (s Span) String() string {
+	return s.Start.String() + "-" + s.End.String()
+}
+
+// ===== Node =====
+
+// Node is embedded in the nodes built by the parser to hold the span of
+// the source text they were parsed from. Nodes which are not parsed, e.g.
+// the rules created by desugaring, have a zero span.
+type Node struct {
+	Span Span
+}
+
+// SetSpan sets the span of the node.
+func (n *Node) SetSpan(span Span) {
+	n.Span = span
+}
+
+// NodeSpan returns the span of the node. Unlike the Span field, it can be
+// reached through an interface, e.g. a Rule.
+func (n *Node) NodeSpan() Span {
+	return n.Span
+}
@@ -0,0 +1,67 @@
+package css
+
+import "testing"
+
+func TestPosition_Advance(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected Position
+	}{
+		{
+			name:     "empty",
+			input:    "",
+			expected: Position{Offset: 0, Line: 1, Column: 1},
+		},
+		{
+			name:     "single line",
+			input:    "a { }",
+			expected: Position{Offset: 5, Line: 1, Column: 6},
+		},
+		{
+			name:     "line feed",
+			input:    "a {\n  b",
+			expected: Position{Offset: 7, Line: 2, Column: 4},
+		},
+		{
+			name:     "carriage return and line feed",
+			input:    "a\r\nb",
+			expected: Position{Offset: 4, Line: 2, Column: 2},
+		},
+		{
+			name:     "carriage return and form feed",
+			input:    "a\rb\fc",
+			expected: Position{Offset: 5, Line: 3, Column: 2},
+		},
+		{
+			name:     "multi-byte characters",
+			input:    "\"é😀\"",
+			expected: Position{Offset: 8, Line: 1, Column: 5},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			if result := StartPosition.Advance([]rune(tc.input)); result != tc.expected {
+				t.Errorf("expected %+v, got %+v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestSpan_String(t *testing.T) {
+	span := Span{
+		Start: Position{Offset: 2, Line: 1, Column: 3},
+		End:   Position{Offset: 10, Line: 2, Column: 4},
+	}
+
+	if result := span.String(); result != "1:3-2:4" {
+		t.Errorf("expected %q, got %q", "1:3-2:4", result)
+	}
+	if !span.IsValid() {
+		t.Errorf("expected the span to be valid")
+	}
+	if (Span{}).IsValid() {
+		t.Errorf("expected the zero span to be invalid")
+	}
+}
@@ -0,0 +1,31 @@
+package css
+
+// ===== StartingStyleRule =====
+
+// StartingStyleRule represents a @starting-style rule, which holds the
+// styles an element starts from when it is first rendered, so that they
+// can be transitioned from.
+//
+// https://www.w3.org/TR/css-transitions-2/#defining-before-change-style
+type StartingStyleRule struct {
+	Node
+
+	Declarations []*Declaration // Declarations directly inside a @starting-style nested in a style rule
+	Rules        []Rule         // Child rules
+}
+
+// String returns the string representation of the @starting-style rule.
+func (r *StartingStyleRule) String() string {
+	return "@starting-style " + serializeBlock(r.Declarations, r.Rules)
+}
+
+// Equals compares two StartingStyleRule instances.
+func (r *StartingStyleRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*StartingStyleRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return declarationListEquals(r.Declarations, otherRule.Declarations) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
@@ -0,0 +1,64 @@
+package css
+
+import "testing"
+
+func TestStartingStyleRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *StartingStyleRule
+		expected string
+	}{
+		{
+			name:     "empty",
+			rule:     &StartingStyleRule{},
+			expected: "@starting-style { }",
+		},
+		{
+			name: "declarations and rules",
+			rule: &StartingStyleRule{
+				Declarations: []*Declaration{{Property: "opacity", Value: "0"}},
+				Rules: []Rule{&StyleRule{
+					Type:         StyleRuleTypeQualifiedRule,
+					Selectors:    []*Selector{{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("a")}}}},
+					Declarations: []*Declaration{{Property: "color", Value: "red"}},
+				}},
+			},
+			expected: "@starting-style { opacity: 0; .a { color: red; } }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStartingStyleRuleEquals(t *testing.T) {
+	newRule := func(value string) *StartingStyleRule {
+		return &StartingStyleRule{
+			Declarations: []*Declaration{{Property: "opacity", Value: value}},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{"equal", newRule("0"), true},
+		{"different declarations", newRule("1"), false},
+		{"different type", &MediaRule{Queries: &MediaQueryList{}}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := newRule("0").Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
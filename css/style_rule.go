@@ -5,16 +5,16 @@ type StyleRuleType int
 const (
 	StyleRuleTypeUnknown StyleRuleType = iota
 
-	StyleRuleTypeAtRule
 	StyleRuleTypeQualifiedRule
+	StyleRuleTypeKeyframe
 )
 
 func (srt StyleRuleType) String() string {
 	switch srt {
-	case StyleRuleTypeAtRule:
-		return "AtRule"
 	case StyleRuleTypeQualifiedRule:
 		return "QualifiedRule"
+	case StyleRuleTypeKeyframe:
+		return "Keyframe"
 	default:
 		return "Unknown"
 	}
@@ -23,55 +23,52 @@ func (srt StyleRuleType) String() string {
 // ------
 
 type StyleRule struct {
-	Type         StyleRuleType  // Type of the rule (AtRule or QualifiedRule)
-	Selectors    []*Selector    // Selectors for the style rule
-	Declarations []*Declaration // CSS declarations
-	Rules        []*GenericRule // Child rules
+	Node
+
+	Type              StyleRuleType       // Type of the rule (QualifiedRule or Keyframe)
+	Selectors         []*Selector         // Selectors for the style rule
+	Declarations      []*Declaration      // CSS declarations
+	Rules             []Rule              // Child rules
+	KeyframeSelectors []*KeyframeSelector // Selectors of the keyframe, only set for keyframe rules
 }
 
 // Equals compares two StyleRule instances
-func (sr *StyleRule) Equals(other *StyleRule) bool {
-	if other == nil {
+func (sr *StyleRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*StyleRule)
+	if !ok || otherRule == nil {
 		return false
 	}
 
-	if sr.Type != other.Type ||
-		len(sr.Selectors) != len(other.Selectors) ||
-		len(sr.Declarations) != len(other.Declarations) ||
-		len(sr.Rules) != len(other.Rules) {
+	if sr.Type != otherRule.Type ||
+		len(sr.Selectors) != len(otherRule.Selectors) ||
+		len(sr.KeyframeSelectors) != len(otherRule.KeyframeSelectors) {
 		return false
 	}
 
 	// Compare selectors
 	for i, sel := range sr.Selectors {
-		if !sel.Equals(other.Selectors[i]) {
+		if !sel.Equals(otherRule.Selectors[i]) {
 			return false
 		}
 	}
 
-	// Compare declarations
-	for i, decl := range sr.Declarations {
-		if !decl.Equals(other.Declarations[i]) {
+	// Compare keyframe selectors
+	for i, sel := range sr.KeyframeSelectors {
+		if !sel.Equals(otherRule.KeyframeSelectors[i]) {
 			return false
 		}
 	}
 
-	// Compare child rules
-	for i, rule := range sr.Rules {
-		if !rule.Equals(other.Rules[i]) {
-			return false
-		}
-	}
-
-	return true
+	// Compare declarations and child rules, recursively
+	return declarationListEquals(sr.Declarations, otherRule.Declarations) &&
+		ruleListEquals(sr.Rules, otherRule.Rules)
 }
 
-// GenericRule represents a generic CSS rule
-type GenericRule struct {
-	// Placeholder for now - will be expanded as needed
-}
+// String returns the string representation of the style rule
+func (sr *StyleRule) String() string {
+	if sr.Type == StyleRuleTypeKeyframe {
+		return serializeKeyframeSelectorList(sr.KeyframeSelectors) + " " + serializeBlock(sr.Declarations, nil)
+	}
 
-// Equals compares two GenericRule instances
-func (gr *GenericRule) Equals(other *GenericRule) bool {
-	return other != nil
+	return serializeSelectorList(sr.Selectors) + " " + serializeBlock(sr.Declarations, sr.Rules)
 }
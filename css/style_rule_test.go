@@ -11,8 +11,8 @@ func TestStyleRuleType(t *testing.T) {
 		expected string
 	}{
 		{"unknown rule", StyleRuleTypeUnknown, "Unknown"},
-		{"at rule", StyleRuleTypeAtRule, "AtRule"},
 		{"qualified rule", StyleRuleTypeQualifiedRule, "QualifiedRule"},
+		{"keyframe rule", StyleRuleTypeKeyframe, "Keyframe"},
 	}
 
 	for _, tt := range tests {
@@ -56,35 +56,30 @@ func TestStyleRuleEquals(t *testing.T) {
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector1},
 		Declarations: []*Declaration{decl1},
-		Rules:        []*GenericRule{},
 	}
 
 	rule2 := &StyleRule{
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector1},
 		Declarations: []*Declaration{decl1},
-		Rules:        []*GenericRule{},
 	}
 
 	rule3 := &StyleRule{
-		Type:         StyleRuleTypeAtRule,
+		Type:         StyleRuleTypeKeyframe,
 		Selectors:    []*Selector{selector1},
 		Declarations: []*Declaration{decl1},
-		Rules:        []*GenericRule{},
 	}
 
 	rule4 := &StyleRule{
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector2},
 		Declarations: []*Declaration{decl1},
-		Rules:        []*GenericRule{},
 	}
 
 	rule5 := &StyleRule{
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector1},
 		Declarations: []*Declaration{decl2},
-		Rules:        []*GenericRule{},
 	}
 
 	tests := []struct {
@@ -137,35 +132,35 @@ func TestStyleRuleEqualsWithDifferentCounts(t *testing.T) {
 		},
 	}
 
-	genericRule1 := &GenericRule{}
-	genericRule2 := &GenericRule{}
+	childRule1 := &StyleRule{Type: StyleRuleTypeQualifiedRule, Selectors: []*Selector{selector2}}
+	childRule2 := &GenericAtRule{Name: "foo"}
 
 	rule1 := &StyleRule{
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector1},
 		Declarations: []*Declaration{decl1},
-		Rules:        []*GenericRule{genericRule1},
+		Rules:        []Rule{childRule1},
 	}
 
 	rule2 := &StyleRule{
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector1, selector2},
 		Declarations: []*Declaration{decl1},
-		Rules:        []*GenericRule{genericRule1},
+		Rules:        []Rule{childRule1},
 	}
 
 	rule3 := &StyleRule{
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector1},
 		Declarations: []*Declaration{decl1, decl2},
-		Rules:        []*GenericRule{genericRule1},
+		Rules:        []Rule{childRule1},
 	}
 
 	rule4 := &StyleRule{
 		Type:         StyleRuleTypeQualifiedRule,
 		Selectors:    []*Selector{selector1},
 		Declarations: []*Declaration{decl1},
-		Rules:        []*GenericRule{genericRule1, genericRule2},
+		Rules:        []Rule{childRule1, childRule2},
 	}
 
 	tests := []struct {
@@ -189,19 +184,113 @@ func TestStyleRuleEqualsWithDifferentCounts(t *testing.T) {
 	}
 }
 
-func TestGenericRuleEquals(t *testing.T) {
-	rule1 := &GenericRule{}
-	rule2 := &GenericRule{}
+func TestStyleRuleString(t *testing.T) {
+	tests := []struct {
+		name     string
+		rule     *StyleRule
+		expected string
+	}{
+		{
+			name: "qualified rule",
+			rule: &StyleRule{
+				Type: StyleRuleTypeQualifiedRule,
+				Selectors: []*Selector{
+					{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("a")}}},
+					{Selectors: []*SimpleSelector{{Match: SelectorMatchId, Data: NewSelectorData("b")}}},
+				},
+				Declarations: []*Declaration{
+					{Property: "color", Value: "red"},
+					{Property: "margin", Value: "0", Important: true},
+				},
+			},
+			expected: ".a, #b { color: red; margin: 0 !important; }",
+		},
+		{
+			name: "empty qualified rule",
+			rule: &StyleRule{
+				Type: StyleRuleTypeQualifiedRule,
+				Selectors: []*Selector{
+					{Selectors: []*SimpleSelector{{Match: SelectorMatchTag, Data: NewSelectorDataTag("", "div")}}},
+				},
+			},
+			expected: "div { }",
+		},
+		{
+			name: "child rules",
+			rule: &StyleRule{
+				Type: StyleRuleTypeQualifiedRule,
+				Selectors: []*Selector{
+					{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("a")}}},
+				},
+				Declarations: []*Declaration{{Property: "color", Value: "red"}},
+				Rules: []Rule{
+					&StyleRule{
+						Type: StyleRuleTypeQualifiedRule,
+						Selectors: []*Selector{
+							{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("b")}}},
+						},
+						Declarations: []*Declaration{{Property: "color", Value: "blue"}},
+					},
+					&NestedDeclarationsRule{
+						Declarations: []*Declaration{{Property: "margin", Value: "0"}},
+					},
+					&GenericAtRule{Name: "foo"},
+				},
+			},
+			expected: ".a { color: red; .b { color: blue; } margin: 0; @foo; }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestStyleRuleEqualsWithChildRules(t *testing.T) {
+	child := func(color string) *StyleRule {
+		return &StyleRule{
+			Type: StyleRuleTypeQualifiedRule,
+			Selectors: []*Selector{
+				{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("b")}}},
+			},
+			Declarations: []*Declaration{{Property: "color", Value: color}},
+		}
+	}
+	parent := func(rules ...Rule) *StyleRule {
+		return &StyleRule{
+			Type: StyleRuleTypeQualifiedRule,
+			Selectors: []*Selector{
+				{Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("a")}}},
+			},
+			Rules: rules,
+		}
+	}
+
+	rule1 := parent(child("red"), &GenericAtRule{Name: "foo"})
+	rule2 := parent(child("red"), &GenericAtRule{Name: "foo"})
+	rule3 := parent(child("blue"), &GenericAtRule{Name: "foo"})
+	rule4 := parent(child("red"), &GenericAtRule{Name: "bar"})
+	rule5 := parent(&GenericAtRule{Name: "foo"}, child("red"))
+	rule6 := parent(parent(child("red")))
+	rule7 := parent(parent(child("blue")))
 
 	tests := []struct {
 		name     string
-		rule1    *GenericRule
-		rule2    *GenericRule
+		rule1    *StyleRule
+		rule2    *StyleRule
 		expected bool
 	}{
-		{"both non-nil", rule1, rule2, true},
-		{"same object", rule1, rule1, true},
-		{"one nil", rule1, nil, false},
+		{"identical child rules", rule1, rule2, true},
+		{"different nested style rule", rule1, rule3, false},
+		{"different nested at rule", rule1, rule4, false},
+		{"different order", rule1, rule5, false},
+		{"deeply nested", rule6, parent(parent(child("red"))), true},
+		{"different deeply nested", rule6, rule7, false},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,112 @@
+package css
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssutil"
+)
+
+// ===== SupportsConditionType =====
+
+type SupportsConditionType int
+
+const (
+	SupportsConditionDeclaration     SupportsConditionType = iota // Example: (display: grid)
+	SupportsConditionSelector                                     // Example: selector(:has(a))
+	SupportsConditionFontTech                                     // Example: font-tech(color-colrv1)
+	SupportsConditionFontFormat                                   // Example: font-format(woff2)
+	SupportsConditionNot                                          // Example: not (display: grid)
+	SupportsConditionAnd                                          // Example: (display: grid) and (gap: 1em)
+	SupportsConditionOr                                           // Example: (display: grid) or (display: flex)
+	SupportsConditionGeneralEnclosed                              // Example: (unknown thing), foo(bar)
+)
+
+// ===== SupportsCondition =====
+
+// SupportsCondition represents a node of a @supports condition tree.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-supports-condition
+type SupportsCondition struct {
+	Node
+
+	Type            SupportsConditionType // The type of the condition node
+	Children        []*SupportsCondition  // The operands of not, and & or conditions
+	Declaration     *Declaration          // The declaration, for declaration conditions
+	Selector        *Selector             // The complex selector, for selector() conditions
+	Keyword         string                // The lowercased keyword, for font-tech() and font-format() conditions
+	GeneralEnclosed *ComponentValue       // The function or block, for general-enclosed conditions
+}
+
+func (c *SupportsCondition) String() string {
+	switch c.Type {
+	case SupportsConditionDeclaration:
+		return "(" + c.Declaration.String() + ")"
+
+	case SupportsConditionSelector:
+		return "selector(" + c.Selector.String() + ")"
+
+	case SupportsConditionFontTech:
+		return "font-tech(" + cssutil.SerializeIdentifier(c.Keyword) + ")"
+
+	case SupportsConditionFontFormat:
+		return "font-format(" + cssutil.SerializeIdentifier(c.Keyword) + ")"
+
+	case SupportsConditionNot:
+		return "not " + c.Children[0].operandString()
+
+	case SupportsConditionAnd, SupportsConditionOr:
+		separator := " and "
+		if c.Type == SupportsConditionOr {
+			separator = " or "
+		}
+
+		operandStrs := make([]string, 0, len(c.Children))
+		for _, child := range c.Children {
+			operandStrs = append(operandStrs, child.operandString())
+		}
+		return strings.Join(operandStrs, separator)
+
+	case SupportsConditionGeneralEnclosed:
+		return c.GeneralEnclosed.String()
+
+	default:
+		return ""
+	}
+}
+
+// operandString returns the string representation of the condition when
+// it is an operand of another condition, wrapping it in parentheses if
+// needed.
+func (c *SupportsCondition) operandString() string {
+	switch c.Type {
+	case SupportsConditionNot, SupportsConditionAnd, SupportsConditionOr:
+		return "(" + c.String() + ")"
+	default:
+		return c.String()
+	}
+}
+
+func (c *SupportsCondition) Equals(other *SupportsCondition) bool {
+	if other == nil || c.Type != other.Type || len(c.Children) != len(other.Children) {
+		return false
+	}
+
+	for i, child := range c.Children {
+		if !child.Equals(other.Children[i]) {
+			return false
+		}
+	}
+
+	switch c.Type {
+	case SupportsConditionDeclaration:
+		return c.Declaration.Equals(other.Declaration)
+	case SupportsConditionSelector:
+		return other.Selector != nil && c.Selector.Equals(other.Selector)
+	case SupportsConditionFontTech, SupportsConditionFontFormat:
+		return c.Keyword == other.Keyword
+	case SupportsConditionGeneralEnclosed:
+		return c.GeneralEnclosed.Equals(other.GeneralEnclosed)
+	default:
+		return true
+	}
+}
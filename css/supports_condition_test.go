@@ -0,0 +1,136 @@
+package css
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestSupportsConditionString(t *testing.T) {
+	grid := &SupportsCondition{
+		Type:        SupportsConditionDeclaration,
+		Declaration: &Declaration{Property: "display", Value: "grid"},
+	}
+	flex := &SupportsCondition{
+		Type:        SupportsConditionDeclaration,
+		Declaration: &Declaration{Property: "display", Value: "flex"},
+	}
+
+	tests := []struct {
+		name      string
+		condition *SupportsCondition
+		expected  string
+	}{
+		{
+			name:      "declaration",
+			condition: grid,
+			expected:  "(display: grid)",
+		},
+		{
+			name: "selector",
+			condition: &SupportsCondition{
+				Type: SupportsConditionSelector,
+				Selector: &Selector{
+					Selectors: []*SimpleSelector{{Match: SelectorMatchClass, Data: NewSelectorData("a")}},
+				},
+			},
+			expected: "selector(.a)",
+		},
+		{
+			name:      "font-tech",
+			condition: &SupportsCondition{Type: SupportsConditionFontTech, Keyword: "color-colrv1"},
+			expected:  "font-tech(color-colrv1)",
+		},
+		{
+			name:      "font-format",
+			condition: &SupportsCondition{Type: SupportsConditionFontFormat, Keyword: "woff2"},
+			expected:  "font-format(woff2)",
+		},
+		{
+			name: "nested conditions",
+			condition: &SupportsCondition{
+				Type: SupportsConditionOr,
+				Children: []*SupportsCondition{
+					{Type: SupportsConditionNot, Children: []*SupportsCondition{grid}},
+					{Type: SupportsConditionAnd, Children: []*SupportsCondition{grid, flex}},
+				},
+			},
+			expected: "(not (display: grid)) or ((display: grid) and (display: flex))",
+		},
+		{
+			name: "general enclosed",
+			condition: &SupportsCondition{
+				Type: SupportsConditionGeneralEnclosed,
+				GeneralEnclosed: &ComponentValue{
+					Type:     ComponentValueTypeFunction,
+					Token:    csslexer.Token{Type: csslexer.FunctionToken, Value: "foo"},
+					Children: []*ComponentValue{newTestToken(csslexer.IdentToken, "bar")},
+				},
+			},
+			expected: "foo(bar)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.condition.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSupportsConditionEquals(t *testing.T) {
+	condition := &SupportsCondition{
+		Type: SupportsConditionNot,
+		Children: []*SupportsCondition{
+			{Type: SupportsConditionFontFormat, Keyword: "woff2"},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		other    *SupportsCondition
+		expected bool
+	}{
+		{
+			name: "same condition",
+			other: &SupportsCondition{
+				Type: SupportsConditionNot,
+				Children: []*SupportsCondition{
+					{Type: SupportsConditionFontFormat, Keyword: "woff2"},
+				},
+			},
+			expected: true,
+		},
+		{
+			name: "different keyword",
+			other: &SupportsCondition{
+				Type: SupportsConditionNot,
+				Children: []*SupportsCondition{
+					{Type: SupportsConditionFontFormat, Keyword: "woff"},
+				},
+			},
+			expected: false,
+		},
+		{
+			name:     "different type",
+			other:    &SupportsCondition{Type: SupportsConditionFontFormat, Keyword: "woff2"},
+			expected: false,
+		},
+		{
+			name:     "nil",
+			other:    nil,
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := condition.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
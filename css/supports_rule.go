@@ -0,0 +1,31 @@
+package css
+
+// ===== SupportsRule =====
+
+// SupportsRule represents a @supports rule.
+//
+// https://www.w3.org/TR/css-conditional-5/#at-supports
+type SupportsRule struct {
+	Node
+
+	Condition    *SupportsCondition // The condition of the rule
+	Declarations []*Declaration     // Declarations directly inside a @supports nested in a style rule
+	Rules        []Rule             // Child rules
+}
+
+// String returns the string representation of the @supports rule.
+func (r *SupportsRule) String() string {
+	return "@supports " + r.Condition.String() + " " + serializeBlock(r.Declarations, r.Rules)
+}
+
+// Equals compares two SupportsRule instances.
+func (r *SupportsRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*SupportsRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return r.Condition.Equals(otherRule.Condition) &&
+		declarationListEquals(r.Declarations, otherRule.Declarations) &&
+		ruleListEquals(r.Rules, otherRule.Rules)
+}
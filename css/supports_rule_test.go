@@ -0,0 +1,93 @@
+package css
+
+import "testing"
+
+func TestSupportsRuleString(t *testing.T) {
+	grid := &SupportsCondition{
+		Type:        SupportsConditionDeclaration,
+		Declaration: &Declaration{Property: "display", Value: "grid"},
+	}
+
+	tests := []struct {
+		name     string
+		rule     *SupportsRule
+		expected string
+	}{
+		{
+			name:     "empty block",
+			rule:     &SupportsRule{Condition: grid},
+			expected: "@supports (display: grid) { }",
+		},
+		{
+			name: "with rules",
+			rule: &SupportsRule{
+				Condition: grid,
+				Rules: []Rule{
+					&StyleRule{
+						Type:         StyleRuleTypeQualifiedRule,
+						Selectors:    []*Selector{{Selectors: []*SimpleSelector{{Match: SelectorMatchTag, Data: NewSelectorDataTag("", "div")}}}},
+						Declarations: []*Declaration{{Property: "display", Value: "grid"}},
+					},
+				},
+			},
+			expected: "@supports (display: grid) { div { display: grid; } }",
+		},
+		{
+			name: "with declarations",
+			rule: &SupportsRule{
+				Condition:    grid,
+				Declarations: []*Declaration{{Property: "display", Value: "grid"}},
+			},
+			expected: "@supports (display: grid) { display: grid; }",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.rule.String()
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSupportsRuleEquals(t *testing.T) {
+	rule := &SupportsRule{
+		Condition: &SupportsCondition{Type: SupportsConditionFontTech, Keyword: "variations"},
+	}
+
+	tests := []struct {
+		name     string
+		other    AtRule
+		expected bool
+	}{
+		{
+			name: "same rule",
+			other: &SupportsRule{
+				Condition: &SupportsCondition{Type: SupportsConditionFontTech, Keyword: "variations"},
+			},
+			expected: true,
+		},
+		{
+			name: "different condition",
+			other: &SupportsRule{
+				Condition: &SupportsCondition{Type: SupportsConditionFontTech, Keyword: "palettes"},
+			},
+			expected: false,
+		},
+		{
+			name:     "different at-rule type",
+			other:    &MediaRule{Queries: &MediaQueryList{}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := rule.Equals(tt.other); result != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, result)
+			}
+		})
+	}
+}
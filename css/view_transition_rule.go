@@ -0,0 +1,42 @@
+package css
+
+// ===== ViewTransitionRule =====
+
+// ViewTransitionRule represents a @view-transition rule, which opts a
+// document into cross-document view transitions.
+//
+// Only valid descriptors are kept. The values of the navigation and types
+// descriptors are also available in their parsed form.
+//
+// https://www.w3.org/TR/css-view-transitions-2/#view-transition-rule
+type ViewTransitionRule struct {
+	Node
+
+	Descriptors []*Declaration // The valid descriptors, in source order
+	Navigation  string         // The lowercased value of the last navigation descriptor, empty if omitted
+	Types       []string       // The transition types of the last types descriptor, empty for none
+}
+
+// String returns the string representation of the @view-transition rule.
+func (r *ViewTransitionRule) String() string {
+	return "@view-transition " + serializeBlock(r.Descriptors, nil)
+}
+
+// Equals compares two ViewTransitionRule instances.
+//
+// The parsed descriptor values are derived from the descriptors, so only
+// the descriptors are compared.
+func (r *ViewTransitionRule) Equals(other Rule) bool {
+	otherRule, ok := other.(*ViewTransitionRule)
+	if !ok || otherRule == nil {
+		return false
+	}
+
+	return declarationListEquals(r.Descriptors, otherRule.Descriptors)
+}
+
+// Descriptor returns the last descriptor with the given name, or nil if
+// there is none.
+func (r *ViewTransitionRule) Descriptor(name string) *Declaration {
+	return lastDeclaration(r.Descriptors, name)
+}
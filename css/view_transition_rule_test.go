@@ -0,0 +1,29 @@
+package css
+
+import "testing"
+
+func TestViewTransitionRule(t *testing.T) {
+	rule := &ViewTransitionRule{
+		Descriptors: []*Declaration{
+			{Property: "navigation", Value: "auto"},
+			{Property: "types", Value: "slide"},
+			{Property: "navigation", Value: "none"},
+		},
+	}
+
+	expected := "@view-transition { navigation: auto; types: slide; navigation: none; }"
+	if result := rule.String(); result != expected {
+		t.Errorf("expected %q, got %q", expected, result)
+	}
+
+	if decl := rule.Descriptor("NAVIGATION"); decl == nil || decl.Value != "none" {
+		t.Errorf("expected the last navigation descriptor, got %v", decl)
+	}
+
+	if rule.Equals(&ViewTransitionRule{Descriptors: rule.Descriptors[:1]}) {
+		t.Errorf("expected rules with different descriptors to differ")
+	}
+	if !rule.Equals(&ViewTransitionRule{Descriptors: rule.Descriptors}) {
+		t.Errorf("expected rules with the same descriptors to be equal")
+	}
+}
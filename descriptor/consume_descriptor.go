@@ -0,0 +1,167 @@
+package descriptor
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// ConsumeList consumes a list of descriptors until the end of the token
+// stream, and calls consume for each descriptor. The descriptor is dropped
+// if consume returns an error.
+//
+// Invalid descriptors, i.e. empty or !important ones, and anything which
+// is not a descriptor are dropped. If consumeAtRule is not nil, it is
+// called with the token stream positioned at the at-keyword of each
+// at-rule, and must consume the at-rule. Otherwise at-rules are dropped.
+//
+// Each dropped part of the list is passed to report, if it is not nil.
+func ConsumeList(
+	ts *token_stream.TokenStream,
+	report ReportFunc,
+	consume func(d *Descriptor) error,
+	consumeAtRule func() error,
+) {
+	for {
+		ts.ConsumeWhitespace()
+
+		if ts.AtEnd() {
+			break
+		}
+
+		token := ts.Peek()
+		if token.Type == csslexer.SemicolonToken {
+			ts.Consume()
+			continue
+		}
+
+		start := ts.PeekSpan().Start
+		mark := ts.StartRecording()
+
+		var err error
+		isRule := false
+
+		switch token.Type {
+		case csslexer.IdentToken:
+			var d *Descriptor
+			if d, err = consumeDescriptor(ts); err == nil {
+				err = consume(d)
+			}
+
+		case csslexer.AtKeywordToken:
+			isRule = true
+			if consumeAtRule != nil {
+				err = consumeAtRule()
+			} else {
+				Skip(ts)
+				err = errors.New("@" + strings.ToLower(token.Value) + " rule not allowed")
+			}
+
+		default:
+			Skip(ts)
+			err = errors.New("expected descriptor")
+		}
+
+		text := ts.StopRecording(mark)
+		if err != nil && report != nil {
+			report(&Drop{
+				Err:  err,
+				Rule: isRule,
+				Text: text,
+				Span: css.Span{Start: start, End: ts.Position()},
+			})
+		}
+	}
+}
+
+// consumeDescriptor consumes a single descriptor. Returns an error if the
+// descriptor is invalid, e.g. if its value is empty or ends with
+// !important.
+func consumeDescriptor(ts *token_stream.TokenStream) (*Descriptor, error) {
+	nameSpan := ts.PeekSpan()
+	name := ts.ConsumeIncludingWhitespace().Value
+
+	if ts.Peek().Type != csslexer.ColonToken {
+		Skip(ts)
+		return nil, errors.New("expected ':' after descriptor name")
+	}
+	ts.Consume()
+
+	var values []*css.ComponentValue
+	var valueSpan, itemSpan css.Span
+	var itemSpans []css.Span
+	for !ts.AtEnd() && ts.Peek().Type != csslexer.SemicolonToken {
+		start := ts.PeekSpan().Start
+		value := ts.ConsumeComponentValue()
+		values = append(values, value)
+
+		if !value.IsWhitespace() {
+			if !valueSpan.Start.IsValid() {
+				valueSpan.Start = start
+			}
+			valueSpan.End = ts.Position()
+		}
+
+		switch {
+		case value.IsToken(csslexer.CommaToken):
+			itemSpans = append(itemSpans, itemSpan)
+			itemSpan = css.Span{}
+		case !value.IsWhitespace():
+			if !itemSpan.Start.IsValid() {
+				itemSpan.Start = start
+			}
+			itemSpan.End = ts.Position()
+		}
+	}
+	itemSpans = append(itemSpans, itemSpan)
+	values = css.TrimComponentValueList(values)
+
+	if ts.Peek().Type == csslexer.SemicolonToken {
+		ts.Consume()
+	}
+
+	if len(values) == 0 {
+		return nil, errors.New("empty descriptor value")
+	}
+	if hasImportant(values) {
+		return nil, errors.New("descriptors can't be !important")
+	}
+
+	return &Descriptor{
+		Name:      strings.ToLower(name),
+		RawName:   name,
+		Values:    values,
+		Span:      css.Span{Start: nameSpan.Start, End: valueSpan.End},
+		NameSpan:  nameSpan,
+		ValueSpan: valueSpan,
+		ItemSpans: itemSpans,
+	}, nil
+}
+
+// Skip skips an invalid descriptor, up to and including the next ';'.
+// Something which looks like a rule is skipped up to the end of its block
+// instead. The end of the enclosing block is not consumed.
+func Skip(ts *token_stream.TokenStream) {
+	ts.SkipUntil(csslexer.SemicolonToken, csslexer.LeftBraceToken, csslexer.RightBraceToken)
+
+	switch ts.Peek().Type {
+	case csslexer.SemicolonToken:
+		ts.Consume()
+	case csslexer.LeftBraceToken:
+		ts.ConsumeComponentValue()
+	}
+}
+
+// hasImportant checks if a descriptor value ends with !important.
+func hasImportant(values []*css.ComponentValue) bool {
+	values = css.StripComponentValueListWhitespace(values)
+	n := len(values)
+
+	return n >= 2 &&
+		values[n-2].IsToken(csslexer.DelimiterToken) && values[n-2].Token.Value == "!" &&
+		values[n-1].IsKeyword("important")
+}
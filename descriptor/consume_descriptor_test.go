@@ -0,0 +1,135 @@
+package descriptor
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func TestConsumeList(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", ""},
+		{"descriptors", "a: 1; B: 2 3 ;c:4", "a: 1; b: 2 3; c: 4"},
+		{"empty value", "a: ; b: 1", "b: 1"},
+		{"important", "a: 1 ! important; b: 1", "b: 1"},
+		{"missing colon", "a 1; b: 1", "b: 1"},
+		{"garbage", "12px; { a: 1 } b: 1", "b: 1"},
+		{"nested rule", "a { b: 1; } c: 1", "c: 1"},
+		{"at-rules are skipped", "@a; @b { c: 1 } d: 1", "d: 1"},
+		{"block in value", "a: { b: 1; } 2; c: 1", "a: { b: 1; } 2; c: 1"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := token_stream.NewTokenStream(csslexer.NewInput(tc.input))
+
+			var result []string
+			ConsumeList(ts, nil, func(d *Descriptor) error {
+				result = append(result, d.Name+": "+css.SerializeComponentValueList(d.Values))
+				return nil
+			}, nil)
+
+			if joined := strings.Join(result, "; "); joined != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, joined)
+			}
+
+			if next := ts.Peek(); next.Type != csslexer.EOFToken {
+				t.Errorf("expected EOF, got %v", next.Type)
+			}
+		})
+	}
+}
+
+func TestConsumeList_AtRules(t *testing.T) {
+	ts := token_stream.NewTokenStream(csslexer.NewInput("@a { } B: 1; @c;"))
+
+	var names, atRules []string
+	ConsumeList(ts, nil, func(d *Descriptor) error {
+		names = append(names, d.RawName)
+		return nil
+	}, func() error {
+		atRules = append(atRules, ts.Peek().Value)
+		Skip(ts)
+		return nil
+	})
+
+	if strings.Join(names, " ") != "B" {
+		t.Errorf("expected the raw name %q, got %q", "B", names)
+	}
+	if strings.Join(atRules, " ") != "a c" {
+		t.Errorf("expected the at-rules %q, got %q", "a c", atRules)
+	}
+}
+
+func TestConsumeList_KeepsBlockEnd(t *testing.T) {
+	input := csslexer.NewInput("{ a: 1; bogus } b")
+	ts := token_stream.NewTokenStream(input)
+
+	var names []string
+	err := ts.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+		ConsumeList(ts, nil, func(d *Descriptor) error {
+			names = append(names, d.Name)
+			return nil
+		}, nil)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Join(names, " ") != "a" {
+		t.Errorf("expected the descriptor %q, got %q", "a", names)
+	}
+	if next := ts.Peek(); next.Type != csslexer.WhitespaceToken {
+		t.Errorf("expected the block to be consumed, got %v", next.Type)
+	}
+}
+
+func TestConsumeList_Report(t *testing.T) {
+	input := "a: 1; b: ; c: 1 !important; d 1; 12px; @e { }\nf: 2; g: 3"
+	ts := token_stream.NewTokenStream(csslexer.NewInput(input))
+
+	var drops []*Drop
+	ConsumeList(ts, func(drop *Drop) {
+		drops = append(drops, drop)
+	}, func(d *Descriptor) error {
+		if d.Name == "g" {
+			return errors.New("invalid g")
+		}
+		return nil
+	}, nil)
+
+	expected := []struct {
+		text string
+		rule bool
+		span string
+	}{
+		{"b: ;", false, "1:7-1:11"},
+		{"c: 1 !important;", false, "1:12-1:28"},
+		{"d 1;", false, "1:29-1:33"},
+		{"12px;", false, "1:34-1:39"},
+		{"@e { }", true, "1:40-1:46"},
+		{"g: 3", false, "2:7-2:11"},
+	}
+	if len(drops) != len(expected) {
+		t.Fatalf("expected %d drops, got %d", len(expected), len(drops))
+	}
+	for i, drop := range drops {
+		if drop.Text != expected[i].text || drop.Rule != expected[i].rule || drop.Span.String() != expected[i].span {
+			t.Errorf("drop %d: expected %q (rule: %v) at %s, got %q (rule: %v) at %s",
+				i, expected[i].text, expected[i].rule, expected[i].span, drop.Text, drop.Rule, drop.Span)
+		}
+		if drop.Err == nil {
+			t.Errorf("drop %d: expected an error", i)
+		}
+	}
+}
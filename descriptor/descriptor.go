@@ -0,0 +1,52 @@
+// Package descriptor consumes the descriptor lists in the blocks of
+// at-rules such as @font-face, @counter-style and @property.
+//
+// https://www.w3.org/TR/css-syntax-3/#consume-list-of-declarations
+package descriptor
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// Descriptor is a descriptor consumed from a descriptor list, e.g.
+// "font-display: swap".
+type Descriptor struct {
+	Name    string                // The lowercased name
+	RawName string                // The name as written
+	Values  []*css.ComponentValue // The value, with surrounding whitespace trimmed
+
+	Span      css.Span // Span of the descriptor, without the ';'
+	NameSpan  css.Span // Span of the name
+	ValueSpan css.Span // Span of the value, without the surrounding whitespace
+
+	// Spans of the comma-separated items of the value, without the
+	// surrounding whitespace, in the order of css.SplitComponentValueList
+	ItemSpans []css.Span
+}
+
+// Drop is a part of a descriptor list which was dropped while parsing,
+// e.g. an invalid descriptor or a rule.
+type Drop struct {
+	Err  error    // Why the part was dropped
+	Rule bool     // Whether the dropped part is a rule rather than a descriptor
+	Text string   // The dropped text, without comments
+	Span css.Span // The span of the dropped text
+}
+
+// ReportFunc is called for each part of a descriptor list which is
+// dropped.
+type ReportFunc func(drop *Drop)
+
+// Declaration returns the descriptor as a declaration with the given
+// serialized value.
+func (d *Descriptor) Declaration(value string) *css.Declaration {
+	decl := &css.Declaration{
+		Property:     d.Name,
+		Value:        value,
+		PropertySpan: d.NameSpan,
+		ValueSpan:    d.ValueSpan,
+	}
+	decl.SetSpan(d.Span)
+
+	return decl
+}
@@ -0,0 +1,163 @@
+// Package desugar rewrites parsed stylesheets to only use syntax which is
+// understood by older browsers.
+package desugar
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/selector"
+)
+
+// Nesting returns the equivalent of the given rules without nested style
+// rules, for browsers which don't support CSS nesting. The given rules are
+// not modified.
+//
+// Nested style rules are moved after their parent rule, with their
+// selectors resolved against the parent selectors: '&' is replaced by the
+// parent selector, written in place when this keeps the meaning and the
+// specificity of the selector, e.g. ".a { &:hover {} }" becomes
+// ".a:hover {}", and wrapped in ":is()" otherwise, e.g.
+// ".a, .b { .c {} }" becomes ":is(.a, .b) .c {}". Nested selectors
+// without '&' are relative to the parent, as if they started with "& ".
+// The rules must not be parsed with '&' already expanded, see
+// cssparser.Parser.SetExpandNestingParent.
+//
+// Declarations after a nested rule become a style rule of their own, so
+// that they keep their place in the cascade.
+//
+// Conditional group rules (@media, @supports and @container) and @layer,
+// @starting-style and @scope rules nested in a style rule are moved out of
+// it, and the declarations directly inside them are wrapped in a style
+// rule with the parent selectors. @layer statements are moved out as is. Other at-rules nested in a style rule,
+// like @apply, can't be moved and are kept in a style rule with the parent
+// selectors.
+//
+// https://www.w3.org/TR/css-nesting-1/
+func Nesting(rules []css.Rule) []css.Rule {
+	return flattenRuleList(rules, nil)
+}
+
+// flattenRuleList flattens a list of rules nested in a style rule with the
+// given resolved selectors, or at the top level if parent is nil.
+func flattenRuleList(rules []css.Rule, parent []*css.Selector) []css.Rule {
+	var result []css.Rule
+	for _, rule := range rules {
+		result = append(result, flattenRule(rule, parent)...)
+	}
+	return result
+}
+
+// flattenRule flattens a single rule, see flattenRuleList.
+func flattenRule(rule css.Rule, parent []*css.Selector) []css.Rule {
+	switch rule := rule.(type) {
+	case *css.StyleRule:
+		if rule.Type != css.StyleRuleTypeQualifiedRule {
+			return []css.Rule{rule}
+		}
+		return flattenStyleRule(rule, parent)
+
+	case *css.NestedDeclarationsRule:
+		selectors := parent
+		if selectors == nil {
+			// Directly inside @scope, the rule has its own selectors
+			selectors = rule.Selectors
+		}
+		return []css.Rule{newStyleRule(selectors, rule.Declarations)}
+
+	case *css.MediaRule:
+		flat := *rule
+		flat.Declarations, flat.Rules = nil, flattenGroupRuleBlock(rule.Declarations, rule.Rules, parent)
+		return []css.Rule{&flat}
+
+	case *css.SupportsRule:
+		flat := *rule
+		flat.Declarations, flat.Rules = nil, flattenGroupRuleBlock(rule.Declarations, rule.Rules, parent)
+		return []css.Rule{&flat}
+
+	case *css.ContainerRule:
+		flat := *rule
+		flat.Declarations, flat.Rules = nil, flattenGroupRuleBlock(rule.Declarations, rule.Rules, parent)
+		return []css.Rule{&flat}
+
+	case *css.LayerBlockRule:
+		flat := *rule
+		flat.Declarations, flat.Rules = nil, flattenGroupRuleBlock(rule.Declarations, rule.Rules, parent)
+		return []css.Rule{&flat}
+
+	case *css.LayerStatementRule:
+		return []css.Rule{rule}
+
+	case *css.StartingStyleRule:
+		flat := *rule
+		flat.Declarations, flat.Rules = nil, flattenGroupRuleBlock(rule.Declarations, rule.Rules, parent)
+		return []css.Rule{&flat}
+
+	case *css.ScopeRule:
+		flat := *rule
+		if parent != nil {
+			// The <scope-start> of a nested @scope is relative to the
+			// parent rule, and defaults to the parent selectors.
+			if len(rule.Start) == 0 {
+				flat.Start = parent
+			} else {
+				flat.Start = selector.ExpandNestingParent(rule.Start, parent, true)
+			}
+		}
+		// The rules inside @scope are relative to the scoping root, not to
+		// the parent rule.
+		flat.Rules = flattenRuleList(rule.Rules, nil)
+		return []css.Rule{&flat}
+
+	default:
+		if parent != nil {
+			return []css.Rule{&css.StyleRule{
+				Type:      css.StyleRuleTypeQualifiedRule,
+				Selectors: parent,
+				Rules:     []css.Rule{rule},
+			}}
+		}
+		return []css.Rule{rule}
+	}
+}
+
+// flattenStyleRule flattens a style rule and its child rules. The rule
+// itself is dropped if it only holds child rules.
+func flattenStyleRule(rule *css.StyleRule, parent []*css.Selector) []css.Rule {
+	selectors := rule.Selectors
+	if parent != nil {
+		selectors = selector.ExpandNestingParent(rule.Selectors, parent, true)
+	}
+
+	var result []css.Rule
+	if len(rule.Declarations) > 0 || len(rule.Rules) == 0 {
+		result = append(result, newStyleRule(selectors, rule.Declarations))
+	}
+
+	return append(result, flattenRuleList(rule.Rules, selectors)...)
+}
+
+// flattenGroupRuleBlock flattens the contents of a group rule nested in a
+// style rule with the given resolved selectors, or at the top level if
+// parent is nil. The declarations directly inside a nested group rule apply
+// to the parent rule.
+func flattenGroupRuleBlock(
+	declarations []*css.Declaration,
+	rules []css.Rule,
+	parent []*css.Selector,
+) []css.Rule {
+	var result []css.Rule
+	if len(declarations) > 0 {
+		result = append(result, newStyleRule(parent, declarations))
+	}
+
+	return append(result, flattenRuleList(rules, parent)...)
+}
+
+// newStyleRule returns a style rule with the given selectors and
+// declarations.
+func newStyleRule(selectors []*css.Selector, declarations []*css.Declaration) *css.StyleRule {
+	return &css.StyleRule{
+		Type:         css.StyleRuleTypeQualifiedRule,
+		Selectors:    selectors,
+		Declarations: declarations,
+	}
+}
@@ -0,0 +1,175 @@
+package desugar
+
+import (
+	"strings"
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser"
+	"go.baoshuo.dev/cssparser/css"
+)
+
+func TestNesting(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "no nesting",
+			input:    "a { color: red; } b { }",
+			expected: "a { color: red; } b { }",
+		},
+		{
+			name:     "implicit parent",
+			input:    ".a { color: red; .b { color: blue; } }",
+			expected: ".a { color: red; } .a .b { color: blue; }",
+		},
+		{
+			name:     "compound with parent",
+			input:    ".a { &:hover { color: blue; } }",
+			expected: ".a:hover { color: blue; }",
+		},
+		{
+			name:     "relative selector",
+			input:    ".a { > .b { color: red; } }",
+			expected: ".a > .b { color: red; }",
+		},
+		{
+			name:     "parent after a combinator",
+			input:    ".a { .x & { color: red; } }",
+			expected: ".x .a { color: red; }",
+		},
+		{
+			name:     "parent used twice",
+			input:    ".a { & + & { color: red; } }",
+			expected: ".a + .a { color: red; }",
+		},
+		{
+			name:     "parent with a type selector",
+			input:    "div { .x& { color: red; } }",
+			expected: "div.x { color: red; }",
+		},
+		{
+			name:     "type selector next to the parent",
+			input:    ".a { div& { color: red; } }",
+			expected: "div:is(.a) { color: red; }",
+		},
+		{
+			name:     "parent selector list",
+			input:    ".a, .b { .c { color: red; } }",
+			expected: ":is(.a, .b) .c { color: red; }",
+		},
+		{
+			name:     "complex parent in the leftmost compound",
+			input:    ".a .b { &.x { color: red; } }",
+			expected: ".a .b.x { color: red; }",
+		},
+		{
+			name:     "complex parent after a combinator",
+			input:    ".a .b { .x & { color: red; } }",
+			expected: ".x :is(.a .b) { color: red; }",
+		},
+		{
+			name:     "parent in a pseudo-class argument",
+			input:    ".a { :not(&) { color: red; } }",
+			expected: ":not(.a) { color: red; }",
+		},
+		{
+			name:     "parent with a pseudo-element",
+			input:    ".a::before { &:hover { color: red; } }",
+			expected: ":is(.a::before):hover { color: red; }",
+		},
+		{
+			name:     "deeply nested",
+			input:    ".a, .b { .c { .d { color: red; } } }",
+			expected: ":is(.a, .b) .c .d { color: red; }",
+		},
+		{
+			name:     "declarations after a nested rule",
+			input:    ".a { color: red; & b { color: green; } color: blue; }",
+			expected: ".a { color: red; } .a b { color: green; } .a { color: blue; }",
+		},
+		{
+			name:     "nested media",
+			input:    ".a { @media print { color: red; .b { color: blue; } } }",
+			expected: "@media print { .a { color: red; } .a .b { color: blue; } }",
+		},
+		{
+			name:     "nested conditional rules",
+			input:    ".a { @supports (display: grid) { @container (width > 1px) { color: red; } } }",
+			expected: "@supports (display: grid) { @container (width > 1px) { .a { color: red; } } }",
+		},
+		{
+			name:     "style rule nested in a top-level media rule",
+			input:    "@media print { .a { .b { color: red; } } }",
+			expected: "@media print { .a .b { color: red; } }",
+		},
+		{
+			name:     "nested layer and starting-style",
+			input:    ".a { @layer x { color: red; } @starting-style { opacity: 0; } }",
+			expected: "@layer x { .a { color: red; } } @starting-style { .a { opacity: 0; } }",
+		},
+		{
+			name:     "nested layer statement",
+			input:    ".a { @layer x, y; color: red; }",
+			expected: "@layer x, y; .a { color: red; }",
+		},
+		{
+			name:     "nested scope",
+			input:    ".a { @scope (.b) { color: red; .c { color: blue; } } }",
+			expected: "@scope (.a .b) { :where(:scope) { color: red; } .c { color: blue; } }",
+		},
+		{
+			name:     "nested scope without start",
+			input:    ".a { @scope { color: red; } }",
+			expected: "@scope (.a) { :where(:scope) { color: red; } }",
+		},
+		{
+			name:     "other nested at-rules are kept",
+			input:    ".a { color: red; .b { @apply --x; } }",
+			expected: ".a { color: red; } .a .b { @apply --x; }",
+		},
+		{
+			name:     "keyframes are untouched",
+			input:    "@keyframes fade { from { opacity: 0; } }",
+			expected: "@keyframes fade { 0% { opacity: 0; } }",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := cssparser.NewParser(input)
+
+			rules := parser.ParseStylesheet()
+
+			result := serializeRules(Nesting(rules))
+			if result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func TestNesting_KeepsInput(t *testing.T) {
+	input := ".a, .b { color: red; &:hover { .c & { color: blue; } } @media print { color: green; } }"
+
+	rules := cssparser.NewParser(csslexer.NewInput(input)).ParseStylesheet()
+
+	before := serializeRules(rules)
+	Nesting(rules)
+
+	if after := serializeRules(rules); after != before {
+		t.Errorf("expected the rules to be unchanged, got %q", after)
+	}
+}
+
+func serializeRules(rules []css.Rule) string {
+	ruleStrs := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		ruleStrs = append(ruleStrs, rule.String())
+	}
+	return strings.Join(ruleStrs, " ")
+}
@@ -0,0 +1,142 @@
+package cssparser
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+)
+
+// DiagnosticKind is the kind of the part of a stylesheet reported by a
+// Diagnostic.
+type DiagnosticKind int
+
+const (
+	DiagnosticKindUnknown DiagnosticKind = iota
+
+	DiagnosticKindInvalidRule
+	DiagnosticKindInvalidDeclaration
+	DiagnosticKindInvalidSelector
+)
+
+func (k DiagnosticKind) String() string {
+	switch k {
+	case DiagnosticKindInvalidRule:
+		return "InvalidRule"
+	case DiagnosticKindInvalidDeclaration:
+		return "InvalidDeclaration"
+	case DiagnosticKindInvalidSelector:
+		return "InvalidSelector"
+	default:
+		return "Unknown"
+	}
+}
+
+// Diagnostic reports a rule, a declaration or a selector which was dropped
+// while parsing, the way a browser drops it.
+type Diagnostic struct {
+	Kind DiagnosticKind
+
+	// Message describes why the text was dropped.
+	Message string
+
+	// Text is the dropped text, without comments.
+	Text string
+
+	// Span is the span of the dropped text in the source text.
+	Span css.Span
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%s: %s: %s: %q", d.Span.Start, d.Kind, d.Message, d.Text)
+}
+
+// selectorError is returned for style rules which are dropped because of
+// their selectors, so that they are reported as invalid selectors.
+type selectorError struct {
+	err error
+}
+
+func (e *selectorError) Error() string {
+	return e.err.Error()
+}
+
+func (e *selectorError) Unwrap() error {
+	return e.err
+}
+
+// isSelectorError returns whether the error is, or wraps, a selectorError.
+func isSelectorError(err error) bool {
+	var selectorErr *selectorError
+	return errors.As(err, &selectorErr)
+}
+
+// reportDiagnostic records a diagnostic for the given dropped text, which
+// starts at the given position and ends with the last consumed token.
+// Errors from selectors are reported as invalid selectors, other errors
+// with the given kind.
+func (p *Parser) reportDiagnostic(kind DiagnosticKind, err error, start css.Position, text string) {
+	if isSelectorError(err) {
+		kind = DiagnosticKindInvalidSelector
+	}
+
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		Kind:    kind,
+		Message: err.Error(),
+		Text:    strings.TrimSpace(text),
+		Span:    css.Span{Start: start, End: p.s.Position()},
+	})
+}
+
+// reportDroppedDescriptor records a diagnostic for a part of a descriptor
+// list dropped by one of the descriptor parsers, e.g. of @font-face.
+func (p *Parser) reportDroppedDescriptor(drop *descriptor.Drop) {
+	kind := DiagnosticKindInvalidDeclaration
+	if drop.Rule {
+		kind = DiagnosticKindInvalidRule
+	}
+
+	p.reportDiagnostic(kind, drop.Err, drop.Span.Start, drop.Text)
+}
+
+// sortDiagnostics sorts the diagnostics reported after the first n ones in
+// source order, for the blocks which report what they drop once they are
+// parsed.
+func (p *Parser) sortDiagnostics(n int) {
+	diagnostics := p.diagnostics[n:]
+	sort.SliceStable(diagnostics, func(i, j int) bool {
+		return diagnostics[i].Span.Start.Offset < diagnostics[j].Span.Start.Offset
+	})
+}
+
+// reportDroppedRules records a diagnostic for each rule which was parsed,
+// but dropped afterwards, e.g. an unknown at-rule in a keyframe.
+func (p *Parser) reportDroppedRules(rules []css.Rule, err error) {
+	for _, rule := range rules {
+		var span css.Span
+		if node, ok := rule.(interface{ NodeSpan() css.Span }); ok {
+			span = node.NodeSpan()
+		}
+
+		p.diagnostics = append(p.diagnostics, Diagnostic{
+			Kind:    DiagnosticKindInvalidRule,
+			Message: err.Error(),
+			Text:    rule.String(),
+			Span:    span,
+		})
+	}
+}
+
+// reportDroppedDeclaration records a diagnostic for a declaration which was
+// parsed, but dropped afterwards, e.g. an !important one in a keyframe.
+func (p *Parser) reportDroppedDeclaration(decl *css.Declaration, err error) {
+	p.diagnostics = append(p.diagnostics, Diagnostic{
+		Kind:    DiagnosticKindInvalidDeclaration,
+		Message: err.Error(),
+		Text:    decl.String(),
+		Span:    decl.Span,
+	})
+}
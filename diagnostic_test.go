@@ -0,0 +1,235 @@
+package cssparser
+
+import (
+	"strings"
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParser_Diagnostics(t *testing.T) {
+	testcases := []struct {
+		name        string
+		input       string
+		expected    string
+		diagnostics []Diagnostic
+	}{
+		{
+			name:     "valid stylesheet",
+			input:    "a { color: red; } @media print { b { } }",
+			expected: "a { color: red; } @media print { b { } }",
+		},
+		{
+			name:     "invalid selector",
+			input:    "a { color: red; } b c] { color: blue; } d { }",
+			expected: "a { color: red; } d { }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidSelector, Text: "b c] { color: blue; }"},
+			},
+		},
+		{
+			name:     "stray closing brace",
+			input:    "} a { } b { }",
+			expected: "b { }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidSelector, Text: "} a { }"},
+			},
+		},
+		{
+			name:     "invalid declarations",
+			input:    "a { color red; width: 1px; height: /* empty */ ; }",
+			expected: "a { width: 1px; }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "color red;"},
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "height:  ;"},
+			},
+		},
+		{
+			name:     "invalid nested rule",
+			input:    "a { color: red; 123 { } width: 1px; }",
+			expected: "a { color: red; width: 1px; }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidSelector, Text: "123 { }"},
+			},
+		},
+		{
+			name:     "misplaced at-rules",
+			input:    "a { @import \"x.css\"; color: red; } @namespace x url(y);",
+			expected: "a { color: red; }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidRule, Text: "@import \"x.css\";"},
+				{Kind: DiagnosticKindInvalidRule, Text: "@namespace x url(y);"},
+			},
+		},
+		{
+			name:     "invalid rule in a group rule",
+			input:    "@media print { ::foo { } a { } }",
+			expected: "@media print { a { } }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidSelector, Text: "::foo { }"},
+			},
+		},
+		{
+			name:     "invalid keyframe",
+			input:    "@keyframes x { 200% { } to { } }",
+			expected: "@keyframes x { 100% { } }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidRule, Text: "200% { }"},
+			},
+		},
+		{
+			name:     "invalid contents of @page",
+			input:    "@page { margin 1cm; size: a4; @media print { } @top-left { content: none; } }",
+			expected: "@page { size: a4; @top-left { content: none; } }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "margin 1cm;"},
+				{Kind: DiagnosticKindInvalidRule, Text: "@media print { }"},
+			},
+		},
+		{
+			name:     "disallowed contents of @function",
+			input:    "@function --f() { color: red; result: 1; .a { } }",
+			expected: "@function --f() { result: 1; }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "color: red;"},
+				{Kind: DiagnosticKindInvalidRule, Text: ".a { }"},
+			},
+		},
+		{
+			name:     "invalid font-face descriptors",
+			input:    "@font-face { font-weight: 2000; foo: bar; @foo; font-display: swap }",
+			expected: "@font-face { font-display: swap; }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "font-weight: 2000;"},
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "foo: bar;"},
+				{Kind: DiagnosticKindInvalidRule, Text: "@foo;"},
+			},
+		},
+		{
+			name:     "invalid counter-style descriptor",
+			input:    "@counter-style x { system: bogus; symbols: a }",
+			expected: "@counter-style x { symbols: a; }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "system: bogus;"},
+			},
+		},
+		{
+			name:     "invalid feature values",
+			input:    "@font-feature-values a { @swash { x: a; y: 1 } @bogus { } }",
+			expected: `@font-feature-values "a" { @swash { y: 1; } }`,
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "x: a;"},
+				{Kind: DiagnosticKindInvalidRule, Text: "@bogus { }"},
+			},
+		},
+		{
+			name:     "descriptors of a dropped rule",
+			input:    `@property --x { syntax: "<foo>"; inherits: false } a { }`,
+			expected: "a { }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidRule, Text: `@property --x { syntax: "<foo>"; inherits: false }`},
+			},
+		},
+		{
+			name:     "important declaration in keyframe",
+			input:    "@keyframes a { from { color: red !important; opacity: 0 } }",
+			expected: "@keyframes a { 0% { opacity: 0; } }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "color: red !important"},
+			},
+		},
+		{
+			name:     "at-rule in keyframe",
+			input:    "@keyframes a { from { @foo; opacity: 0 } }",
+			expected: "@keyframes a { 0% { opacity: 0; } }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidRule, Text: "@foo;"},
+			},
+		},
+		{
+			name:     "dropped in @position-try",
+			input:    "@position-try --p { @foo; top: 0; color: red }",
+			expected: "@position-try --p { top: 0; }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidRule, Text: "@foo;"},
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "color: red"},
+			},
+		},
+		{
+			name:     "dropped in @view-transition",
+			input:    "@view-transition { navigation: bogus; foo: bar }",
+			expected: "@view-transition { }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "navigation: bogus"},
+				{Kind: DiagnosticKindInvalidDeclaration, Text: "foo: bar"},
+			},
+		},
+		{
+			name:     "block closed by EOF",
+			input:    "a { color: red",
+			expected: "a { color: red; }",
+		},
+		{
+			name:     "nested block closed by EOF",
+			input:    "a { b { color: red }",
+			expected: "a { b { color: red; } }",
+		},
+		{
+			name:     "invalid rule closed by EOF",
+			input:    "a { } b] { color: red",
+			expected: "a { }",
+			diagnostics: []Diagnostic{
+				{Kind: DiagnosticKindInvalidSelector, Text: "b] { color: red"},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			parser := NewParser(input)
+
+			rules := parser.ParseStylesheet()
+
+			ruleStrs := make([]string, 0, len(rules))
+			for _, rule := range rules {
+				ruleStrs = append(ruleStrs, rule.String())
+			}
+			if result := strings.Join(ruleStrs, " "); result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+
+			diagnostics := parser.Diagnostics()
+			if len(diagnostics) != len(tc.diagnostics) {
+				t.Fatalf("expected %d diagnostics, got %v", len(tc.diagnostics), diagnostics)
+			}
+			for i, diagnostic := range diagnostics {
+				if diagnostic.Kind != tc.diagnostics[i].Kind || diagnostic.Text != tc.diagnostics[i].Text {
+					t.Errorf("diagnostic %d: expected %v %q, got %v %q", i, tc.diagnostics[i].Kind, tc.diagnostics[i].Text, diagnostic.Kind, diagnostic.Text)
+				}
+				if diagnostic.Message == "" {
+					t.Errorf("diagnostic %d: expected a message", i)
+				}
+			}
+		})
+	}
+}
+
+func TestParser_DiagnosticSpans(t *testing.T) {
+	source := "a { }\nb c] { color: blue; }\nd { e: ; }"
+
+	parser := NewParser(csslexer.NewInput(source))
+	parser.ParseStylesheet()
+
+	diagnostics := parser.Diagnostics()
+	if len(diagnostics) != 2 {
+		t.Fatalf("expected 2 diagnostics, got %v", diagnostics)
+	}
+
+	expected := []string{"2:1-2:22", "3:5-3:9"}
+	for i, diagnostic := range diagnostics {
+		if result := diagnostic.Span.String(); result != expected[i] {
+			t.Errorf("diagnostic %d: expected span %s, got %s", i, expected[i], result)
+		}
+	}
+}
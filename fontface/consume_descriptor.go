@@ -0,0 +1,67 @@
+package fontface
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+)
+
+// consumeDescriptorList consumes a list of @font-face descriptors.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-face-rule
+func (fp *FontFaceParser) consumeDescriptorList() *css.FontFaceRule {
+	rule := &css.FontFaceRule{}
+
+	descriptor.ConsumeList(fp.tokenStream, fp.report, func(d *descriptor.Descriptor) error {
+		return fp.consumeDescriptor(rule, d)
+	}, nil)
+
+	return rule
+}
+
+// consumeDescriptor adds a descriptor to the rule if it is valid.
+func (fp *FontFaceParser) consumeDescriptor(rule *css.FontFaceRule, d *descriptor.Descriptor) error {
+	name, values := d.Name, d.Values
+	value := css.SerializeComponentValueList(values)
+
+	switch name {
+	case "src":
+		sources, ok := parseSourceList(values, d.ItemSpans)
+		if !ok {
+			return invalidDescriptorError(name)
+		}
+		rule.Src = sources
+
+		sourceStrs := make([]string, 0, len(sources))
+		for _, source := range sources {
+			sourceStrs = append(sourceStrs, source.String())
+		}
+		value = strings.Join(sourceStrs, ", ")
+
+	case "unicode-range":
+		ranges, ok := parseUnicodeRangeList(values, d.ItemSpans)
+		if !ok {
+			return invalidDescriptorError(name)
+		}
+		rule.UnicodeRange = ranges
+
+		rangeStrs := make([]string, 0, len(ranges))
+		for _, urange := range ranges {
+			rangeStrs = append(rangeStrs, urange.String())
+		}
+		value = strings.Join(rangeStrs, ", ")
+
+	default:
+		validate, ok := descriptorValidators[name]
+		if !ok {
+			return unknownDescriptorError(name)
+		}
+		if !validate(css.StripComponentValueListWhitespace(values)) {
+			return invalidDescriptorError(name)
+		}
+	}
+
+	rule.Descriptors = append(rule.Descriptors, d.Declaration(value))
+	return nil
+}
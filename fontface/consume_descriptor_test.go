@@ -0,0 +1,98 @@
+package fontface
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeFontFaceDescriptors(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", "@font-face { }"},
+		{"font-family identifiers", "font-family: Open   Sans", "@font-face { font-family: Open   Sans; }"},
+		{"font-family string", `font-family: "Open Sans";`, `@font-face { font-family: "Open Sans"; }`},
+		{"generic font-family", "font-family: serif;", "@font-face { }"},
+		{"name is lowercased", "FONT-DISPLAY: swap", "@font-face { font-display: swap; }"},
+		{"font-weight range", "font-weight: 100 900;", "@font-face { font-weight: 100 900; }"},
+		{"font-weight out of range", "font-weight: 1001;", "@font-face { }"},
+		{"font-style oblique angles", "font-style: oblique 10deg 20deg;", "@font-face { font-style: oblique 10deg 20deg; }"},
+		{"font-style oblique angle out of range", "font-style: oblique 100deg;", "@font-face { }"},
+		{"font-stretch", "font-stretch: 75% 125%;", "@font-face { font-stretch: 75% 125%; }"},
+		{"font-width keyword", "font-width: condensed;", "@font-face { font-width: condensed; }"},
+		{"invalid font-display", "font-display: maybe;", "@font-face { }"},
+		{"metrics override", "ascent-override: 90%; descent-override: normal; line-gap-override: -1%;", "@font-face { ascent-override: 90%; descent-override: normal; }"},
+		{"size-adjust", "size-adjust: 110%;", "@font-face { size-adjust: 110%; }"},
+		{"font-feature-settings", `font-feature-settings: "liga" 0;`, `@font-face { font-feature-settings: "liga" 0; }`},
+		{"unknown descriptor", "color: red; font-display: swap;", "@font-face { font-display: swap; }"},
+		{"important descriptor", "font-display: swap !important;", "@font-face { }"},
+		{"empty value", "font-display: ;", "@font-face { }"},
+		{"missing colon", "font-display swap; size-adjust: 50%;", "@font-face { size-adjust: 50%; }"},
+		{"garbage before descriptor", "{ } ; 12px; font-display: block", "@font-face { font-display: block; }"},
+		{"src url", "src: url(a.woff2) format(woff2)", `@font-face { src: url("a.woff2") format("woff2"); }`},
+		{"src", `src: local(Foo  Bar), url("a.woff2") format("woff2") tech(variations, COLOR-COLRv1), url(a.ttf) FORMAT(TrueType);`, `@font-face { src: local("Foo Bar"), url("a.woff2") format("woff2") tech(variations, color-colrv1), url("a.ttf") format("truetype"); }`},
+		{"src invalid entries dropped", "src: url(a.woff) format(foo), url(b.woff) bar, local(), url(c.woff) tech(foo), url(d.woff);", `@font-face { src: url("d.woff"); }`},
+		{"src all invalid", "src: url(a.woff) format(foo);", "@font-face { }"},
+		{"unicode-range", "unicode-range: U+26, u+0-7F, U+4??;", "@font-face { unicode-range: U+26, U+0-7F, U+400-4FF; }"},
+		{"unicode-range invalid", "unicode-range: U+110000;", "@font-face { }"},
+		{"unicode-range reversed", "unicode-range: U+7F-0;", "@font-face { }"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			rule := ConsumeFontFaceDescriptors(ts, nil)
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if next := ts.Peek(); next.Type != csslexer.EOFToken {
+				t.Errorf("expected EOF, got %v", next.Type)
+			}
+		})
+	}
+}
+
+func Test_ConsumeFontFaceDescriptors_Parsed(t *testing.T) {
+	input := csslexer.NewInput(`src: url(a.woff); src: local(Foo), url(b.woff2) format("woff2"); unicode-range: U+0-FF, U+131`)
+	ts := token_stream.NewTokenStream(input)
+
+	rule := ConsumeFontFaceDescriptors(ts, nil)
+
+	if len(rule.Src) != 2 {
+		t.Fatalf("expected the last src descriptor with 2 sources, got %d", len(rule.Src))
+	}
+	if rule.Src[0].Local != "Foo" || rule.Src[1].URL != "b.woff2" || rule.Src[1].Format != "woff2" {
+		t.Errorf("unexpected sources: %q, %q", rule.Src[0], rule.Src[1])
+	}
+
+	if len(rule.UnicodeRange) != 2 {
+		t.Fatalf("expected 2 unicode ranges, got %d", len(rule.UnicodeRange))
+	}
+	if !rule.UnicodeRange[0].Contains('a') || rule.UnicodeRange[1].Contains('a') {
+		t.Errorf("unexpected unicode ranges: %v, %v", rule.UnicodeRange[0], rule.UnicodeRange[1])
+	}
+
+	if descriptor := rule.Descriptor("SRC"); descriptor == nil || descriptor.Value != `local("Foo"), url("b.woff2") format("woff2")` {
+		t.Errorf("unexpected src descriptor: %v", descriptor)
+	}
+}
+
+func TestIsFontFormat(t *testing.T) {
+	for _, name := range []string{"woff", "woff2", "truetype", "opentype", "collection", "embedded-opentype", "svg"} {
+		if !IsFontFormat(name) {
+			t.Errorf("expected %q to be a font format", name)
+		}
+	}
+	if IsFontFormat("ttf") {
+		t.Errorf("expected %q not to be a font format", "ttf")
+	}
+}
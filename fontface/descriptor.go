@@ -0,0 +1,212 @@
+package fontface
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/numeric"
+)
+
+// descriptorValidators maps the names of the @font-face descriptors, other
+// than src and unicode-range, to a function validating their value. The
+// functions are given the value without whitespace.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-face-rule
+var descriptorValidators = map[string]func(values []*css.ComponentValue) bool{
+	"font-family":             isValidFontFamily,
+	"font-style":              isValidFontStyle,
+	"font-weight":             isValidFontWeight,
+	"font-stretch":            isValidFontWidth,
+	"font-width":              isValidFontWidth,
+	"font-display":            isValidFontDisplay,
+	"font-feature-settings":   isAnyValue,
+	"font-variation-settings": isAnyValue,
+	"font-language-override":  isAnyValue,
+	"font-named-instance":     isAnyValue,
+	"ascent-override":         isValidMetricsOverride,
+	"descent-override":        isValidMetricsOverride,
+	"line-gap-override":       isValidMetricsOverride,
+	"size-adjust":             isValidSizeAdjust,
+}
+
+// isAnyValue accepts any value.
+func isAnyValue(values []*css.ComponentValue) bool {
+	return true
+}
+
+// isValidFontFamily validates the font-family descriptor, a single
+// <family-name>. Generic family keywords are not allowed.
+func isValidFontFamily(values []*css.ComponentValue) bool {
+	name, ok := parseFamilyName(values)
+	if !ok {
+		return false
+	}
+
+	if len(values) == 1 && values[0].IsToken(csslexer.IdentToken) {
+		switch strings.ToLower(name) {
+		case "serif", "sans-serif", "cursive", "fantasy", "monospace", "system-ui":
+			return false
+		}
+		if css.IsReservedCustomIdent(name) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidFontStyle validates the font-style descriptor:
+//
+//	auto | normal | italic | oblique [ <angle [-90deg,90deg]>{1,2} ]?
+func isValidFontStyle(values []*css.ComponentValue) bool {
+	if len(values) == 1 {
+		return values[0].IsKeyword("auto") ||
+			values[0].IsKeyword("normal") ||
+			values[0].IsKeyword("italic") ||
+			values[0].IsKeyword("oblique")
+	}
+
+	if len(values) > 3 || !values[0].IsKeyword("oblique") {
+		return false
+	}
+
+	for _, value := range values[1:] {
+		degrees, ok := parseAngle(value)
+		if !ok || degrees < -90 || degrees > 90 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidFontWeight validates the font-weight descriptor:
+//
+//	auto | [ normal | bold | <number [1,1000]> ]{1,2}
+func isValidFontWeight(values []*css.ComponentValue) bool {
+	if len(values) == 1 && values[0].IsKeyword("auto") {
+		return true
+	}
+
+	if len(values) == 0 || len(values) > 2 {
+		return false
+	}
+
+	for _, value := range values {
+		if value.IsKeyword("normal") || value.IsKeyword("bold") {
+			continue
+		}
+
+		number, ok := numeric.ParseNumber(value.Token)
+		if !ok || number < 1 || number > 1000 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidFontWidth validates the font-width descriptor, also known as
+// font-stretch:
+//
+//	auto | [ normal | <percentage [0,∞]> | ultra-condensed | ... | ultra-expanded ]{1,2}
+func isValidFontWidth(values []*css.ComponentValue) bool {
+	if len(values) == 1 && values[0].IsKeyword("auto") {
+		return true
+	}
+
+	if len(values) == 0 || len(values) > 2 {
+		return false
+	}
+
+	for _, value := range values {
+		if value.IsToken(csslexer.IdentToken) {
+			switch strings.ToLower(value.Token.Value) {
+			case "normal", "ultra-condensed", "extra-condensed", "condensed", "semi-condensed",
+				"semi-expanded", "expanded", "extra-expanded", "ultra-expanded":
+				continue
+			}
+			return false
+		}
+
+		percentage, ok := numeric.ParsePercentage(value.Token)
+		if !ok || percentage < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidFontDisplay validates the font-display descriptor:
+//
+//	auto | block | swap | fallback | optional
+func isValidFontDisplay(values []*css.ComponentValue) bool {
+	if len(values) != 1 || !values[0].IsToken(csslexer.IdentToken) {
+		return false
+	}
+
+	switch strings.ToLower(values[0].Token.Value) {
+	case "auto", "block", "swap", "fallback", "optional":
+		return true
+	default:
+		return false
+	}
+}
+
+// isValidMetricsOverride validates the ascent-override, descent-override
+// and line-gap-override descriptors:
+//
+//	[ normal | <percentage [0,∞]> ]{1,2}
+func isValidMetricsOverride(values []*css.ComponentValue) bool {
+	if len(values) == 0 || len(values) > 2 {
+		return false
+	}
+
+	for _, value := range values {
+		if value.IsKeyword("normal") {
+			continue
+		}
+
+		percentage, ok := numeric.ParsePercentage(value.Token)
+		if !ok || percentage < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isValidSizeAdjust validates the size-adjust descriptor, a
+// <percentage [0,∞]>.
+func isValidSizeAdjust(values []*css.ComponentValue) bool {
+	if len(values) != 1 {
+		return false
+	}
+
+	percentage, ok := numeric.ParsePercentage(values[0].Token)
+	return ok && percentage >= 0
+}
+
+// parseAngle returns the value in degrees of an <angle>.
+func parseAngle(value *css.ComponentValue) (float64, bool) {
+	number, unit, ok := numeric.ParseDimension(value.Token)
+	if !ok {
+		return 0, false
+	}
+
+	switch unit {
+	case "deg":
+		return number, true
+	case "grad":
+		return number * 360 / 400, true
+	case "rad":
+		return number * 180 / 3.141592653589793, true
+	case "turn":
+		return number * 360, true
+	default:
+		return 0, false
+	}
+}
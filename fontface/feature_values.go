@@ -0,0 +1,155 @@
+package fontface
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+	"go.baoshuo.dev/cssparser/numeric"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// featureValuesBlockTypes maps the lowercased names of the feature value
+// blocks to their type.
+var featureValuesBlockTypes = map[string]css.FontFeatureValuesBlockType{
+	"stylistic":         css.FontFeatureValuesStylistic,
+	"historical-forms":  css.FontFeatureValuesHistoricalForms,
+	"styleset":          css.FontFeatureValuesStyleset,
+	"character-variant": css.FontFeatureValuesCharacterVariant,
+	"swash":             css.FontFeatureValuesSwash,
+	"ornaments":         css.FontFeatureValuesOrnaments,
+	"annotation":        css.FontFeatureValuesAnnotation,
+}
+
+// ConsumeFontFeatureValues consumes the contents of a @font-feature-values
+// block until the end of the token stream, and returns the rule for the
+// given font families. Unknown blocks, invalid feature values and invalid
+// descriptors are dropped, and passed to report if it is not nil.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-feature-values
+func ConsumeFontFeatureValues(
+	tokenStream *token_stream.TokenStream,
+	familyNames []string,
+	report descriptor.ReportFunc,
+) *css.FontFeatureValuesRule {
+	return NewFontFaceParser(tokenStream, report).consumeFeatureValuesBlockList(familyNames)
+}
+
+// consumeFeatureValuesBlockList consumes the feature value blocks and the
+// descriptors of a @font-feature-values rule.
+func (fp *FontFaceParser) consumeFeatureValuesBlockList(familyNames []string) *css.FontFeatureValuesRule {
+	rule := &css.FontFeatureValuesRule{
+		FamilyNames: familyNames,
+	}
+
+	descriptor.ConsumeList(fp.tokenStream, fp.report, func(d *descriptor.Descriptor) error {
+		// font-display is the only descriptor allowed here
+		if d.Name != "font-display" {
+			return unknownDescriptorError(d.Name)
+		}
+		if !isValidFontDisplay(css.StripComponentValueListWhitespace(d.Values)) {
+			return invalidDescriptorError(d.Name)
+		}
+
+		rule.Descriptors = append(rule.Descriptors, d.Declaration(css.SerializeComponentValueList(d.Values)))
+		return nil
+	}, func() error {
+		block, err := fp.consumeFeatureValuesBlock()
+		if err != nil {
+			return err
+		}
+
+		rule.Blocks = append(rule.Blocks, block)
+		return nil
+	})
+
+	return rule
+}
+
+// consumeFeatureValuesBlock consumes a feature value block, e.g.
+// "@swash { fancy: 1; }". Returns an error if the block is unknown or
+// invalid.
+func (fp *FontFaceParser) consumeFeatureValuesBlock() (*css.FontFeatureValuesBlock, error) {
+	start := fp.tokenStream.PeekSpan().Start
+	name := strings.ToLower(fp.tokenStream.ConsumeIncludingWhitespace().Value)
+
+	blockType, ok := featureValuesBlockTypes[name]
+	if !ok {
+		descriptor.Skip(fp.tokenStream)
+		return nil, errors.New("unknown feature value block: @" + name)
+	}
+	if fp.tokenStream.Peek().Type != csslexer.LeftBraceToken {
+		descriptor.Skip(fp.tokenStream)
+		return nil, errors.New("expected '{' after @" + name)
+	}
+
+	block := &css.FontFeatureValuesBlock{
+		Type: blockType,
+	}
+	err := fp.tokenStream.ConsumeRuleBlock(func(ts *token_stream.TokenStream) error {
+		block.Values = fp.consumeFeatureValueList(blockType)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	block.SetSpan(css.Span{Start: start, End: fp.tokenStream.Position()})
+
+	return block, nil
+}
+
+// consumeFeatureValueList consumes the feature values of a block, e.g.
+// "fancy: 1; nice: 2;".
+func (fp *FontFaceParser) consumeFeatureValueList(blockType css.FontFeatureValuesBlockType) []*css.FontFeatureValue {
+	var featureValues []*css.FontFeatureValue
+
+	descriptor.ConsumeList(fp.tokenStream, fp.report, func(d *descriptor.Descriptor) error {
+		indexes, ok := parseFeatureIndexes(blockType, css.StripComponentValueListWhitespace(d.Values))
+		if !ok {
+			return errors.New("invalid feature value: " + d.RawName)
+		}
+
+		featureValue := &css.FontFeatureValue{
+			Name:    d.RawName, // Feature value names are case-sensitive
+			Indexes: indexes,
+		}
+		featureValue.SetSpan(d.Span)
+		featureValues = append(featureValues, featureValue)
+		return nil
+	}, nil)
+
+	return featureValues
+}
+
+// parseFeatureIndexes parses the non-negative integers of a feature value.
+// @styleset accepts any number of them, @character-variant one or two, and
+// the other blocks a single one.
+//
+// https://www.w3.org/TR/css-fonts-4/#multi-value-features
+func parseFeatureIndexes(blockType css.FontFeatureValuesBlockType, values []*css.ComponentValue) ([]int, bool) {
+	switch blockType {
+	case css.FontFeatureValuesStyleset:
+	case css.FontFeatureValuesCharacterVariant:
+		if len(values) > 2 {
+			return nil, false
+		}
+	default:
+		if len(values) != 1 {
+			return nil, false
+		}
+	}
+
+	indexes := make([]int, 0, len(values))
+	for _, value := range values {
+		index, ok := numeric.ParseInteger(value.Token)
+		if !ok || index < 0 {
+			return nil, false
+		}
+		indexes = append(indexes, index)
+	}
+
+	return indexes, true
+}
@@ -0,0 +1,94 @@
+package fontface
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeFontFeatureValues(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", `@font-feature-values "Foo" { }`},
+		{"swash", "@swash { fancy: 1; }", `@font-feature-values "Foo" { @swash { fancy: 1; } }`},
+		{"block name is case-insensitive", "@StyleSet { Nice-Style: 12 4 }", `@font-feature-values "Foo" { @styleset { Nice-Style: 12 4; } }`},
+		{"character-variant", "@character-variant { a: 1 2; b: 1 2 3; c: 3 }", `@font-feature-values "Foo" { @character-variant { a: 1 2; c: 3; } }`},
+		{"single value blocks", "@stylistic { a: 1; b: 1 2 } @ornaments { c: 2 } @annotation { d: 3 } @historical-forms { e: 1 }", `@font-feature-values "Foo" { @stylistic { a: 1; } @ornaments { c: 2; } @annotation { d: 3; } @historical-forms { e: 1; } }`},
+		{"invalid values", "@swash { a: -1; b: 1.5; c: foo; d: ; e: 2 !important; f: 3 }", `@font-feature-values "Foo" { @swash { f: 3; } }`},
+		{"unknown block", "@foo { a: 1 } @swash { b: 2 }", `@font-feature-values "Foo" { @swash { b: 2; } }`},
+		{"block without braces", "@swash; @swash { b: 2 }", `@font-feature-values "Foo" { @swash { b: 2; } }`},
+		{"font-display", "font-display: swap; color: red; font-display: maybe", `@font-feature-values "Foo" { font-display: swap; }`},
+		{"nested blocks are ignored", "@swash { a { b: 1 } c: 2 }", `@font-feature-values "Foo" { @swash { c: 2; } }`},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			rule := ConsumeFontFeatureValues(ts, []string{"Foo"}, nil)
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if next := ts.Peek(); next.Type != csslexer.EOFToken {
+				t.Errorf("expected EOF, got %v", next.Type)
+			}
+		})
+	}
+}
+
+func Test_ConsumeFontFeatureValues_Lookup(t *testing.T) {
+	input := csslexer.NewInput("@swash { fancy: 1; } @styleset { fancy: 2 3; } @swash { fancy: 4; }")
+	ts := token_stream.NewTokenStream(input)
+
+	rule := ConsumeFontFeatureValues(ts, []string{"Foo"}, nil)
+
+	if indexes := rule.Lookup(css.FontFeatureValuesSwash, "fancy"); len(indexes) != 1 || indexes[0] != 4 {
+		t.Errorf("expected [4], got %v", indexes)
+	}
+	if indexes := rule.Lookup(css.FontFeatureValuesStyleset, "fancy"); len(indexes) != 2 {
+		t.Errorf("expected [2 3], got %v", indexes)
+	}
+	if indexes := rule.Lookup(css.FontFeatureValuesSwash, "Fancy"); indexes != nil {
+		t.Errorf("expected no indexes, got %v", indexes)
+	}
+}
+
+func Test_ParseFamilyNameList(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected []string
+		ok       bool
+	}{
+		{"identifiers", "Font  One", []string{"Font One"}, true},
+		{"list", `Font One, "Font Two"`, []string{"Font One", "Font Two"}, true},
+		{"generic family", "Font One, serif", nil, false},
+		{"empty item", "Font One,", nil, false},
+		{"number", "Font 1", nil, false},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := token_stream.NewTokenStream(csslexer.NewInput(tc.input))
+
+			names, ok := ParseFamilyNameList(ts.ConsumeComponentValueList())
+			if ok != tc.ok || len(names) != len(tc.expected) {
+				t.Fatalf("expected %q, %v, got %q, %v", tc.expected, tc.ok, names, ok)
+			}
+			for i, name := range names {
+				if name != tc.expected[i] {
+					t.Errorf("expected %q, got %q", tc.expected[i], name)
+				}
+			}
+		})
+	}
+}
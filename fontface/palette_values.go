@@ -0,0 +1,148 @@
+package fontface
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+	"go.baoshuo.dev/cssparser/numeric"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// ConsumeFontPaletteValuesDescriptors consumes the contents of a
+// @font-palette-values block until the end of the token stream, and
+// returns the rule defining the palette with the given name. Invalid and
+// unknown descriptors are dropped, and passed to report if it is not nil.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-palette-values
+func ConsumeFontPaletteValuesDescriptors(
+	tokenStream *token_stream.TokenStream,
+	name string,
+	report descriptor.ReportFunc,
+) *css.FontPaletteValuesRule {
+	return NewFontFaceParser(tokenStream, report).consumePaletteValuesDescriptorList(name)
+}
+
+// consumePaletteValuesDescriptorList consumes a list of
+// @font-palette-values descriptors.
+func (fp *FontFaceParser) consumePaletteValuesDescriptorList(name string) *css.FontPaletteValuesRule {
+	rule := &css.FontPaletteValuesRule{
+		Name: name,
+	}
+
+	descriptor.ConsumeList(fp.tokenStream, fp.report, func(d *descriptor.Descriptor) error {
+		return fp.consumePaletteValuesDescriptor(rule, d)
+	}, nil)
+
+	return rule
+}
+
+// consumePaletteValuesDescriptor adds a descriptor to the rule if it is
+// valid.
+func (fp *FontFaceParser) consumePaletteValuesDescriptor(rule *css.FontPaletteValuesRule, d *descriptor.Descriptor) error {
+	values := d.Values
+
+	switch d.Name {
+	case "font-family":
+		familyNames, ok := ParseFamilyNameList(values)
+		if !ok {
+			return invalidDescriptorError(d.Name)
+		}
+		rule.FontFamily = familyNames
+
+	case "base-palette":
+		if !isValidBasePalette(css.StripComponentValueListWhitespace(values)) {
+			return invalidDescriptorError(d.Name)
+		}
+
+	case "override-colors":
+		colors, ok := parseOverrideColors(values)
+		if !ok {
+			return invalidDescriptorError(d.Name)
+		}
+		rule.OverrideColors = colors
+
+	default:
+		return unknownDescriptorError(d.Name)
+	}
+
+	rule.Descriptors = append(rule.Descriptors, d.Declaration(css.SerializeComponentValueList(values)))
+	return nil
+}
+
+// isValidBasePalette validates the base-palette descriptor:
+//
+//	light | dark | <integer [0,∞]>
+func isValidBasePalette(values []*css.ComponentValue) bool {
+	if len(values) != 1 {
+		return false
+	}
+
+	if values[0].IsKeyword("light") || values[0].IsKeyword("dark") {
+		return true
+	}
+
+	index, ok := numeric.ParseInteger(values[0].Token)
+	return ok && index >= 0
+}
+
+// parseOverrideColors parses the override-colors descriptor:
+//
+//	[ <integer [0,∞]> <absolute-color-base> ]#
+func parseOverrideColors(values []*css.ComponentValue) ([]*css.FontPaletteOverrideColor, bool) {
+	var colors []*css.FontPaletteOverrideColor
+
+	for _, item := range css.SplitComponentValueList(values) {
+		item = css.StripComponentValueListWhitespace(item)
+		if len(item) != 2 {
+			return nil, false
+		}
+
+		index, ok := numeric.ParseInteger(item[0].Token)
+		if !ok || index < 0 || !isAbsoluteColor(item[1]) {
+			return nil, false
+		}
+
+		colors = append(colors, &css.FontPaletteOverrideColor{Index: index, Color: item[1]})
+	}
+
+	return colors, true
+}
+
+// isAbsoluteColor checks if a component value looks like an
+// <absolute-color-base>, i.e. a color which doesn't depend on other
+// colors, such as a hex color, a named color or rgb().
+//
+// https://www.w3.org/TR/css-color-5/#typedef-absolute-color-base
+func isAbsoluteColor(value *css.ComponentValue) bool {
+	switch value.Type {
+	case css.ComponentValueTypePreservedToken:
+		switch value.Token.Type {
+		case csslexer.HashToken:
+			switch len(value.Token.Value) {
+			case 3, 4, 6, 8:
+				return true
+			default:
+				return false
+			}
+		case csslexer.IdentToken:
+			name := value.Token.Value
+			return !strings.EqualFold(name, "currentcolor") && !css.IsReservedCustomIdent(name)
+		default:
+			return false
+		}
+
+	case css.ComponentValueTypeFunction:
+		switch strings.ToLower(value.Token.Value) {
+		case "rgb", "rgba", "hsl", "hsla", "hwb", "lab", "lch", "oklab", "oklch", "color":
+			return true
+		default:
+			return false
+		}
+
+	default:
+		return false
+	}
+}
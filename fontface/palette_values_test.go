@@ -0,0 +1,58 @@
+package fontface
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeFontPaletteValuesDescriptors(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"empty", "", "@font-palette-values --p { }"},
+		{"font-family", `font-family: Bixa, "Bixa Color"; font-family: serif`, `@font-palette-values --p { font-family: Bixa, "Bixa Color"; }`},
+		{"base-palette", "base-palette: 1; base-palette: LIGHT; base-palette: -1; base-palette: foo", "@font-palette-values --p { base-palette: 1; base-palette: LIGHT; }"},
+		{"override-colors", "override-colors: 0 red, 1 #00ff00, 2 rgb(0 0 255)", "@font-palette-values --p { override-colors: 0 red, 1 #00ff00, 2 rgb(0 0 255); }"},
+		{"override-colors with currentcolor", "override-colors: 0 currentColor", "@font-palette-values --p { }"},
+		{"override-colors with relative color", "override-colors: 0 color-mix(in srgb, red, blue)", "@font-palette-values --p { }"},
+		{"override-colors without index", "override-colors: red", "@font-palette-values --p { }"},
+		{"override-colors with negative index", "override-colors: -1 red", "@font-palette-values --p { }"},
+		{"unknown and important descriptors", "color: red; base-palette: dark !important; base-palette: dark", "@font-palette-values --p { base-palette: dark; }"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			rule := ConsumeFontPaletteValuesDescriptors(ts, "--p", nil)
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+
+			if next := ts.Peek(); next.Type != csslexer.EOFToken {
+				t.Errorf("expected EOF, got %v", next.Type)
+			}
+		})
+	}
+}
+
+func Test_ConsumeFontPaletteValuesDescriptors_ParsedValues(t *testing.T) {
+	input := csslexer.NewInput(`font-family: "Bixa"; override-colors: 0 red, 3 #fff; override-colors: 1 blue`)
+	ts := token_stream.NewTokenStream(input)
+
+	rule := ConsumeFontPaletteValuesDescriptors(ts, "--p", nil)
+
+	if len(rule.FontFamily) != 1 || rule.FontFamily[0] != "Bixa" {
+		t.Errorf("unexpected font family %q", rule.FontFamily)
+	}
+	if len(rule.OverrideColors) != 1 || rule.OverrideColors[0].String() != "1 blue" {
+		t.Errorf("unexpected override colors %v", rule.OverrideColors)
+	}
+}
@@ -0,0 +1,26 @@
+package fontface
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+type FontFaceParser struct {
+	tokenStream *token_stream.TokenStream
+	report      descriptor.ReportFunc
+}
+
+func NewFontFaceParser(tokenStream *token_stream.TokenStream, report descriptor.ReportFunc) *FontFaceParser {
+	return &FontFaceParser{
+		tokenStream: tokenStream,
+		report:      report,
+	}
+}
+
+// ConsumeFontFaceDescriptors consumes the contents of a @font-face block
+// until the end of the token stream. Invalid and unknown descriptors are
+// dropped, and passed to report if it is not nil.
+func ConsumeFontFaceDescriptors(tokenStream *token_stream.TokenStream, report descriptor.ReportFunc) *css.FontFaceRule {
+	return NewFontFaceParser(tokenStream, report).consumeDescriptorList()
+}
@@ -0,0 +1,148 @@
+package fontface
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// parseSourceList parses the value of the src descriptor.
+//
+// Entries which can't be parsed are skipped, the descriptor is only
+// invalid if none of them can be parsed. Each source gets the span of its
+// entry from spans, which holds the spans of the comma-separated entries.
+//
+// https://www.w3.org/TR/css-fonts-4/#src-desc
+func parseSourceList(values []*css.ComponentValue, spans []css.Span) ([]*css.FontFaceSource, bool) {
+	var sources []*css.FontFaceSource
+
+	for i, item := range css.SplitComponentValueList(values) {
+		if source, ok := parseSource(css.StripComponentValueListWhitespace(item)); ok {
+			if i < len(spans) {
+				source.SetSpan(spans[i])
+			}
+			sources = append(sources, source)
+		}
+	}
+
+	return sources, len(sources) > 0
+}
+
+// parseSource parses a single entry of the src descriptor:
+//
+//	<url> [ format(<font-format>) ]? [ tech( <font-tech>#) ]? | local(<family-name>)
+func parseSource(values []*css.ComponentValue) (*css.FontFaceSource, bool) {
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	if values[0].IsFunction("local") {
+		if len(values) != 1 {
+			return nil, false
+		}
+
+		name, ok := parseFamilyName(css.StripComponentValueListWhitespace(values[0].Children))
+		if !ok {
+			return nil, false
+		}
+
+		return &css.FontFaceSource{
+			Type:  css.FontFaceSourceLocal,
+			Local: name,
+		}, true
+	}
+
+	url, ok := parseURL(values[0])
+	if !ok {
+		return nil, false
+	}
+
+	source := &css.FontFaceSource{
+		Type: css.FontFaceSourceURL,
+		URL:  url,
+	}
+	values = values[1:]
+
+	if len(values) > 0 && values[0].IsFunction("format") {
+		args := css.StripComponentValueListWhitespace(values[0].Children)
+		if len(args) != 1 {
+			return nil, false
+		}
+
+		switch {
+		case args[0].IsToken(csslexer.StringToken):
+			source.Format = args[0].Token.Value
+		case args[0].IsToken(csslexer.IdentToken) && IsFontFormat(strings.ToLower(args[0].Token.Value)):
+			source.Format = strings.ToLower(args[0].Token.Value)
+		default:
+			return nil, false
+		}
+
+		values = values[1:]
+	}
+
+	if len(values) > 0 && values[0].IsFunction("tech") {
+		for _, item := range css.SplitComponentValueList(values[0].Children) {
+			if len(item) != 1 || !item[0].IsToken(csslexer.IdentToken) {
+				return nil, false
+			}
+
+			tech := strings.ToLower(item[0].Token.Value)
+			if !IsFontTech(tech) {
+				return nil, false
+			}
+			source.Techs = append(source.Techs, tech)
+		}
+
+		values = values[1:]
+	}
+
+	if len(values) > 0 {
+		return nil, false
+	}
+
+	return source, true
+}
+
+// parseURL parses a <url>, which is either an url token or an url()
+// function holding a string.
+func parseURL(value *css.ComponentValue) (string, bool) {
+	if value.IsToken(csslexer.UrlToken) {
+		return value.Token.Value, true
+	}
+
+	if value.IsFunction("url") {
+		args := css.StripComponentValueListWhitespace(value.Children)
+		if len(args) == 1 && args[0].IsToken(csslexer.StringToken) {
+			return args[0].Token.Value, true
+		}
+	}
+
+	return "", false
+}
+
+// parseFamilyName parses a <family-name>, which is either a string or a
+// sequence of identifiers joined with single spaces.
+//
+// https://www.w3.org/TR/css-fonts-4/#family-name-syntax
+func parseFamilyName(values []*css.ComponentValue) (string, bool) {
+	if len(values) == 1 && values[0].IsToken(csslexer.StringToken) {
+		return values[0].Token.Value, true
+	}
+
+	if len(values) == 0 {
+		return "", false
+	}
+
+	idents := make([]string, 0, len(values))
+	for _, value := range values {
+		if !value.IsToken(csslexer.IdentToken) {
+			return "", false
+		}
+		idents = append(idents, value.Token.Value)
+	}
+
+	return strings.Join(idents, " "), true
+}
@@ -0,0 +1,125 @@
+package fontface
+
+import (
+	"strconv"
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// maxCodePoint is the largest valid Unicode code point.
+const maxCodePoint = 0x10FFFF
+
+// parseUnicodeRangeList parses the value of the unicode-range descriptor.
+// Each range gets its span from spans, which holds the spans of the
+// comma-separated ranges.
+//
+// https://www.w3.org/TR/css-fonts-4/#unicode-range-desc
+func parseUnicodeRangeList(values []*css.ComponentValue, spans []css.Span) ([]*css.UnicodeRange, bool) {
+	items := css.SplitComponentValueList(values)
+	ranges := make([]*css.UnicodeRange, 0, len(items))
+
+	for i, item := range items {
+		urange, ok := parseUnicodeRange(item)
+		if !ok {
+			return nil, false
+		}
+		if i < len(spans) {
+			urange.SetSpan(spans[i])
+		}
+		ranges = append(ranges, urange)
+	}
+
+	return ranges, true
+}
+
+// parseUnicodeRange parses a single <urange>.
+//
+// A <urange> may be split into several tokens, e.g. "u+0-7f" is an ident,
+// a number and a dimension, so it is parsed from the source text of the
+// tokens.
+//
+// https://www.w3.org/TR/css-syntax-3/#urange-syntax
+func parseUnicodeRange(values []*css.ComponentValue) (*css.UnicodeRange, bool) {
+	var text strings.Builder
+	for _, value := range values {
+		if value.Type != css.ComponentValueTypePreservedToken || value.IsWhitespace() {
+			return nil, false
+		}
+		text.WriteString(string(value.Token.Raw))
+	}
+
+	s := text.String()
+	if len(s) < 3 || (s[0] != 'u' && s[0] != 'U') || s[1] != '+' {
+		return nil, false
+	}
+	s = s[2:]
+
+	start, rest := consumeHexDigits(s, true)
+	if start == "" || len(start) > 6 {
+		return nil, false
+	}
+
+	var end string
+	switch {
+	case strings.Contains(start, "?"):
+		// Wildcard ranges can't have an end
+		if rest != "" {
+			return nil, false
+		}
+		end = strings.ReplaceAll(start, "?", "f")
+		start = strings.ReplaceAll(start, "?", "0")
+
+	case rest == "":
+		end = start
+
+	case rest[0] == '-':
+		end, rest = consumeHexDigits(rest[1:], false)
+		if end == "" || len(end) > 6 || rest != "" {
+			return nil, false
+		}
+
+	default:
+		return nil, false
+	}
+
+	startValue, err := strconv.ParseUint(start, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+	endValue, err := strconv.ParseUint(end, 16, 32)
+	if err != nil {
+		return nil, false
+	}
+
+	if endValue > maxCodePoint || startValue > endValue {
+		return nil, false
+	}
+
+	return &css.UnicodeRange{
+		Start: rune(startValue),
+		End:   rune(endValue),
+	}, true
+}
+
+// consumeHexDigits splits s after its leading hex digits. If allowWildcard
+// is true, question marks are allowed after the hex digits.
+func consumeHexDigits(s string, allowWildcard bool) (string, string) {
+	i := 0
+	for i < len(s) && isHexDigit(s[i]) {
+		i++
+	}
+
+	if allowWildcard {
+		for i < len(s) && s[i] == '?' {
+			i++
+		}
+	}
+
+	return s[:i], s[i:]
+}
+
+// isHexDigit checks if a byte is an ASCII hex digit.
+func isHexDigit(c byte) bool {
+	return (c >= '0' && c <= '9') || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F')
+}
@@ -0,0 +1,68 @@
+package fontface
+
+import (
+	"errors"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// IsFontTech checks if a lowercased identifier is a <font-tech> keyword.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-tech-definitions
+func IsFontTech(name string) bool {
+	switch name {
+	case "features-opentype", "features-aat", "features-graphite",
+		"color-colrv0", "color-colrv1", "color-svg", "color-sbix", "color-cbdt",
+		"variations", "palettes", "incremental":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsFontFormat checks if a lowercased identifier is a <font-format>
+// keyword.
+//
+// https://www.w3.org/TR/css-fonts-4/#font-format-definitions
+func IsFontFormat(name string) bool {
+	switch name {
+	case "collection", "embedded-opentype", "opentype", "svg", "truetype", "woff", "woff2":
+		return true
+	default:
+		return false
+	}
+}
+
+// ParseFamilyNameList parses a comma-separated list of <family-name>, as
+// used in the prelude of @font-feature-values and by the font-family
+// descriptor of @font-palette-values. Generic family keywords are not
+// allowed.
+//
+// https://www.w3.org/TR/css-fonts-4/#family-name-syntax
+func ParseFamilyNameList(values []*css.ComponentValue) ([]string, bool) {
+	var names []string
+
+	for _, item := range css.SplitComponentValueList(values) {
+		item = css.StripComponentValueListWhitespace(item)
+		if !isValidFontFamily(item) {
+			return nil, false
+		}
+
+		name, _ := parseFamilyName(item)
+		names = append(names, name)
+	}
+
+	return names, true
+}
+
+// unknownDescriptorError returns the error for a descriptor which doesn't
+// exist in the rule.
+func unknownDescriptorError(name string) error {
+	return errors.New("unknown descriptor: " + name)
+}
+
+// invalidDescriptorError returns the error for a descriptor with an
+// invalid value.
+func invalidDescriptorError(name string) error {
+	return errors.New("invalid value for descriptor: " + name)
+}
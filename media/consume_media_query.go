@@ -0,0 +1,503 @@
+package media
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/numeric"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeMediaQueryList consumes a comma-separated list of media queries.
+//
+// https://www.w3.org/TR/mediaqueries-5/#mq-list
+func (mp *MediaQueryParser) consumeMediaQueryList() *css.MediaQueryList {
+	list := &css.MediaQueryList{}
+
+	if mp.atEndOfQueryList() {
+		// An empty media query list matches all media.
+		return list
+	}
+
+	for {
+		start := mp.tokenStream.PeekSpan().Start
+		query, err := mp.consumeMediaQuery()
+		mp.tokenStream.ConsumeWhitespace()
+
+		if err != nil || !mp.atEndOfQuery() {
+			// An invalid media query is replaced by "not all", and the
+			// parsing continues with the next query in the list.
+			mp.tokenStream.SkipUntil(
+				csslexer.CommaToken,
+				csslexer.LeftBraceToken,
+				csslexer.SemicolonToken,
+				csslexer.RightBraceToken,
+			)
+			query = css.NewNotAllMediaQuery()
+		}
+		query.SetSpan(mp.spanFrom(start))
+
+		list.Queries = append(list.Queries, query)
+
+		if mp.tokenStream.AtEnd() || mp.tokenStream.Peek().Type != csslexer.CommaToken {
+			break
+		}
+
+		mp.tokenStream.ConsumeIncludingWhitespace() // Consume the comma
+	}
+
+	return list
+}
+
+// consumeMediaQuery consumes a single media query.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-media-query
+func (mp *MediaQueryParser) consumeMediaQuery() (*css.MediaQuery, error) {
+	query := &css.MediaQuery{}
+
+	if mp.tokenStream.Peek().Type == csslexer.IdentToken {
+		// [ not | only ]? <media-type> [ and <media-condition-without-or> ]?
+		state := mp.tokenStream.State()
+
+		restrictor := css.MediaQueryRestrictorNone
+		if mp.peekIsKeyword("not") {
+			restrictor = css.MediaQueryRestrictorNot
+			mp.tokenStream.ConsumeIncludingWhitespace()
+		} else if mp.peekIsKeyword("only") {
+			restrictor = css.MediaQueryRestrictorOnly
+			mp.tokenStream.ConsumeIncludingWhitespace()
+		}
+
+		token := mp.tokenStream.Peek()
+		if token.Type == csslexer.IdentToken && isValidMediaType(token.Value) {
+			mp.tokenStream.ConsumeIncludingWhitespace()
+
+			query.Restrictor = restrictor
+			query.MediaType = strings.ToLower(token.Value)
+
+			if !mp.peekIsKeyword("and") {
+				return query, nil
+			}
+			mp.tokenStream.ConsumeIncludingWhitespace()
+
+			condition, err := mp.consumeMediaCondition(false)
+			if err != nil {
+				return nil, err
+			}
+			query.Condition = condition
+
+			return query, nil
+		}
+
+		// Not a media type, so this must be a media condition,
+		// e.g. "not (color)".
+		state.Restore()
+	}
+
+	condition, err := mp.consumeMediaCondition(true)
+	if err != nil {
+		return nil, err
+	}
+	query.Condition = condition
+
+	return query, nil
+}
+
+// consumeMediaCondition consumes a media condition. If allowOr is false,
+// a <media-condition-without-or> is consumed instead.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-media-condition
+func (mp *MediaQueryParser) consumeMediaCondition(allowOr bool) (*css.MediaCondition, error) {
+	start := mp.tokenStream.PeekSpan().Start
+
+	if mp.peekIsKeyword("not") {
+		mp.tokenStream.ConsumeIncludingWhitespace()
+
+		operand, err := mp.consumeMediaInParens()
+		if err != nil {
+			return nil, err
+		}
+
+		condition := &css.MediaCondition{
+			Type:     css.MediaConditionNot,
+			Children: []*css.MediaCondition{operand},
+		}
+		condition.SetSpan(mp.spanFrom(start))
+
+		return condition, nil
+	}
+
+	first, err := mp.consumeMediaInParens()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only peek past the whitespace, so that it is left untouched if the
+	// condition ends here.
+	state := mp.tokenStream.State()
+	mp.tokenStream.ConsumeWhitespace()
+
+	var conditionType css.MediaConditionType
+	var keyword string
+	switch {
+	case mp.peekIsKeyword("and"):
+		conditionType = css.MediaConditionAnd
+		keyword = "and"
+	case allowOr && mp.peekIsKeyword("or"):
+		conditionType = css.MediaConditionOr
+		keyword = "or"
+	default:
+		state.Restore()
+		return first, nil
+	}
+
+	condition := &css.MediaCondition{
+		Type:     conditionType,
+		Children: []*css.MediaCondition{first},
+	}
+
+	for mp.peekIsKeyword(keyword) {
+		mp.tokenStream.ConsumeIncludingWhitespace()
+
+		operand, err := mp.consumeMediaInParens()
+		if err != nil {
+			return nil, err
+		}
+		condition.Children = append(condition.Children, operand)
+
+		state = mp.tokenStream.State()
+		mp.tokenStream.ConsumeWhitespace()
+	}
+	state.Restore()
+	condition.SetSpan(mp.spanFrom(start))
+
+	return condition, nil
+}
+
+// consumeMediaInParens consumes a parenthesized media condition, a media
+// feature or a general-enclosed value.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-media-in-parens
+func (mp *MediaQueryParser) consumeMediaInParens() (*css.MediaCondition, error) {
+	token := mp.tokenStream.Peek()
+	start := mp.tokenStream.PeekSpan().Start
+
+	switch token.Type {
+	case csslexer.LeftParenthesisToken:
+		state := mp.tokenStream.State()
+
+		// ( <media-condition> )
+		var condition *css.MediaCondition
+		err := mp.tokenStream.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+			ts.ConsumeWhitespace()
+
+			c, err := mp.consumeMediaCondition(true)
+			if err != nil {
+				return err
+			}
+
+			ts.ConsumeWhitespace()
+			if !ts.AtEnd() {
+				return errors.New("invalid media condition: unexpected tokens after condition")
+			}
+
+			condition = c
+			return nil
+		})
+		if err == nil {
+			// The span includes the parentheses
+			condition.SetSpan(mp.spanFrom(start))
+			return condition, nil
+		}
+		state.Restore()
+
+		// <media-feature>
+		var feature *css.MediaFeature
+		err = mp.tokenStream.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+			ts.ConsumeWhitespace()
+
+			f, err := mp.consumeMediaFeature()
+			if err != nil {
+				return err
+			}
+
+			ts.ConsumeWhitespace()
+			if !ts.AtEnd() {
+				return errors.New("invalid media feature: unexpected tokens after feature")
+			}
+
+			feature = f
+			return nil
+		})
+		if err == nil {
+			condition := &css.MediaCondition{
+				Type:    css.MediaConditionFeature,
+				Feature: feature,
+			}
+			feature.SetSpan(mp.spanFrom(start))
+			condition.SetSpan(feature.Span)
+			return condition, nil
+		}
+		state.Restore()
+
+		// <general-enclosed>
+		return mp.consumeGeneralEnclosed(), nil
+
+	case csslexer.FunctionToken:
+		// <general-enclosed>
+		return mp.consumeGeneralEnclosed(), nil
+
+	default:
+		return nil, errors.New("invalid media condition: expected '(' or function")
+	}
+}
+
+// consumeGeneralEnclosed consumes a function or a simple block as a
+// general-enclosed value.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-general-enclosed
+func (mp *MediaQueryParser) consumeGeneralEnclosed() *css.MediaCondition {
+	start := mp.tokenStream.PeekSpan().Start
+
+	condition := &css.MediaCondition{
+		Type:            css.MediaConditionGeneralEnclosed,
+		GeneralEnclosed: mp.tokenStream.ConsumeComponentValue(),
+	}
+	condition.SetSpan(mp.spanFrom(start))
+
+	return condition
+}
+
+// consumeMediaFeature consumes the contents of a media feature, i.e. what
+// is inside the parentheses.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-media-feature
+func (mp *MediaQueryParser) consumeMediaFeature() (*css.MediaFeature, error) {
+	if mp.tokenStream.Peek().Type == csslexer.IdentToken {
+		state := mp.tokenStream.State()
+
+		feature, err := mp.consumeMediaFeatureStartingWithName()
+		if err == nil {
+			return feature, nil
+		}
+
+		// The identifier may be a value, e.g. in "(landscape = orientation)"
+		state.Restore()
+	}
+
+	return mp.consumeMediaFeatureStartingWithValue()
+}
+
+// consumeMediaFeatureStartingWithName consumes <mf-boolean>, <mf-plain> and
+// the "<mf-name> <mf-comparison> <mf-value>" form of <mf-range>.
+func (mp *MediaQueryParser) consumeMediaFeatureStartingWithName() (*css.MediaFeature, error) {
+	name := strings.ToLower(mp.tokenStream.ConsumeIncludingWhitespace().Value)
+
+	if mp.tokenStream.AtEnd() {
+		// <mf-boolean>
+		return &css.MediaFeature{
+			Type: css.MediaFeatureBoolean,
+			Name: name,
+		}, nil
+	}
+
+	if mp.tokenStream.Peek().Type == csslexer.ColonToken {
+		// <mf-plain>
+		mp.tokenStream.ConsumeIncludingWhitespace()
+
+		value, err := mp.consumeMediaFeatureValue()
+		if err != nil {
+			return nil, err
+		}
+
+		return &css.MediaFeature{
+			Type:  css.MediaFeaturePlain,
+			Name:  name,
+			Value: value,
+		}, nil
+	}
+
+	comparison := mp.consumeComparison()
+	if comparison == css.MediaComparisonNone {
+		return nil, errors.New("invalid media feature: expected ':' or comparison after name")
+	}
+	if isPrefixedFeatureName(name) {
+		return nil, errors.New("invalid media feature: prefixed name in range context")
+	}
+
+	value, err := mp.consumeMediaFeatureValue()
+	if err != nil {
+		return nil, err
+	}
+
+	return &css.MediaFeature{
+		Type:            css.MediaFeatureRange,
+		Name:            name,
+		Right:           value,
+		RightComparison: comparison,
+	}, nil
+}
+
+// consumeMediaFeatureStartingWithValue consumes the
+// "<mf-value> <mf-comparison> <mf-name>" and
+// "<mf-value> <mf-lt|mf-gt> <mf-name> <mf-lt|mf-gt> <mf-value>" forms of
+// <mf-range>.
+func (mp *MediaQueryParser) consumeMediaFeatureStartingWithValue() (*css.MediaFeature, error) {
+	left, err := mp.consumeMediaFeatureValue()
+	if err != nil {
+		return nil, err
+	}
+
+	leftComparison := mp.consumeComparison()
+	if leftComparison == css.MediaComparisonNone {
+		return nil, errors.New("invalid media feature: expected comparison after value")
+	}
+
+	token := mp.tokenStream.Peek()
+	if token.Type != csslexer.IdentToken {
+		return nil, errors.New("invalid media feature: expected feature name")
+	}
+	name := strings.ToLower(token.Value)
+	if isPrefixedFeatureName(name) {
+		return nil, errors.New("invalid media feature: prefixed name in range context")
+	}
+	mp.tokenStream.ConsumeIncludingWhitespace()
+
+	feature := &css.MediaFeature{
+		Type:           css.MediaFeatureRange,
+		Name:           name,
+		Left:           left,
+		LeftComparison: leftComparison,
+	}
+
+	if mp.tokenStream.AtEnd() {
+		return feature, nil
+	}
+
+	rightComparison := mp.consumeComparison()
+	if !isSameDirection(leftComparison, rightComparison) {
+		return nil, errors.New("invalid media feature: mismatched comparisons in range")
+	}
+
+	right, err := mp.consumeMediaFeatureValue()
+	if err != nil {
+		return nil, err
+	}
+
+	feature.Right = right
+	feature.RightComparison = rightComparison
+
+	return feature, nil
+}
+
+// consumeComparison consumes a comparison operator and the whitespace after
+// it. Returns MediaComparisonNone without consuming anything if the next
+// tokens are not a comparison.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-mf-comparison
+func (mp *MediaQueryParser) consumeComparison() css.MediaComparisonType {
+	token := mp.tokenStream.Peek()
+	if token.Type != csslexer.DelimiterToken {
+		return css.MediaComparisonNone
+	}
+
+	var comparison, comparisonOrEqual css.MediaComparisonType
+	switch token.Value {
+	case "=":
+		mp.tokenStream.ConsumeIncludingWhitespace()
+		return css.MediaComparisonEqual
+	case "<":
+		comparison, comparisonOrEqual = css.MediaComparisonLess, css.MediaComparisonLessOrEqual
+	case ">":
+		comparison, comparisonOrEqual = css.MediaComparisonGreater, css.MediaComparisonGreaterOrEqual
+	default:
+		return css.MediaComparisonNone
+	}
+
+	mp.tokenStream.Consume()
+
+	// No whitespace is allowed between '<' or '>' and '='.
+	next := mp.tokenStream.Peek()
+	if next.Type == csslexer.DelimiterToken && next.Value == "=" {
+		mp.tokenStream.ConsumeIncludingWhitespace()
+		return comparisonOrEqual
+	}
+
+	mp.tokenStream.ConsumeWhitespace()
+	return comparison
+}
+
+// consumeMediaFeatureValue consumes a media feature value and the whitespace
+// after it.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-mf-value
+func (mp *MediaQueryParser) consumeMediaFeatureValue() (*css.MediaFeatureValue, error) {
+	token := mp.tokenStream.Peek()
+
+	switch token.Type {
+	case csslexer.NumberToken:
+		number, ok := numeric.ParseNumber(token)
+		if !ok {
+			return nil, errors.New("invalid media feature value: invalid number")
+		}
+		mp.tokenStream.ConsumeIncludingWhitespace()
+
+		// <ratio> = <number [0,∞]> [ / <number [0,∞]> ]?
+		state := mp.tokenStream.State()
+		if slash := mp.tokenStream.Peek(); slash.Type == csslexer.DelimiterToken && slash.Value == "/" {
+			mp.tokenStream.ConsumeIncludingWhitespace()
+
+			denominator, ok := numeric.ParseNumber(mp.tokenStream.Peek())
+			if ok && number >= 0 && denominator >= 0 {
+				mp.tokenStream.ConsumeIncludingWhitespace()
+				return &css.MediaFeatureValue{
+					Type:        css.MediaFeatureValueRatio,
+					Number:      number,
+					Denominator: denominator,
+				}, nil
+			}
+		}
+		state.Restore()
+
+		return &css.MediaFeatureValue{
+			Type:   css.MediaFeatureValueNumber,
+			Number: number,
+		}, nil
+
+	case csslexer.DimensionToken:
+		number, unit, ok := numeric.ParseDimension(token)
+		if !ok {
+			return nil, errors.New("invalid media feature value: invalid dimension")
+		}
+		mp.tokenStream.ConsumeIncludingWhitespace()
+
+		return &css.MediaFeatureValue{
+			Type:   css.MediaFeatureValueDimension,
+			Number: number,
+			Unit:   unit,
+		}, nil
+
+	case csslexer.IdentToken:
+		mp.tokenStream.ConsumeIncludingWhitespace()
+
+		return &css.MediaFeatureValue{
+			Type:  css.MediaFeatureValueIdent,
+			Ident: strings.ToLower(token.Value),
+		}, nil
+
+	case csslexer.FunctionToken:
+		value := mp.tokenStream.ConsumeComponentValue()
+		mp.tokenStream.ConsumeWhitespace()
+
+		return &css.MediaFeatureValue{
+			Type:  css.MediaFeatureValueOther,
+			Other: value,
+		}, nil
+
+	default:
+		return nil, errors.New("invalid media feature value: unexpected token")
+	}
+}
@@ -0,0 +1,182 @@
+package media
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeMediaQueryList(t *testing.T) {
+	testcases := []struct {
+		name              string
+		input             string
+		expected          string
+		expectedNextToken csslexer.TokenType
+	}{
+		{"empty list", "", "", csslexer.EOFToken},
+		{"empty list before block", "{", "", csslexer.LeftBraceToken},
+		{"media type", "screen", "screen", csslexer.EOFToken},
+		{"media type is lowercased", "SCREEN", "screen", csslexer.EOFToken},
+		{"only restrictor", "only screen", "only screen", csslexer.EOFToken},
+		{"not restrictor", "not print", "not print", csslexer.EOFToken},
+		{"media type and condition", "screen and (color)", "screen and (color)", csslexer.EOFToken},
+		{"media type and chained conditions", "screen and (color) and (hover)", "screen and (color) and (hover)", csslexer.EOFToken},
+		{"plain feature", "(min-width: 100px)", "(min-width: 100px)", csslexer.EOFToken},
+		{"feature name is lowercased", "(MIN-WIDTH: 100PX)", "(min-width: 100px)", csslexer.EOFToken},
+		{"plain feature without spaces", "(min-width:100px)", "(min-width: 100px)", csslexer.EOFToken},
+		{"ident value", "(orientation: landscape)", "(orientation: landscape)", csslexer.EOFToken},
+		{"ratio value", "(aspect-ratio: 16/9)", "(aspect-ratio: 16 / 9)", csslexer.EOFToken},
+		{"math function value", "(width: calc(100px + 2em))", "(width: calc(100px + 2em))", csslexer.EOFToken},
+		{"range", "(width >= 600px)", "(width >= 600px)", csslexer.EOFToken},
+		{"reversed range", "(600px < width)", "(600px < width)", csslexer.EOFToken},
+		{"double range", "(400px <= width < 800px)", "(400px <= width < 800px)", csslexer.EOFToken},
+		{"double range without spaces", "(400px<=width<800px)", "(400px <= width < 800px)", csslexer.EOFToken},
+		{"equal range", "(width = 100px)", "(width = 100px)", csslexer.EOFToken},
+		{"not condition", "not (color)", "not (color)", csslexer.EOFToken},
+		{"or condition", "(color) or (hover)", "(color) or (hover)", csslexer.EOFToken},
+		{"nested conditions", "((color) and (hover)) or (not (pointer: fine))", "((color) and (hover)) or (not (pointer: fine))", csslexer.EOFToken},
+		{"multiple queries", "screen, print and (color)", "screen, print and (color)", csslexer.EOFToken},
+		{"general enclosed function", "foo(bar) and (color)", "foo(bar) and (color)", csslexer.EOFToken},
+		{"general enclosed block", "(unknown thing)", "(unknown thing)", csslexer.EOFToken},
+		{"stops before block", "screen { a { } }", "screen", csslexer.LeftBraceToken},
+		{"stops before semicolon", "print;", "print", csslexer.SemicolonToken},
+		{"mixed and or is invalid", "(color) and (hover) or (pointer)", "not all", csslexer.EOFToken},
+		{"or after media type is invalid", "screen and (color) or (hover)", "not all", csslexer.EOFToken},
+		{"only without media type is invalid", "only (color)", "not all", csslexer.EOFToken},
+		{"reserved media type is invalid", "and", "not all", csslexer.EOFToken},
+		{"invalid query does not affect others", "screen, 1px, print", "screen, not all, print", csslexer.EOFToken},
+		{"trailing comma", "screen,", "screen, not all", csslexer.EOFToken},
+		{"prefixed range is invalid", "(min-width > 100px)", "(min-width > 100px)", csslexer.EOFToken},
+		{"mismatched range is invalid", "(100px < width > 200px)", "(100px < width > 200px)", csslexer.EOFToken},
+		{"whitespace in comparison is invalid", "(width < = 100px)", "(width < = 100px)", csslexer.EOFToken},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			list := ConsumeMediaQueryList(ts)
+
+			if list.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, list.String())
+			}
+
+			if next := ts.Peek(); next.Type != tc.expectedNextToken {
+				t.Errorf("expected next token %v, got %v", tc.expectedNextToken, next.Type)
+			}
+		})
+	}
+}
+
+func Test_ConsumeMediaQueryList_Structure(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected *css.MediaQueryList
+	}{
+		{
+			name:  "media type with range",
+			input: "only screen and (400px <= width < 800px)",
+			expected: &css.MediaQueryList{
+				Queries: []*css.MediaQuery{
+					{
+						Restrictor: css.MediaQueryRestrictorOnly,
+						MediaType:  "screen",
+						Condition: &css.MediaCondition{
+							Type: css.MediaConditionFeature,
+							Feature: &css.MediaFeature{
+								Type:            css.MediaFeatureRange,
+								Name:            "width",
+								Left:            &css.MediaFeatureValue{Type: css.MediaFeatureValueDimension, Number: 400, Unit: "px"},
+								LeftComparison:  css.MediaComparisonLessOrEqual,
+								Right:           &css.MediaFeatureValue{Type: css.MediaFeatureValueDimension, Number: 800, Unit: "px"},
+								RightComparison: css.MediaComparisonLess,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "not condition",
+			input: "not (hover)",
+			expected: &css.MediaQueryList{
+				Queries: []*css.MediaQuery{
+					{
+						Condition: &css.MediaCondition{
+							Type: css.MediaConditionNot,
+							Children: []*css.MediaCondition{
+								{
+									Type:    css.MediaConditionFeature,
+									Feature: &css.MediaFeature{Type: css.MediaFeatureBoolean, Name: "hover"},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:  "invalid query",
+			input: "screen and",
+			expected: &css.MediaQueryList{
+				Queries: []*css.MediaQuery{css.NewNotAllMediaQuery()},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			list := ConsumeMediaQueryList(ts)
+
+			if !list.Equals(tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected.String(), list.String())
+			}
+		})
+	}
+}
+
+func Test_ConsumeMediaCondition(t *testing.T) {
+	testcases := []struct {
+		name        string
+		input       string
+		expectError bool
+		expected    string
+	}{
+		{"feature", "(width > 100px)", false, "(width > 100px)"},
+		{"and condition", "(color) and (hover)", false, "(color) and (hover)"},
+		{"not condition", "not (color)", false, "not (color)"},
+		{"media type is not a condition", "screen", true, ""},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			condition, err := ConsumeMediaCondition(ts)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if condition.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, condition.String())
+			}
+		})
+	}
+}
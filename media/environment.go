@@ -0,0 +1,184 @@
+package media
+
+import "go.baoshuo.dev/cssparser/numeric"
+
+// Environment describes the device a media query is evaluated against.
+//
+// Zero values mean that a feature is unknown: media features depending on
+// them evaluate to ResultUnknown. Integer features are pointers instead,
+// nil if unknown, since zero is a valid value for them, e.g. Color is 0
+// on a monochrome device. Keyword fields hold the lowercased value of the
+// matching media feature, e.g. "dark" for PrefersColorScheme.
+type Environment struct {
+	MediaType string // The media type, e.g. "screen" or "print"
+
+	Width        float64 // The viewport width in CSS pixels
+	Height       float64 // The viewport height in CSS pixels
+	DeviceWidth  float64 // The screen width in CSS pixels
+	DeviceHeight float64 // The screen height in CSS pixels
+	Resolution   float64 // The resolution in dots per CSS pixel
+	Color        *int    // The number of bits per color component, 0 if not a color device
+	Monochrome   *int    // The number of bits per pixel in a monochrome frame buffer, 0 if not a monochrome device
+	FontSize     float64 // The initial font size in CSS pixels, used for em and rem; 16 if zero
+
+	Hover                      string // "none" or "hover"
+	AnyHover                   string // "none" or "hover"
+	Pointer                    string // "none", "coarse" or "fine"
+	AnyPointer                 string // "none", "coarse" or "fine"
+	PrefersColorScheme         string // "light" or "dark"
+	PrefersReducedMotion       string // "no-preference" or "reduce"
+	PrefersReducedTransparency string // "no-preference" or "reduce"
+	PrefersReducedData         string // "no-preference" or "reduce"
+	PrefersContrast            string // "no-preference", "more", "less" or "custom"
+	ForcedColors               string // "none" or "active"
+	InvertedColors             string // "none" or "inverted"
+	Scripting                  string // "none", "initial-only" or "enabled"
+	Update                     string // "none", "slow" or "fast"
+	DisplayMode                string // "browser", "standalone", "fullscreen", etc.
+}
+
+// rangeValueType is the type of the value of a range media feature.
+type rangeValueType int
+
+const (
+	rangeValueLength     rangeValueType = iota // Example: width
+	rangeValueRatio                            // Example: aspect-ratio
+	rangeValueResolution                       // Example: resolution
+	rangeValueInteger                          // Example: color
+)
+
+// rangeFeatures maps the names of the supported range media features to
+// the type of their values.
+//
+// https://www.w3.org/TR/mediaqueries-5/#mq-range-context
+var rangeFeatures = map[string]rangeValueType{
+	"width":               rangeValueLength,
+	"height":              rangeValueLength,
+	"device-width":        rangeValueLength,
+	"device-height":       rangeValueLength,
+	"aspect-ratio":        rangeValueRatio,
+	"device-aspect-ratio": rangeValueRatio,
+	"resolution":          rangeValueResolution,
+	"color":               rangeValueInteger,
+	"monochrome":          rangeValueInteger,
+}
+
+// discreteFeatures maps the names of the supported discrete media features
+// to the value which evaluates to false in a boolean context, or to an
+// empty string if every value evaluates to true.
+//
+// https://www.w3.org/TR/mediaqueries-5/#mq-boolean-context
+var discreteFeatures = map[string]string{
+	"orientation":                  "",
+	"hover":                        "none",
+	"any-hover":                    "none",
+	"pointer":                      "none",
+	"any-pointer":                  "none",
+	"prefers-color-scheme":         "",
+	"prefers-reduced-motion":       "no-preference",
+	"prefers-reduced-transparency": "no-preference",
+	"prefers-reduced-data":         "no-preference",
+	"prefers-contrast":             "no-preference",
+	"forced-colors":                "none",
+	"inverted-colors":              "none",
+	"scripting":                    "none",
+	"update":                       "none",
+	"display-mode":                 "",
+}
+
+// rangeValue returns the value of a range media feature in the canonical
+// unit of its type, i.e. CSS pixels, dots per CSS pixel or a plain number.
+func (env *Environment) rangeValue(name string) (float64, bool) {
+	switch name {
+	case "color":
+		return integerValue(env.Color)
+	case "monochrome":
+		return integerValue(env.Monochrome)
+	}
+
+	var value float64
+
+	switch name {
+	case "width":
+		value = env.Width
+	case "height":
+		value = env.Height
+	case "device-width":
+		value = env.DeviceWidth
+	case "device-height":
+		value = env.DeviceHeight
+	case "aspect-ratio":
+		if env.Height != 0 {
+			value = env.Width / env.Height
+		}
+	case "device-aspect-ratio":
+		if env.DeviceHeight != 0 {
+			value = env.DeviceWidth / env.DeviceHeight
+		}
+	case "resolution":
+		value = env.Resolution
+	}
+
+	return value, value != 0
+}
+
+// integerValue returns the value of an integer media feature, if known.
+func integerValue(value *int) (float64, bool) {
+	if value == nil {
+		return 0, false
+	}
+	return float64(*value), true
+}
+
+// discreteValue returns the value of a discrete media feature, or an empty
+// string if it is unknown.
+func (env *Environment) discreteValue(name string) string {
+	switch name {
+	case "orientation":
+		if env.Width == 0 || env.Height == 0 {
+			return ""
+		}
+		if env.Height >= env.Width {
+			return "portrait"
+		}
+		return "landscape"
+	case "hover":
+		return env.Hover
+	case "any-hover":
+		return env.AnyHover
+	case "pointer":
+		return env.Pointer
+	case "any-pointer":
+		return env.AnyPointer
+	case "prefers-color-scheme":
+		return env.PrefersColorScheme
+	case "prefers-reduced-motion":
+		return env.PrefersReducedMotion
+	case "prefers-reduced-transparency":
+		return env.PrefersReducedTransparency
+	case "prefers-reduced-data":
+		return env.PrefersReducedData
+	case "prefers-contrast":
+		return env.PrefersContrast
+	case "forced-colors":
+		return env.ForcedColors
+	case "inverted-colors":
+		return env.InvertedColors
+	case "scripting":
+		return env.Scripting
+	case "update":
+		return env.Update
+	case "display-mode":
+		return env.DisplayMode
+	default:
+		return ""
+	}
+}
+
+// fontSize returns the font size used to resolve font-relative lengths.
+func (env *Environment) fontSize() float64 {
+	if env.FontSize != 0 {
+		return env.FontSize
+	}
+	return numeric.DefaultFontSize
+}
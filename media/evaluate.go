@@ -0,0 +1,325 @@
+package media
+
+import (
+	"math"
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/numeric"
+)
+
+// ===== Result =====
+
+// Result is the three-valued result of evaluating a media query.
+//
+// https://www.w3.org/TR/mediaqueries-5/#evaluating
+type Result int
+
+const (
+	ResultFalse   Result = iota // The query does not match
+	ResultTrue                  // The query matches
+	ResultUnknown               // The query depends on something the environment does not know
+)
+
+func (r Result) String() string {
+	switch r {
+	case ResultFalse:
+		return "false"
+	case ResultTrue:
+		return "true"
+	default:
+		return "unknown"
+	}
+}
+
+// Not returns the negation of the result. The negation of unknown is
+// unknown.
+func (r Result) Not() Result {
+	switch r {
+	case ResultFalse:
+		return ResultTrue
+	case ResultTrue:
+		return ResultFalse
+	default:
+		return ResultUnknown
+	}
+}
+
+// And returns the conjunction of two results.
+func (r Result) And(other Result) Result {
+	if r == ResultFalse || other == ResultFalse {
+		return ResultFalse
+	}
+	if r == ResultUnknown || other == ResultUnknown {
+		return ResultUnknown
+	}
+	return ResultTrue
+}
+
+// Or returns the disjunction of two results.
+func (r Result) Or(other Result) Result {
+	if r == ResultTrue || other == ResultTrue {
+		return ResultTrue
+	}
+	if r == ResultUnknown || other == ResultUnknown {
+		return ResultUnknown
+	}
+	return ResultFalse
+}
+
+// ===== Evaluation =====
+
+// Evaluate evaluates a media query list against the environment.
+//
+// An empty list always matches. Otherwise the list matches if any of its
+// queries matches.
+func Evaluate(list *css.MediaQueryList, env *Environment) Result {
+	if len(list.Queries) == 0 {
+		return ResultTrue
+	}
+
+	result := ResultFalse
+	for _, query := range list.Queries {
+		result = result.Or(EvaluateMediaQuery(query, env))
+	}
+	return result
+}
+
+// EvaluateMediaQuery evaluates a single media query against the
+// environment.
+func EvaluateMediaQuery(query *css.MediaQuery, env *Environment) Result {
+	result := evaluateMediaType(query.MediaType, env)
+
+	if query.Condition != nil {
+		result = result.And(EvaluateMediaCondition(query.Condition, env))
+	}
+
+	if query.Restrictor == css.MediaQueryRestrictorNot {
+		return result.Not()
+	}
+	return result
+}
+
+// EvaluateMediaCondition evaluates a media condition against the
+// environment.
+//
+// General-enclosed conditions, unsupported media features and values
+// which cannot be resolved evaluate to unknown.
+func EvaluateMediaCondition(condition *css.MediaCondition, env *Environment) Result {
+	switch condition.Type {
+	case css.MediaConditionFeature:
+		return evaluateMediaFeature(condition.Feature, env)
+
+	case css.MediaConditionNot:
+		return EvaluateMediaCondition(condition.Children[0], env).Not()
+
+	case css.MediaConditionAnd:
+		result := ResultTrue
+		for _, child := range condition.Children {
+			result = result.And(EvaluateMediaCondition(child, env))
+		}
+		return result
+
+	case css.MediaConditionOr:
+		result := ResultFalse
+		for _, child := range condition.Children {
+			result = result.Or(EvaluateMediaCondition(child, env))
+		}
+		return result
+
+	default:
+		return ResultUnknown
+	}
+}
+
+// evaluateMediaType checks if the media type of a query matches the
+// environment. An omitted media type is the same as "all".
+func evaluateMediaType(mediaType string, env *Environment) Result {
+	if mediaType == "" || mediaType == "all" {
+		return ResultTrue
+	}
+	if env.MediaType == "" {
+		return ResultUnknown
+	}
+	return resultOf(strings.EqualFold(mediaType, env.MediaType))
+}
+
+// evaluateMediaFeature evaluates a media feature against the environment.
+//
+// https://www.w3.org/TR/mediaqueries-5/#mq-features
+func evaluateMediaFeature(feature *css.MediaFeature, env *Environment) Result {
+	name := feature.Name
+	comparison := css.MediaComparisonEqual
+
+	if feature.Type == css.MediaFeaturePlain && isPrefixedFeatureName(name) {
+		if strings.HasPrefix(name, "min-") {
+			comparison = css.MediaComparisonGreaterOrEqual
+		} else {
+			comparison = css.MediaComparisonLessOrEqual
+		}
+		name = name[len("min-"):]
+
+		// Prefixes are only valid for range features
+		if _, ok := rangeFeatures[name]; !ok {
+			return ResultUnknown
+		}
+	}
+
+	if valueType, ok := rangeFeatures[name]; ok {
+		return evaluateRangeFeature(feature, name, comparison, valueType, env)
+	}
+
+	if falseValue, ok := discreteFeatures[name]; ok {
+		return evaluateDiscreteFeature(feature, name, falseValue, env)
+	}
+
+	return ResultUnknown
+}
+
+// evaluateRangeFeature evaluates a media feature of the range type, e.g.
+// width or resolution.
+//
+// https://www.w3.org/TR/mediaqueries-5/#mq-range-context
+func evaluateRangeFeature(
+	feature *css.MediaFeature,
+	name string,
+	comparison css.MediaComparisonType,
+	valueType rangeValueType,
+	env *Environment,
+) Result {
+	envValue, known := env.rangeValue(name)
+
+	switch feature.Type {
+	case css.MediaFeatureBoolean:
+		// https://www.w3.org/TR/mediaqueries-5/#mq-boolean-context
+		if !known {
+			return ResultUnknown
+		}
+		return resultOf(envValue != 0)
+
+	case css.MediaFeaturePlain:
+		value, ok := resolveRangeValue(feature.Value, valueType, env)
+		if !ok || !known {
+			return ResultUnknown
+		}
+		return resultOf(compare(envValue, comparison, value))
+
+	case css.MediaFeatureRange:
+		result := ResultTrue
+
+		if feature.Left != nil {
+			value, ok := resolveRangeValue(feature.Left, valueType, env)
+			if !ok || !known {
+				return ResultUnknown
+			}
+			result = result.And(resultOf(compare(value, feature.LeftComparison, envValue)))
+		}
+
+		if feature.Right != nil {
+			value, ok := resolveRangeValue(feature.Right, valueType, env)
+			if !ok || !known {
+				return ResultUnknown
+			}
+			result = result.And(resultOf(compare(envValue, feature.RightComparison, value)))
+		}
+
+		return result
+
+	default:
+		return ResultUnknown
+	}
+}
+
+// evaluateDiscreteFeature evaluates a media feature of the discrete type,
+// e.g. hover or prefers-color-scheme.
+func evaluateDiscreteFeature(
+	feature *css.MediaFeature,
+	name string,
+	falseValue string,
+	env *Environment,
+) Result {
+	envValue := env.discreteValue(name)
+
+	switch feature.Type {
+	case css.MediaFeatureBoolean:
+		if envValue == "" {
+			return ResultUnknown
+		}
+		return resultOf(envValue != falseValue)
+
+	case css.MediaFeaturePlain:
+		if feature.Value.Type != css.MediaFeatureValueIdent || envValue == "" {
+			return ResultUnknown
+		}
+		return resultOf(feature.Value.Ident == strings.ToLower(envValue))
+
+	default:
+		// Discrete features can't be used in a range context
+		return ResultUnknown
+	}
+}
+
+// resolveRangeValue converts the value of a range media feature to the
+// canonical unit of its type, see Environment.rangeValue.
+func resolveRangeValue(value *css.MediaFeatureValue, valueType rangeValueType, env *Environment) (float64, bool) {
+	switch valueType {
+	case rangeValueLength:
+		switch value.Type {
+		case css.MediaFeatureValueNumber:
+			// Unitless zero is the only number allowed as a length
+			return 0, value.Number == 0
+		case css.MediaFeatureValueDimension:
+			return numeric.ToPixels(value.Number, value.Unit, env.fontSize())
+		}
+
+	case rangeValueRatio:
+		switch value.Type {
+		case css.MediaFeatureValueNumber:
+			return value.Number, true
+		case css.MediaFeatureValueRatio:
+			if value.Denominator == 0 {
+				// A ratio with a zero denominator is infinite, 0/0 is degenerate
+				return math.Inf(1), value.Number != 0
+			}
+			return value.Number / value.Denominator, true
+		}
+
+	case rangeValueResolution:
+		if value.Type == css.MediaFeatureValueDimension {
+			return numeric.ToDppx(value.Number, value.Unit)
+		}
+
+	case rangeValueInteger:
+		if value.Type == css.MediaFeatureValueNumber && value.Number == math.Trunc(value.Number) {
+			return value.Number, true
+		}
+	}
+
+	return 0, false
+}
+
+// compare applies the comparison to two values, e.g. a < b.
+func compare(a float64, comparison css.MediaComparisonType, b float64) bool {
+	switch comparison {
+	case css.MediaComparisonEqual:
+		return a == b
+	case css.MediaComparisonLess:
+		return a < b
+	case css.MediaComparisonLessOrEqual:
+		return a <= b
+	case css.MediaComparisonGreater:
+		return a > b
+	case css.MediaComparisonGreaterOrEqual:
+		return a >= b
+	default:
+		return false
+	}
+}
+
+// resultOf converts a boolean to a Result.
+func resultOf(b bool) Result {
+	if b {
+		return ResultTrue
+	}
+	return ResultFalse
+}
@@ -0,0 +1,141 @@
+package media
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_Evaluate(t *testing.T) {
+	intPointer := func(value int) *int { return &value }
+
+	desktop := &Environment{
+		MediaType:            "screen",
+		Width:                1280,
+		Height:               800,
+		Resolution:           2,
+		Color:                intPointer(8),
+		Monochrome:           intPointer(0),
+		Hover:                "hover",
+		Pointer:              "fine",
+		PrefersColorScheme:   "dark",
+		PrefersReducedMotion: "no-preference",
+	}
+	eInk := &Environment{
+		MediaType:  "screen",
+		Color:      intPointer(0),
+		Monochrome: intPointer(4),
+	}
+	unknown := &Environment{}
+
+	testcases := []struct {
+		name     string
+		input    string
+		env      *Environment
+		expected Result
+	}{
+		{"empty list", "", desktop, ResultTrue},
+		{"all", "all", unknown, ResultTrue},
+		{"matching media type", "screen", desktop, ResultTrue},
+		{"other media type", "print", desktop, ResultFalse},
+		{"unknown media type", "print", unknown, ResultUnknown},
+		{"not media type", "not print", desktop, ResultTrue},
+		{"only media type", "only screen", desktop, ResultTrue},
+		{"invalid query", "screen and", desktop, ResultFalse},
+		{"min-width", "(min-width: 1024px)", desktop, ResultTrue},
+		{"max-width", "(max-width: 1024px)", desktop, ResultFalse},
+		{"width in em", "(min-width: 80em)", desktop, ResultTrue},
+		{"width with unitless zero", "(min-width: 0)", desktop, ResultTrue},
+		{"width with number", "(min-width: 10)", desktop, ResultUnknown},
+		{"width with unknown unit", "(min-width: 10vw)", desktop, ResultUnknown},
+		{"width with math function", "(min-width: calc(10px + 1em))", desktop, ResultUnknown},
+		{"unknown width", "(min-width: 1024px)", unknown, ResultUnknown},
+		{"range", "(width >= 1280px)", desktop, ResultTrue},
+		{"reversed range", "(1280px < width)", desktop, ResultFalse},
+		{"double range", "(1024px <= width < 1440px)", desktop, ResultTrue},
+		{"double range out of range", "(400px <= width < 800px)", desktop, ResultFalse},
+		{"boolean range feature", "(width)", desktop, ResultTrue},
+		{"aspect-ratio", "(min-aspect-ratio: 16/10)", desktop, ResultTrue},
+		{"aspect-ratio as number", "(aspect-ratio > 2)", desktop, ResultFalse},
+		{"resolution", "(min-resolution: 192dpi)", desktop, ResultTrue},
+		{"resolution with length", "(min-resolution: 2px)", desktop, ResultUnknown},
+		{"color", "(color)", desktop, ResultTrue},
+		{"min-color", "(min-color: 10)", desktop, ResultFalse},
+		{"color on a monochrome device", "(color)", eInk, ResultFalse},
+		{"max-color on a monochrome device", "(max-color: 0)", eInk, ResultTrue},
+		{"monochrome", "(monochrome)", eInk, ResultTrue},
+		{"min-monochrome", "(min-monochrome: 2)", eInk, ResultTrue},
+		{"monochrome on a color device", "(monochrome)", desktop, ResultFalse},
+		{"unknown color", "(color)", unknown, ResultUnknown},
+		{"unknown monochrome", "(monochrome: 0)", unknown, ResultUnknown},
+		{"orientation", "(orientation: landscape)", desktop, ResultTrue},
+		{"orientation mismatch", "(orientation: portrait)", desktop, ResultFalse},
+		{"hover", "(hover)", desktop, ResultTrue},
+		{"hover value", "(hover: none)", desktop, ResultFalse},
+		{"pointer", "(pointer: fine)", desktop, ResultTrue},
+		{"prefers-color-scheme", "(prefers-color-scheme: dark)", desktop, ResultTrue},
+		{"prefers-reduced-motion", "(prefers-reduced-motion)", desktop, ResultFalse},
+		{"unknown discrete feature", "(prefers-contrast: more)", desktop, ResultUnknown},
+		{"prefixed discrete feature", "(min-hover: hover)", desktop, ResultUnknown},
+		{"discrete feature in range", "(hover > none)", desktop, ResultUnknown},
+		{"unsupported feature", "(scan: interlace)", desktop, ResultUnknown},
+		{"general enclosed", "(unknown thing)", desktop, ResultUnknown},
+		{"media type and condition", "screen and (min-width: 1024px)", desktop, ResultTrue},
+		{"not condition", "not (hover: none)", desktop, ResultTrue},
+		{"not unknown", "not (unknown thing)", desktop, ResultUnknown},
+		{"and with false", "(hover) and (max-width: 100px)", desktop, ResultFalse},
+		{"and with unknown", "(hover) and (unknown thing)", desktop, ResultUnknown},
+		{"and with false and unknown", "(unknown thing) and (max-width: 100px)", desktop, ResultFalse},
+		{"or with true and unknown", "(unknown thing) or (hover)", desktop, ResultTrue},
+		{"or with false and unknown", "(unknown thing) or (hover: none)", desktop, ResultUnknown},
+		{"not media type with unknown condition", "not screen and (unknown thing)", desktop, ResultUnknown},
+		{"list with one match", "print, screen and (hover)", desktop, ResultTrue},
+		{"list without match", "print, (max-width: 100px)", desktop, ResultFalse},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			list := ConsumeMediaQueryList(ts)
+
+			if result := Evaluate(list, tc.env); result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
+
+func Test_Result(t *testing.T) {
+	results := []Result{ResultFalse, ResultTrue, ResultUnknown}
+
+	expectedAnd := [][]Result{
+		{ResultFalse, ResultFalse, ResultFalse},
+		{ResultFalse, ResultTrue, ResultUnknown},
+		{ResultFalse, ResultUnknown, ResultUnknown},
+	}
+	expectedOr := [][]Result{
+		{ResultFalse, ResultTrue, ResultUnknown},
+		{ResultTrue, ResultTrue, ResultTrue},
+		{ResultUnknown, ResultTrue, ResultUnknown},
+	}
+	expectedNot := []Result{ResultTrue, ResultFalse, ResultUnknown}
+
+	for i, a := range results {
+		if result := a.Not(); result != expectedNot[i] {
+			t.Errorf("not %v: expected %v, got %v", a, expectedNot[i], result)
+		}
+
+		for j, b := range results {
+			if result := a.And(b); result != expectedAnd[i][j] {
+				t.Errorf("%v and %v: expected %v, got %v", a, b, expectedAnd[i][j], result)
+			}
+			if result := a.Or(b); result != expectedOr[i][j] {
+				t.Errorf("%v or %v: expected %v, got %v", a, b, expectedOr[i][j], result)
+			}
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package media
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+type MediaQueryParser struct {
+	tokenStream *token_stream.TokenStream
+}
+
+func NewMediaQueryParser(tokenStream *token_stream.TokenStream) *MediaQueryParser {
+	return &MediaQueryParser{
+		tokenStream: tokenStream,
+	}
+}
+
+// ConsumeMediaQueryList consumes a media query list, stopping before a '{',
+// a ';' or the end of the token stream.
+//
+// Invalid media queries in the list are replaced with "not all".
+func ConsumeMediaQueryList(tokenStream *token_stream.TokenStream) *css.MediaQueryList {
+	tokenStream.ConsumeWhitespace()
+	return NewMediaQueryParser(tokenStream).consumeMediaQueryList()
+}
+
+// ConsumeMediaCondition consumes a media condition, stopping at the first
+// token which cannot be part of it.
+func ConsumeMediaCondition(tokenStream *token_stream.TokenStream) (*css.MediaCondition, error) {
+	tokenStream.ConsumeWhitespace()
+	return NewMediaQueryParser(tokenStream).consumeMediaCondition(true)
+}
+
+// ConsumeMediaFeature consumes the contents of a media feature, i.e. what
+// is inside the parentheses, and the whitespace after it. Container
+// queries use the same syntax for their size and scroll-state features.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-media-feature
+func ConsumeMediaFeature(tokenStream *token_stream.TokenStream) (*css.MediaFeature, error) {
+	tokenStream.ConsumeWhitespace()
+	return NewMediaQueryParser(tokenStream).consumeMediaFeature()
+}
@@ -0,0 +1,36 @@
+package media
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+func (mp *MediaQueryParser) atEndOfQueryList() bool {
+	if mp.tokenStream.AtEnd() {
+		return true
+	}
+
+	t := mp.tokenStream.Peek()
+
+	return t.Type == csslexer.LeftBraceToken || t.Type == csslexer.SemicolonToken
+}
+
+func (mp *MediaQueryParser) atEndOfQuery() bool {
+	return mp.atEndOfQueryList() || mp.tokenStream.Peek().Type == csslexer.CommaToken
+}
+
+// peekIsKeyword checks if the next token is the given identifier, ignoring
+// ASCII case.
+func (mp *MediaQueryParser) peekIsKeyword(keyword string) bool {
+	t := mp.tokenStream.Peek()
+	return t.Type == csslexer.IdentToken && strings.EqualFold(t.Value, keyword)
+}
+
+// spanFrom returns the span of a node which starts at the given position
+// and ends with the last consumed token, not counting whitespace.
+func (mp *MediaQueryParser) spanFrom(start css.Position) css.Span {
+	return css.Span{Start: start, End: mp.tokenStream.PositionBeforeWhitespace()}
+}
@@ -0,0 +1,40 @@
+package media
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// isValidMediaType checks if an identifier can be used as a media type.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-media-type
+func isValidMediaType(name string) bool {
+	switch strings.ToLower(name) {
+	case "only", "not", "and", "or", "layer":
+		return false
+	default:
+		return true
+	}
+}
+
+// isPrefixedFeatureName checks if a media feature name has a min- or max-
+// prefix, which is only allowed in the plain feature syntax.
+func isPrefixedFeatureName(name string) bool {
+	return strings.HasPrefix(name, "min-") || strings.HasPrefix(name, "max-")
+}
+
+// isSameDirection checks if two comparisons can be chained in a range, i.e.
+// they are both "less than" or both "greater than" comparisons.
+//
+// https://www.w3.org/TR/mediaqueries-5/#typedef-mf-range
+func isSameDirection(a, b css.MediaComparisonType) bool {
+	isLess := func(c css.MediaComparisonType) bool {
+		return c == css.MediaComparisonLess || c == css.MediaComparisonLessOrEqual
+	}
+	isGreater := func(c css.MediaComparisonType) bool {
+		return c == css.MediaComparisonGreater || c == css.MediaComparisonGreaterOrEqual
+	}
+
+	return (isLess(a) && isLess(b)) || (isGreater(a) && isGreater(b))
+}
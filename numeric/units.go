@@ -0,0 +1,115 @@
+package numeric
+
+// DefaultFontSize is the font size in CSS pixels used to resolve
+// font-relative lengths when no other font size is known.
+const DefaultFontSize = 16
+
+// ToPixels converts a length to CSS pixels.
+//
+// Font-relative units (em, rem, ex, ch) are resolved against the given
+// font size, approximating ex and ch as half of it. Other relative units
+// cannot be resolved and are reported as not ok.
+//
+// https://www.w3.org/TR/css-values-4/#lengths
+func ToPixels(value float64, unit string, fontSize float64) (float64, bool) {
+	switch unit {
+	case "px":
+		return value, true
+	case "cm":
+		return value * 96 / 2.54, true
+	case "mm":
+		return value * 96 / 25.4, true
+	case "q":
+		return value * 96 / 101.6, true
+	case "in":
+		return value * 96, true
+	case "pt":
+		return value * 96 / 72, true
+	case "pc":
+		return value * 96 / 6, true
+	case "em", "rem":
+		return value * fontSize, true
+	case "ex", "ch":
+		return value * fontSize / 2, true
+	default:
+		return 0, false
+	}
+}
+
+// ToDppx converts a resolution to dots per CSS pixel.
+//
+// https://www.w3.org/TR/css-values-4/#resolution
+func ToDppx(value float64, unit string) (float64, bool) {
+	switch unit {
+	case "dppx", "x":
+		return value, true
+	case "dpi":
+		return value / 96, true
+	case "dpcm":
+		return value * 2.54 / 96, true
+	default:
+		return 0, false
+	}
+}
+
+// IsAbsoluteLengthUnit checks if a lowercased unit is an absolute length
+// unit.
+//
+// https://www.w3.org/TR/css-values-4/#absolute-lengths
+func IsAbsoluteLengthUnit(unit string) bool {
+	switch unit {
+	case "px", "cm", "mm", "q", "in", "pt", "pc":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsLengthUnit checks if a lowercased unit is a length unit, either
+// absolute or relative.
+//
+// https://www.w3.org/TR/css-values-4/#lengths
+func IsLengthUnit(unit string) bool {
+	if IsAbsoluteLengthUnit(unit) {
+		return true
+	}
+
+	switch unit {
+	case "em", "rem", "ex", "rex", "cap", "rcap", "ch", "rch", "ic", "ric", "lh", "rlh",
+		"vw", "vh", "vi", "vb", "vmin", "vmax",
+		"svw", "svh", "svi", "svb", "svmin", "svmax",
+		"lvw", "lvh", "lvi", "lvb", "lvmin", "lvmax",
+		"dvw", "dvh", "dvi", "dvb", "dvmin", "dvmax",
+		"cqw", "cqh", "cqi", "cqb", "cqmin", "cqmax":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsAngleUnit checks if a lowercased unit is an angle unit.
+//
+// https://www.w3.org/TR/css-values-4/#angles
+func IsAngleUnit(unit string) bool {
+	switch unit {
+	case "deg", "grad", "rad", "turn":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsTimeUnit checks if a lowercased unit is a time unit.
+//
+// https://www.w3.org/TR/css-values-4/#time
+func IsTimeUnit(unit string) bool {
+	return unit == "s" || unit == "ms"
+}
+
+// IsResolutionUnit checks if a lowercased unit is a resolution unit.
+//
+// https://www.w3.org/TR/css-values-4/#resolution
+func IsResolutionUnit(unit string) bool {
+	_, ok := ToDppx(1, unit)
+	return ok
+}
@@ -0,0 +1,95 @@
+package numeric
+
+import "testing"
+
+func TestToPixels(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		unit     string
+		expected float64
+		ok       bool
+	}{
+		{"pixels", 10, "px", 10, true},
+		{"inches", 1, "in", 96, true},
+		{"points", 72, "pt", 96, true},
+		{"picas", 1, "pc", 16, true},
+		{"em", 2, "em", 32, true},
+		{"rem", 1.5, "rem", 24, true},
+		{"viewport unit", 10, "vw", 0, false},
+		{"unknown unit", 10, "foo", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := ToPixels(tt.value, tt.unit, DefaultFontSize)
+			if ok != tt.ok || value != tt.expected {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, value, ok)
+			}
+		})
+	}
+}
+
+func TestToDppx(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		unit     string
+		expected float64
+		ok       bool
+	}{
+		{"dppx", 2, "dppx", 2, true},
+		{"x", 1.5, "x", 1.5, true},
+		{"dpi", 192, "dpi", 2, true},
+		{"not a resolution", 2, "px", 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := ToDppx(tt.value, tt.unit)
+			if ok != tt.ok || value != tt.expected {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, value, ok)
+			}
+		})
+	}
+}
+
+func TestUnitCategories(t *testing.T) {
+	tests := []struct {
+		unit       string
+		absolute   bool
+		length     bool
+		angle      bool
+		time       bool
+		resolution bool
+	}{
+		{"px", true, true, false, false, false},
+		{"q", true, true, false, false, false},
+		{"em", false, true, false, false, false},
+		{"cqmin", false, true, false, false, false},
+		{"turn", false, false, true, false, false},
+		{"ms", false, false, false, true, false},
+		{"dpcm", false, false, false, false, true},
+		{"foo", false, false, false, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.unit, func(t *testing.T) {
+			if got := IsAbsoluteLengthUnit(tt.unit); got != tt.absolute {
+				t.Errorf("IsAbsoluteLengthUnit: expected %v, got %v", tt.absolute, got)
+			}
+			if got := IsLengthUnit(tt.unit); got != tt.length {
+				t.Errorf("IsLengthUnit: expected %v, got %v", tt.length, got)
+			}
+			if got := IsAngleUnit(tt.unit); got != tt.angle {
+				t.Errorf("IsAngleUnit: expected %v, got %v", tt.angle, got)
+			}
+			if got := IsTimeUnit(tt.unit); got != tt.time {
+				t.Errorf("IsTimeUnit: expected %v, got %v", tt.time, got)
+			}
+			if got := IsResolutionUnit(tt.unit); got != tt.resolution {
+				t.Errorf("IsResolutionUnit: expected %v, got %v", tt.resolution, got)
+			}
+		})
+	}
+}
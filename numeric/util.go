@@ -0,0 +1,119 @@
+package numeric
+
+import (
+	"strconv"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+// ParseNumber returns the numeric value of a <number-token>.
+func ParseNumber(token csslexer.Token) (float64, bool) {
+	if token.Type != csslexer.NumberToken {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(token.Value, 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// ParseInteger returns the value of a <number-token> holding an integer.
+func ParseInteger(token csslexer.Token) (int, bool) {
+	if token.Type != csslexer.NumberToken {
+		return 0, false
+	}
+
+	value, err := strconv.Atoi(strings.TrimPrefix(token.Value, "+"))
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// ParsePercentage returns the numeric value of a <percentage-token>,
+// e.g. 50 for "50%".
+func ParsePercentage(token csslexer.Token) (float64, bool) {
+	if token.Type != csslexer.PercentageToken {
+		return 0, false
+	}
+
+	value, err := strconv.ParseFloat(strings.TrimSuffix(token.Value, "%"), 64)
+	if err != nil {
+		return 0, false
+	}
+
+	return value, true
+}
+
+// ParseDimension returns the numeric value and the lowercased unit of a
+// <dimension-token>, e.g. 10 and "px" for "10PX".
+func ParseDimension(token csslexer.Token) (float64, string, bool) {
+	if token.Type != csslexer.DimensionToken {
+		return 0, "", false
+	}
+
+	n := numberPrefixLength(token.Value)
+	if n == 0 || n == len(token.Value) {
+		return 0, "", false
+	}
+
+	value, err := strconv.ParseFloat(token.Value[:n], 64)
+	if err != nil {
+		return 0, "", false
+	}
+
+	return value, strings.ToLower(token.Value[n:]), true
+}
+
+// FormatNumber returns the shortest string representation of a number,
+// as used when serializing CSS values.
+func FormatNumber(value float64) string {
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// numberPrefixLength returns the length of the number at the start of s.
+//
+// https://www.w3.org/TR/2021/CRD-css-syntax-3-20211224/#consume-number
+func numberPrefixLength(s string) int {
+	i := 0
+
+	if i < len(s) && (s[i] == '+' || s[i] == '-') {
+		i++
+	}
+
+	i += digitsLength(s[i:])
+
+	if i+1 < len(s) && s[i] == '.' && isDigit(s[i+1]) {
+		i++
+		i += digitsLength(s[i:])
+	}
+
+	if i+1 < len(s) && (s[i] == 'e' || s[i] == 'E') {
+		j := i + 1
+		if s[j] == '+' || s[j] == '-' {
+			j++
+		}
+		if j < len(s) && isDigit(s[j]) {
+			i = j + digitsLength(s[j:])
+		}
+	}
+
+	return i
+}
+
+func digitsLength(s string) int {
+	i := 0
+	for i < len(s) && isDigit(s[i]) {
+		i++
+	}
+	return i
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
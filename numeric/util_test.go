@@ -0,0 +1,122 @@
+package numeric
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestParseNumber(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    csslexer.Token
+		expected float64
+		ok       bool
+	}{
+		{"integer", csslexer.Token{Type: csslexer.NumberToken, Value: "42"}, 42, true},
+		{"decimal", csslexer.Token{Type: csslexer.NumberToken, Value: "-0.5"}, -0.5, true},
+		{"exponent", csslexer.Token{Type: csslexer.NumberToken, Value: "1e3"}, 1000, true},
+		{"not a number", csslexer.Token{Type: csslexer.IdentToken, Value: "a"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := ParseNumber(tt.token)
+			if ok != tt.ok || value != tt.expected {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, value, ok)
+			}
+		})
+	}
+}
+
+func TestParseInteger(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    csslexer.Token
+		expected int
+		ok       bool
+	}{
+		{"integer", csslexer.Token{Type: csslexer.NumberToken, Value: "42"}, 42, true},
+		{"signed integer", csslexer.Token{Type: csslexer.NumberToken, Value: "+3"}, 3, true},
+		{"negative integer", csslexer.Token{Type: csslexer.NumberToken, Value: "-3"}, -3, true},
+		{"decimal", csslexer.Token{Type: csslexer.NumberToken, Value: "1.5"}, 0, false},
+		{"not a number", csslexer.Token{Type: csslexer.DimensionToken, Value: "1px"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := ParseInteger(tt.token)
+			if ok != tt.ok || value != tt.expected {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, value, ok)
+			}
+		})
+	}
+}
+
+func TestParsePercentage(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    csslexer.Token
+		expected float64
+		ok       bool
+	}{
+		{"percentage", csslexer.Token{Type: csslexer.PercentageToken, Value: "50%"}, 50, true},
+		{"decimal percentage", csslexer.Token{Type: csslexer.PercentageToken, Value: "12.5%"}, 12.5, true},
+		{"not a percentage", csslexer.Token{Type: csslexer.NumberToken, Value: "50"}, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, ok := ParsePercentage(tt.token)
+			if ok != tt.ok || value != tt.expected {
+				t.Errorf("expected (%v, %v), got (%v, %v)", tt.expected, tt.ok, value, ok)
+			}
+		})
+	}
+}
+
+func TestParseDimension(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        csslexer.Token
+		expected     float64
+		expectedUnit string
+		ok           bool
+	}{
+		{"pixels", csslexer.Token{Type: csslexer.DimensionToken, Value: "10px"}, 10, "px", true},
+		{"uppercase unit", csslexer.Token{Type: csslexer.DimensionToken, Value: "1.5EM"}, 1.5, "em", true},
+		{"unit starting with e", csslexer.Token{Type: csslexer.DimensionToken, Value: "2em"}, 2, "em", true},
+		{"exponent", csslexer.Token{Type: csslexer.DimensionToken, Value: "1e2px"}, 100, "px", true},
+		{"negative", csslexer.Token{Type: csslexer.DimensionToken, Value: "-3dppx"}, -3, "dppx", true},
+		{"not a dimension", csslexer.Token{Type: csslexer.NumberToken, Value: "10"}, 0, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			value, unit, ok := ParseDimension(tt.token)
+			if ok != tt.ok || value != tt.expected || unit != tt.expectedUnit {
+				t.Errorf("expected (%v, %q, %v), got (%v, %q, %v)", tt.expected, tt.expectedUnit, tt.ok, value, unit, ok)
+			}
+		})
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0"},
+		{1.5, "1.5"},
+		{-2, "-2"},
+		{1000, "1000"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if result := FormatNumber(tt.value); result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
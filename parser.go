@@ -3,6 +3,7 @@ package cssparser
 import (
 	"go.baoshuo.dev/csslexer"
 
+	"go.baoshuo.dev/cssparser/charset"
 	"go.baoshuo.dev/cssparser/css"
 	"go.baoshuo.dev/cssparser/nesting"
 	"go.baoshuo.dev/cssparser/token_stream"
@@ -10,15 +11,87 @@ import (
 
 type Parser struct {
 	s *token_stream.TokenStream
+
+	// namespaces holds the namespaces declared by the @namespace rules
+	// parsed so far, used to resolve the namespace prefixes of selectors.
+	namespaces css.NamespaceMap
+
+	// encoding is the name of the encoding the stylesheet was decoded
+	// from.
+	encoding string
+
+	// unsupportedEncoding is the label of the unknown or unsupported
+	// encoding ignored while decoding the stylesheet, if any.
+	unsupportedEncoding string
+
+	// expandNestingParent is whether '&' in the selectors of nested rules
+	// is replaced by the selectors of the parent rule.
+	expandNestingParent bool
+
+	// diagnostics holds the diagnostics reported so far.
+	diagnostics []Diagnostic
 }
 
 func NewParser(input *csslexer.Input) *Parser {
 	return &Parser{
-		s: token_stream.NewTokenStream(input),
+		s:          token_stream.NewTokenStream(input),
+		namespaces: css.NamespaceMap{},
+		encoding:   charset.UTF8,
 	}
 }
 
-func (p *Parser) ParseStylesheet() ([]*css.StyleRule, error) {
+// NewParserFromBytes creates a parser for a stylesheet given as bytes of
+// an unknown encoding, which is decoded to UTF-8 first.
+//
+// The protocol encoding, e.g. the charset parameter of a Content-Type
+// header, and the environment encoding, e.g. the encoding of the referring
+// stylesheet, are encoding labels and may be empty. See charset.Decode for
+// how the encoding is determined.
+//
+// https://www.w3.org/TR/css-syntax-3/#input-byte-stream
+func NewParserFromBytes(data []byte, protocolEncoding, environmentEncoding string) *Parser {
+	text, encoding, unsupported := charset.Decode(data, protocolEncoding, environmentEncoding)
+
+	parser := NewParser(csslexer.NewInput(text))
+	parser.encoding = encoding
+	parser.unsupportedEncoding = unsupported
+
+	return parser
+}
+
+// Encoding returns the name of the encoding the stylesheet was decoded
+// from, which is "utf-8" for parsers created with NewParser.
+func (p *Parser) Encoding() string {
+	return p.encoding
+}
+
+// UnsupportedEncoding returns the label of the encoding given for the
+// stylesheet which was ignored because it is unknown or not supported, e.g.
+// "shift_jis", or an empty string. The stylesheet is then decoded with
+// another encoding, see Encoding, and its text is likely wrong.
+func (p *Parser) UnsupportedEncoding() string {
+	return p.unsupportedEncoding
+}
+
+// SetExpandNestingParent sets whether the selectors of nested rules are
+// expanded while parsing: '&' is replaced by the selectors of the parent
+// rule, and nested selectors without '&' are made relative to the parent
+// rule. The selectors of each rule can then be matched on their own. It is
+// off by default, which keeps the selectors as written.
+//
+// https://www.w3.org/TR/css-nesting-1/#nest-selector
+func (p *Parser) SetExpandNestingParent(expand bool) {
+	p.expandNestingParent = expand
+}
+
+// ParseStylesheet parses the stylesheet and returns its rules.
+//
+// Like in browsers, invalid rules, declarations and selectors don't stop
+// the parsing: they are dropped, and the rest of the stylesheet is parsed
+// as usual. Each dropped part is reported by Diagnostics.
+//
+// https://www.w3.org/TR/css-syntax-3/#error-handling
+func (p *Parser) ParseStylesheet() []css.Rule {
 	return p.consumeRuleList(
 		topLevelAllowedRules,
 		true,
@@ -26,3 +99,9 @@ func (p *Parser) ParseStylesheet() ([]*css.StyleRule, error) {
 		nil,
 	)
 }
+
+// Diagnostics returns the diagnostics reported for the rules,
+// declarations and selectors dropped while parsing, in source order.
+func (p *Parser) Diagnostics() []Diagnostic {
+	return p.diagnostics
+}
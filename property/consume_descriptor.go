@@ -0,0 +1,82 @@
+package property
+
+import (
+	"errors"
+	"fmt"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+)
+
+// consumeDescriptorList consumes a list of @property descriptors and
+// checks that they make a valid rule.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#at-property-rule
+func (pp *PropertyParser) consumeDescriptorList(name string) (*css.PropertyRule, error) {
+	rule := &css.PropertyRule{
+		Name: name,
+	}
+	hasInherits := false
+
+	// The error of the last invalid syntax descriptor, reported if there is
+	// no valid one
+	var syntaxErr error
+
+	descriptor.ConsumeList(pp.tokenStream, pp.report, func(d *descriptor.Descriptor) error {
+		values := d.Values
+
+		switch d.Name {
+		case "syntax":
+			if len(values) != 1 || !values[0].IsToken(csslexer.StringToken) {
+				syntaxErr = errors.New("expected a string")
+				return fmt.Errorf("invalid value for descriptor: syntax: %w", syntaxErr)
+			}
+			syntax, err := ParseSyntax(values[0].Token.Value)
+			if err != nil {
+				syntaxErr = err
+				return fmt.Errorf("invalid value for descriptor: syntax: %w", syntaxErr)
+			}
+			rule.Syntax = syntax
+
+		case "inherits":
+			switch {
+			case len(values) == 1 && values[0].IsKeyword("true"):
+				rule.Inherits = true
+			case len(values) == 1 && values[0].IsKeyword("false"):
+				rule.Inherits = false
+			default:
+				return errors.New("invalid value for descriptor: inherits")
+			}
+			hasInherits = true
+
+		case "initial-value":
+			rule.InitialValue = values
+
+		default:
+			return errors.New("unknown descriptor: " + d.Name)
+		}
+
+		return nil
+	}, nil)
+
+	if rule.Syntax == nil {
+		if syntaxErr != nil {
+			return nil, fmt.Errorf("invalid @property rule: invalid syntax descriptor: %w", syntaxErr)
+		}
+		return nil, errors.New("invalid @property rule: missing syntax descriptor")
+	}
+	if !hasInherits {
+		return nil, errors.New("invalid @property rule: missing inherits descriptor")
+	}
+	if rule.InitialValue == nil {
+		if !rule.Syntax.IsUniversal() {
+			return nil, errors.New("invalid @property rule: missing initial-value descriptor")
+		}
+	} else if !IsValidInitialValue(rule.Syntax, rule.InitialValue) {
+		return nil, errors.New("invalid @property rule: initial value does not match the syntax")
+	}
+
+	return rule, nil
+}
@@ -0,0 +1,136 @@
+package property
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumePropertyDescriptors(t *testing.T) {
+	testcases := []struct {
+		name        string
+		input       string
+		expectError bool
+		expected    string
+	}{
+		{
+			name:     "universal syntax without initial value",
+			input:    `syntax: "*"; inherits: true`,
+			expected: `@property --x { syntax: "*"; inherits: true; }`,
+		},
+		{
+			name:     "universal syntax with any initial value",
+			input:    `syntax: '*'; inherits: false; initial-value: var(--y) 1em`,
+			expected: `@property --x { syntax: "*"; inherits: false; initial-value: var(--y) 1em; }`,
+		},
+		{
+			name:     "length",
+			input:    `SYNTAX: "<length>"; inherits: FALSE; initial-value:  0px ;`,
+			expected: `@property --x { syntax: "<length>"; inherits: false; initial-value: 0px; }`,
+		},
+		{name: "unitless zero length", input: `syntax: "<length>"; inherits: false; initial-value: 0`, expected: `@property --x { syntax: "<length>"; inherits: false; initial-value: 0; }`},
+		{name: "unitless length", input: `syntax: "<length>"; inherits: false; initial-value: 10`, expectError: true},
+		{name: "relative length", input: `syntax: "<length>"; inherits: false; initial-value: 2em`, expectError: true},
+		{name: "calc length", input: `syntax: "<length>"; inherits: false; initial-value: calc(1in + 10px)`, expected: `@property --x { syntax: "<length>"; inherits: false; initial-value: calc(1in + 10px); }`},
+		{name: "calc with relative length", input: `syntax: "<length>"; inherits: false; initial-value: calc(1em + 10px)`, expectError: true},
+		{name: "calc with wrong unit", input: `syntax: "<length>"; inherits: false; initial-value: calc(10deg * 2)`, expectError: true},
+		{name: "var reference", input: `syntax: "<length>"; inherits: false; initial-value: var(--y)`, expectError: true},
+		{name: "length-percentage", input: `syntax: "<length-percentage>"; inherits: false; initial-value: 50%`, expected: `@property --x { syntax: "<length-percentage>"; inherits: false; initial-value: 50%; }`},
+		{name: "integer", input: `syntax: "<integer>"; inherits: false; initial-value: 3`, expected: `@property --x { syntax: "<integer>"; inherits: false; initial-value: 3; }`},
+		{name: "non-integer", input: `syntax: "<integer>"; inherits: false; initial-value: 1.5`, expectError: true},
+		{name: "angle", input: `syntax: "<angle>"; inherits: false; initial-value: 0.5turn`, expected: `@property --x { syntax: "<angle>"; inherits: false; initial-value: 0.5turn; }`},
+		{name: "time", input: `syntax: "<time>"; inherits: false; initial-value: 10px`, expectError: true},
+		{name: "resolution", input: `syntax: "<resolution>"; inherits: false; initial-value: 2dppx`, expected: `@property --x { syntax: "<resolution>"; inherits: false; initial-value: 2dppx; }`},
+		{name: "hex color", input: `syntax: "<color>"; inherits: true; initial-value: #ff0000`, expected: `@property --x { syntax: "<color>"; inherits: true; initial-value: #ff0000; }`},
+		{name: "named color", input: `syntax: "<color>"; inherits: true; initial-value: RebeccaPurple`, expected: `@property --x { syntax: "<color>"; inherits: true; initial-value: RebeccaPurple; }`},
+		{name: "color function", input: `syntax: "<color>"; inherits: true; initial-value: rgb(0 0 0 / 50%)`, expected: `@property --x { syntax: "<color>"; inherits: true; initial-value: rgb(0 0 0 / 50%); }`},
+		{name: "4-digit hex color", input: `syntax: "<color>"; inherits: true; initial-value: #f00a`, expected: `@property --x { syntax: "<color>"; inherits: true; initial-value: #f00a; }`},
+		{name: "invalid hex color", input: `syntax: "<color>"; inherits: true; initial-value: #ff000`, expectError: true},
+		{name: "unknown color name", input: `syntax: "<color>"; inherits: true; initial-value: reddish`, expectError: true},
+		{name: "space-separated list", input: `syntax: "<length>+"; inherits: false; initial-value: 1px 2px  3px`, expected: `@property --x { syntax: "<length>+"; inherits: false; initial-value: 1px 2px  3px; }`},
+		{name: "comma in space-separated list", input: `syntax: "<length>+"; inherits: false; initial-value: 1px, 2px`, expectError: true},
+		{name: "comma-separated list", input: `syntax: "<color>#"; inherits: false; initial-value: red, blue`, expected: `@property --x { syntax: "<color>#"; inherits: false; initial-value: red, blue; }`},
+		{name: "empty comma-separated item", input: `syntax: "<color>#"; inherits: false; initial-value: red,, blue`, expectError: true},
+		{name: "multiple values without multiplier", input: `syntax: "<length>"; inherits: false; initial-value: 1px 2px`, expectError: true},
+		{name: "identifier", input: `syntax: "<length> | auto"; inherits: false; initial-value: auto`, expected: `@property --x { syntax: "<length> | auto"; inherits: false; initial-value: auto; }`},
+		{name: "identifier is case-sensitive", input: `syntax: "<length> | auto"; inherits: false; initial-value: AUTO`, expectError: true},
+		{name: "custom-ident", input: `syntax: "<custom-ident>"; inherits: false; initial-value: foo`, expected: `@property --x { syntax: "<custom-ident>"; inherits: false; initial-value: foo; }`},
+		{name: "CSS-wide keyword as custom-ident", input: `syntax: "<custom-ident>"; inherits: false; initial-value: initial`, expectError: true},
+		{name: "string", input: `syntax: "<string>"; inherits: false; initial-value: "hello"`, expected: `@property --x { syntax: "<string>"; inherits: false; initial-value: "hello"; }`},
+		{name: "url", input: `syntax: "<url>"; inherits: false; initial-value: url(a.png)`, expected: `@property --x { syntax: "<url>"; inherits: false; initial-value: url(a.png); }`},
+		{name: "image", input: `syntax: "<image>"; inherits: false; initial-value: linear-gradient(red, blue)`, expected: `@property --x { syntax: "<image>"; inherits: false; initial-value: linear-gradient(red, blue); }`},
+		{name: "transform-list", input: `syntax: "<transform-list>"; inherits: false; initial-value: rotate(45deg) scale(2)`, expected: `@property --x { syntax: "<transform-list>"; inherits: false; initial-value: rotate(45deg) scale(2); }`},
+		{name: "transform-function", input: `syntax: "<transform-function>"; inherits: false; initial-value: rotate(45deg) scale(2)`, expectError: true},
+		{name: "missing syntax", input: `inherits: false; initial-value: 0px`, expectError: true},
+		{name: "invalid syntax", input: `syntax: "<foo>"; inherits: false; initial-value: 0px`, expectError: true},
+		{name: "unquoted syntax", input: `syntax: <length>; inherits: false; initial-value: 0px`, expectError: true},
+		{name: "missing inherits", input: `syntax: "<length>"; initial-value: 0px`, expectError: true},
+		{name: "invalid inherits", input: `syntax: "*"; inherits: yes`, expectError: true},
+		{name: "missing initial value", input: `syntax: "<length>"; inherits: false`, expectError: true},
+		{
+			name:     "last valid descriptor wins",
+			input:    `syntax: "<length>"; syntax: "<foo>"; inherits: true; inherits: maybe; initial-value: 1px; initial-value: ;`,
+			expected: `@property --x { syntax: "<length>"; inherits: true; initial-value: 1px; }`,
+		},
+		{
+			name:     "unknown and important descriptors are ignored",
+			input:    `color: red; syntax: "*"; inherits: true !important; inherits: false; { } foo`,
+			expected: `@property --x { syntax: "*"; inherits: false; }`,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			rule, err := ConsumePropertyDescriptors(ts, "--x", nil)
+
+			if next := ts.Peek(); next.Type != csslexer.EOFToken {
+				t.Errorf("expected EOF, got %v", next.Type)
+			}
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got %q", rule.String())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if rule.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, rule.String())
+			}
+		})
+	}
+}
+
+func Test_ConsumePropertyDescriptors_Errors(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{"missing syntax", `inherits: false`, "invalid @property rule: missing syntax descriptor"},
+		{"unparseable syntax", `syntax: "<length> +"; inherits: false`, "invalid @property rule: invalid syntax descriptor: unterminated data type name"},
+		{"unsupported data type", `syntax: "<foo>"; inherits: false`, "invalid @property rule: invalid syntax descriptor: unsupported data type name: foo"},
+		{"unquoted syntax", `syntax: <length>; inherits: false`, "invalid @property rule: invalid syntax descriptor: expected a string"},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			ts := token_stream.NewTokenStream(csslexer.NewInput(tc.input))
+
+			_, err := ConsumePropertyDescriptors(ts, "--x", nil)
+			if err == nil || err.Error() != tc.expected {
+				t.Errorf("expected error %q, got %v", tc.expected, err)
+			}
+		})
+	}
+}
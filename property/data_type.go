@@ -0,0 +1,240 @@
+package property
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/numeric"
+)
+
+// dataTypeMatchers checks if a single component value matches a supported
+// data type name.
+//
+// The checks are syntactic: a math function is accepted if all of its
+// dimensions have units of the right category, and functional notations
+// such as rgb() are accepted by name without looking at their arguments.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#supported-names
+var dataTypeMatchers = map[string]func(value *css.ComponentValue) bool{
+	"angle": func(value *css.ComponentValue) bool {
+		return matchesNumeric(value, numeric.IsAngleUnit, false, false)
+	},
+	"color": isColor,
+	"custom-ident": func(value *css.ComponentValue) bool {
+		return value.IsToken(csslexer.IdentToken) && !css.IsReservedCustomIdent(value.Token.Value)
+	},
+	"image": isImage,
+	"integer": func(value *css.ComponentValue) bool {
+		if value.IsToken(csslexer.NumberToken) {
+			_, ok := numeric.ParseInteger(value.Token)
+			return ok
+		}
+		return isMathFunction(value) && mathFunctionMatches(value, nil, false)
+	},
+	"length": func(value *css.ComponentValue) bool {
+		return matchesNumeric(value, numeric.IsLengthUnit, false, true)
+	},
+	"length-percentage": func(value *css.ComponentValue) bool {
+		return matchesNumeric(value, numeric.IsLengthUnit, true, true)
+	},
+	"number": func(value *css.ComponentValue) bool {
+		return matchesNumeric(value, nil, false, true)
+	},
+	"percentage": func(value *css.ComponentValue) bool {
+		return matchesNumeric(value, nil, true, false)
+	},
+	"resolution": func(value *css.ComponentValue) bool {
+		return matchesNumeric(value, numeric.IsResolutionUnit, false, false)
+	},
+	"string": func(value *css.ComponentValue) bool {
+		return value.IsToken(csslexer.StringToken)
+	},
+	"time": func(value *css.ComponentValue) bool {
+		return matchesNumeric(value, numeric.IsTimeUnit, false, false)
+	},
+	"transform-function": isTransformFunction,
+	"url":                isURL,
+}
+
+// matchesNumeric checks if a component value is a dimension whose unit is
+// accepted by isUnit, a percentage if allowPercentage is set, or a number
+// if allowNumber is set. Math functions are accepted if all of their
+// operands match.
+//
+// When isUnit is set but numbers are not allowed, a plain 0 is still
+// accepted for lengths, as in "margin: 0".
+func matchesNumeric(value *css.ComponentValue, isUnit func(string) bool, allowPercentage, allowNumber bool) bool {
+	if isMathFunction(value) {
+		return mathFunctionMatches(value, isUnit, allowPercentage)
+	}
+
+	if value.Type != css.ComponentValueTypePreservedToken {
+		return false
+	}
+
+	switch value.Token.Type {
+	case csslexer.NumberToken:
+		if allowNumber && isUnit == nil {
+			return true
+		}
+		if allowNumber {
+			// Only a zero can be used as a length without a unit
+			n, ok := numeric.ParseNumber(value.Token)
+			return ok && n == 0
+		}
+		return false
+
+	case csslexer.PercentageToken:
+		return allowPercentage
+
+	case csslexer.DimensionToken:
+		_, unit, ok := numeric.ParseDimension(value.Token)
+		return ok && isUnit != nil && isUnit(unit)
+
+	default:
+		return false
+	}
+}
+
+// isMathFunction checks if a component value is a math function.
+//
+// https://www.w3.org/TR/css-values-4/#math
+func isMathFunction(value *css.ComponentValue) bool {
+	switch functionName(value) {
+	case "calc", "min", "max", "clamp", "round", "mod", "rem",
+		"sin", "cos", "tan", "asin", "acos", "atan", "atan2",
+		"pow", "sqrt", "hypot", "log", "exp", "abs", "sign":
+		return true
+	default:
+		return false
+	}
+}
+
+// mathFunctionMatches checks if all the dimensions and percentages inside
+// a math function are accepted. Numbers and keywords such as pi are always
+// accepted, as they can be combined with any type.
+func mathFunctionMatches(value *css.ComponentValue, isUnit func(string) bool, allowPercentage bool) bool {
+	for _, child := range value.Children {
+		switch child.Type {
+		case css.ComponentValueTypePreservedToken:
+			switch child.Token.Type {
+			case csslexer.PercentageToken:
+				if !allowPercentage {
+					return false
+				}
+			case csslexer.DimensionToken:
+				_, unit, ok := numeric.ParseDimension(child.Token)
+				if !ok || isUnit == nil || !isUnit(unit) {
+					return false
+				}
+			}
+
+		case css.ComponentValueTypeSimpleBlock:
+			if child.Token.Type != csslexer.LeftParenthesisToken ||
+				!mathFunctionMatches(child, isUnit, allowPercentage) {
+				return false
+			}
+
+		case css.ComponentValueTypeFunction:
+			if !isMathFunction(child) || !mathFunctionMatches(child, isUnit, allowPercentage) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// isColor checks if a component value is a <color>.
+//
+// https://www.w3.org/TR/css-color-4/#color-syntax
+func isColor(value *css.ComponentValue) bool {
+	switch value.Type {
+	case css.ComponentValueTypePreservedToken:
+		switch value.Token.Type {
+		case csslexer.HashToken:
+			return isHexColor(value.Token.Value)
+		case csslexer.IdentToken:
+			name := strings.ToLower(value.Token.Value)
+			return name == "transparent" || name == "currentcolor" || namedColors[name]
+		default:
+			return false
+		}
+
+	case css.ComponentValueTypeFunction:
+		switch functionName(value) {
+		case "rgb", "rgba", "hsl", "hsla", "hwb", "lab", "lch", "oklab", "oklch",
+			"color", "color-mix", "light-dark":
+			return true
+		default:
+			return false
+		}
+
+	default:
+		return false
+	}
+}
+
+// isHexColor checks if the value of a hash token is a hex color, i.e. 3,
+// 4, 6 or 8 hexadecimal digits.
+func isHexColor(s string) bool {
+	switch len(s) {
+	case 3, 4, 6, 8:
+	default:
+		return false
+	}
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isImage checks if a component value is an <image>.
+//
+// https://www.w3.org/TR/css-images-4/#image-values
+func isImage(value *css.ComponentValue) bool {
+	if isURL(value) {
+		return true
+	}
+
+	switch functionName(value) {
+	case "linear-gradient", "radial-gradient", "conic-gradient",
+		"repeating-linear-gradient", "repeating-radial-gradient", "repeating-conic-gradient",
+		"image", "image-set", "cross-fade", "element", "paint":
+		return true
+	default:
+		return false
+	}
+}
+
+// isURL checks if a component value is a <url>.
+//
+// https://www.w3.org/TR/css-values-4/#urls
+func isURL(value *css.ComponentValue) bool {
+	return value.IsToken(csslexer.UrlToken) || functionName(value) == "url"
+}
+
+// isTransformFunction checks if a component value is a
+// <transform-function>.
+//
+// https://www.w3.org/TR/css-transforms-2/#transform-functions
+func isTransformFunction(value *css.ComponentValue) bool {
+	switch functionName(value) {
+	case "matrix", "matrix3d",
+		"translate", "translatex", "translatey", "translatez", "translate3d",
+		"scale", "scalex", "scaley", "scalez", "scale3d",
+		"rotate", "rotatex", "rotatey", "rotatez", "rotate3d",
+		"skew", "skewx", "skewy",
+		"perspective":
+		return true
+	default:
+		return false
+	}
+}
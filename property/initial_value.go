@@ -0,0 +1,130 @@
+package property
+
+import (
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/numeric"
+)
+
+// IsValidInitialValue checks if a value can be the initial value of a
+// custom property registered with the given syntax, i.e. if it matches the
+// syntax and is computationally independent.
+//
+// Any value is valid for the universal syntax.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#initial-value-descriptor
+func IsValidInitialValue(syntax *css.PropertySyntax, values []*css.ComponentValue) bool {
+	if syntax.IsUniversal() {
+		return true
+	}
+
+	return isComputationallyIndependent(values) && MatchesSyntax(syntax, values)
+}
+
+// MatchesSyntax checks if a value matches one of the components of a
+// syntax definition. The universal syntax matches any value.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#calculation-of-computed-values
+func MatchesSyntax(syntax *css.PropertySyntax, values []*css.ComponentValue) bool {
+	if syntax.IsUniversal() {
+		return true
+	}
+
+	values = css.TrimComponentValueList(values)
+	if len(values) == 0 {
+		return false
+	}
+
+	for _, component := range syntax.Components {
+		if matchesSyntaxComponent(component, values) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesSyntaxComponent checks if a value matches a single syntax
+// component, taking its multiplier into account.
+func matchesSyntaxComponent(component *css.PropertySyntaxComponent, values []*css.ComponentValue) bool {
+	if component.Type == css.PropertySyntaxComponentDataType && component.Name == "transform-list" {
+		// <transform-list> is equivalent to <transform-function>+
+		component = &css.PropertySyntaxComponent{
+			Type:       css.PropertySyntaxComponentDataType,
+			Name:       "transform-function",
+			Multiplier: css.PropertySyntaxMultiplierSpace,
+		}
+	}
+
+	switch component.Multiplier {
+	case css.PropertySyntaxMultiplierSpace:
+		for _, value := range values {
+			if value.IsToken(csslexer.CommaToken) {
+				return false
+			}
+		}
+		for _, value := range css.StripComponentValueListWhitespace(values) {
+			if !matchesSingleValue(component, value) {
+				return false
+			}
+		}
+		return true
+
+	case css.PropertySyntaxMultiplierComma:
+		for _, item := range css.SplitComponentValueList(values) {
+			if len(item) != 1 || !matchesSingleValue(component, item[0]) {
+				return false
+			}
+		}
+		return true
+
+	default:
+		return len(values) == 1 && matchesSingleValue(component, values[0])
+	}
+}
+
+// matchesSingleValue checks if a single component value matches a syntax
+// component, ignoring its multiplier.
+func matchesSingleValue(component *css.PropertySyntaxComponent, value *css.ComponentValue) bool {
+	if component.Type == css.PropertySyntaxComponentIdent {
+		// Identifiers in a syntax string are matched case-sensitively
+		return value.IsToken(csslexer.IdentToken) && value.Token.Value == component.Name
+	}
+
+	match, ok := dataTypeMatchers[component.Name]
+	return ok && match(value)
+}
+
+// isComputationallyIndependent checks if a value can be computed without
+// knowing anything about the element it applies to, i.e. if it contains no
+// relative lengths and no var(), env() or attr() references.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#computationally-independent
+func isComputationallyIndependent(values []*css.ComponentValue) bool {
+	for _, value := range values {
+		switch value.Type {
+		case css.ComponentValueTypePreservedToken:
+			if _, unit, ok := numeric.ParseDimension(value.Token); ok &&
+				numeric.IsLengthUnit(unit) && !numeric.IsAbsoluteLengthUnit(unit) {
+				return false
+			}
+
+		case css.ComponentValueTypeFunction:
+			switch functionName(value) {
+			case "var", "env", "attr":
+				return false
+			}
+			if !isComputationallyIndependent(value.Children) {
+				return false
+			}
+
+		case css.ComponentValueTypeSimpleBlock:
+			if !isComputationallyIndependent(value.Children) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,35 @@
+package property
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/descriptor"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+type PropertyParser struct {
+	tokenStream *token_stream.TokenStream
+	report      descriptor.ReportFunc
+}
+
+func NewPropertyParser(tokenStream *token_stream.TokenStream, report descriptor.ReportFunc) *PropertyParser {
+	return &PropertyParser{
+		tokenStream: tokenStream,
+		report:      report,
+	}
+}
+
+// ConsumePropertyDescriptors consumes the contents of a @property block
+// until the end of the token stream, and returns the rule registering the
+// custom property with the given name. Invalid and unknown descriptors are
+// dropped, and passed to report if it is not nil.
+//
+// An error is returned if the rule is invalid, i.e. if the syntax or the
+// inherits descriptor is missing, or if the initial value does not match
+// the syntax.
+func ConsumePropertyDescriptors(
+	tokenStream *token_stream.TokenStream,
+	name string,
+	report descriptor.ReportFunc,
+) (*css.PropertyRule, error) {
+	return NewPropertyParser(tokenStream, report).consumeDescriptorList(name)
+}
@@ -0,0 +1,142 @@
+package property
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// supportedDataTypes are the data type names which can be used in a syntax
+// string.
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#supported-names
+var supportedDataTypes = map[string]bool{
+	"angle":              true,
+	"color":              true,
+	"custom-ident":       true,
+	"image":              true,
+	"integer":            true,
+	"length":             true,
+	"length-percentage":  true,
+	"number":             true,
+	"percentage":         true,
+	"resolution":         true,
+	"string":             true,
+	"time":               true,
+	"transform-function": true,
+	"transform-list":     true,
+	"url":                true,
+}
+
+// ParseSyntax parses the value of the syntax descriptor of a @property
+// rule, e.g. "<length> | auto" or "<color>#".
+//
+// https://www.w3.org/TR/css-properties-values-api-1/#parsing-syntax
+func ParseSyntax(s string) (*css.PropertySyntax, error) {
+	s = strings.Trim(s, " \t\n\r\f")
+
+	if s == "" {
+		return nil, errors.New("empty syntax string")
+	}
+
+	if s == "*" {
+		return &css.PropertySyntax{}, nil
+	}
+
+	syntax := &css.PropertySyntax{}
+	for _, part := range strings.Split(s, "|") {
+		component, err := parseSyntaxComponent(strings.Trim(part, " \t\n\r\f"))
+		if err != nil {
+			return nil, err
+		}
+		syntax.Components = append(syntax.Components, component)
+	}
+
+	return syntax, nil
+}
+
+// parseSyntaxComponent parses a single syntax component, i.e. a data type
+// name or an identifier, optionally followed by a multiplier.
+func parseSyntaxComponent(s string) (*css.PropertySyntaxComponent, error) {
+	if s == "" {
+		return nil, errors.New("empty syntax component")
+	}
+
+	component := &css.PropertySyntaxComponent{}
+
+	switch s[len(s)-1] {
+	case '+':
+		component.Multiplier = css.PropertySyntaxMultiplierSpace
+		s = s[:len(s)-1]
+	case '#':
+		component.Multiplier = css.PropertySyntaxMultiplierComma
+		s = s[:len(s)-1]
+	}
+
+	if strings.HasPrefix(s, "<") {
+		if !strings.HasSuffix(s, ">") {
+			return nil, errors.New("unterminated data type name")
+		}
+
+		name := s[1 : len(s)-1]
+		if !supportedDataTypes[name] {
+			return nil, errors.New("unsupported data type name: " + name)
+		}
+		if name == "transform-list" && component.Multiplier != css.PropertySyntaxMultiplierNone {
+			// <transform-list> is already a list, so it can't be multiplied
+			return nil, errors.New("<transform-list> can't be followed by a multiplier")
+		}
+
+		component.Type = css.PropertySyntaxComponentDataType
+		component.Name = name
+		return component, nil
+	}
+
+	if !isIdentifier(s) || css.IsReservedCustomIdent(s) {
+		return nil, errors.New("invalid syntax component: " + s)
+	}
+
+	component.Type = css.PropertySyntaxComponentIdent
+	component.Name = s
+	return component, nil
+}
+
+// isIdentifier checks if a string is a valid CSS identifier without
+// escapes.
+//
+// https://www.w3.org/TR/css-syntax-3/#would-start-an-identifier
+func isIdentifier(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	rest := s
+	if rest[0] == '-' {
+		rest = rest[1:]
+		if rest == "" {
+			return false
+		}
+		if rest[0] == '-' {
+			rest = rest[1:]
+		} else if !isNameStartCodePoint(rest[0]) {
+			return false
+		}
+	} else if !isNameStartCodePoint(rest[0]) {
+		return false
+	}
+
+	for i := 0; i < len(rest); i++ {
+		if !isNameStartCodePoint(rest[i]) && rest[i] != '-' && (rest[i] < '0' || rest[i] > '9') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// isNameStartCodePoint checks if a byte can start a name. Bytes of
+// non-ASCII code points are always accepted.
+func isNameStartCodePoint(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_' || c >= 0x80
+}
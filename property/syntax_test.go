@@ -0,0 +1,56 @@
+package property
+
+import (
+	"testing"
+)
+
+func Test_ParseSyntax(t *testing.T) {
+	testcases := []struct {
+		name        string
+		input       string
+		expectError bool
+		expected    string
+	}{
+		{name: "universal", input: " * ", expected: "*"},
+		{name: "data type", input: "<length>", expected: "<length>"},
+		{name: "multipliers", input: "<length>+|<color>#", expected: "<length>+ | <color>#"},
+		{name: "identifiers", input: "auto | <percentage> | -moz-thing", expected: "auto | <percentage> | -moz-thing"},
+		{name: "custom-ident list", input: "<custom-ident>+", expected: "<custom-ident>+"},
+		{name: "transform-list", input: "<transform-list>", expected: "<transform-list>"},
+		{name: "empty", input: "  ", expectError: true},
+		{name: "empty component", input: "<length> |", expectError: true},
+		{name: "unknown data type", input: "<foo>", expectError: true},
+		{name: "data type is case-sensitive", input: "<Length>", expectError: true},
+		{name: "unterminated data type", input: "<length", expectError: true},
+		{name: "space inside data type", input: "< length >", expectError: true},
+		{name: "multiplied transform-list", input: "<transform-list>+", expectError: true},
+		{name: "double multiplier", input: "<length>++", expectError: true},
+		{name: "CSS-wide keyword", input: "<length> | inherit", expectError: true},
+		{name: "default keyword", input: "DEFAULT", expectError: true},
+		{name: "component juxtaposition", input: "<length> <color>", expectError: true},
+		{name: "universal in a list", input: "* | <length>", expectError: true},
+		{name: "invalid identifier", input: "1px", expectError: true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			syntax, err := ParseSyntax(tc.input)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got %q", syntax.String())
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if syntax.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, syntax.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,16 @@
+package property
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// functionName returns the lowercased name of a function, or an empty
+// string if the component value is not a function.
+func functionName(value *css.ComponentValue) string {
+	if value.Type != css.ComponentValueTypeFunction {
+		return ""
+	}
+	return strings.ToLower(value.Token.Value)
+}
@@ -0,0 +1,93 @@
+package selector
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// consumePageSelectorList consumes a list of page selectors.
+//
+// https://www.w3.org/TR/css-page-3/#typedef-page-selector-list
+func (sp *SelectorParser) consumePageSelectorList() ([]*css.Selector, error) {
+	var selectors []*css.Selector
+
+	if sp.tokenStream.AtEnd() || sp.tokenStream.Peek().Type == csslexer.LeftBraceToken {
+		return selectors, nil
+	}
+
+	for {
+		selector, err := sp.consumePageSelector()
+		if err != nil {
+			return nil, err
+		}
+		selectors = append(selectors, selector)
+
+		sp.tokenStream.ConsumeWhitespace()
+		if sp.tokenStream.AtEnd() || sp.tokenStream.Peek().Type == csslexer.LeftBraceToken {
+			return selectors, nil
+		}
+
+		if sp.tokenStream.Peek().Type != csslexer.CommaToken {
+			return nil, errors.New("invalid page selector: expected ',' or '{'")
+		}
+		sp.tokenStream.ConsumeIncludingWhitespace()
+	}
+}
+
+// consumePageSelector consumes a single page selector, i.e. an optional
+// page type name followed by page pseudo-classes, without whitespace in
+// between. The page type name is stored as a type selector.
+//
+// https://www.w3.org/TR/css-page-3/#typedef-page-selector
+func (sp *SelectorParser) consumePageSelector() (*css.Selector, error) {
+	selector := &css.Selector{}
+	start := sp.tokenStream.PeekSpan().Start
+
+	if token := sp.tokenStream.Peek(); token.Type == csslexer.IdentToken {
+		sp.tokenStream.Consume()
+		simple := &css.SimpleSelector{
+			Match:    css.SelectorMatchTag,
+			Relation: css.SelectorRelationSubSelector,
+			Data:     css.NewSelectorDataTag("", token.Value),
+		}
+		simple.Span = css.Span{Start: start, End: sp.tokenStream.Position()}
+		selector.Append(simple)
+	}
+
+	for sp.tokenStream.Peek().Type == csslexer.ColonToken {
+		pseudoStart := sp.tokenStream.PeekSpan().Start
+		sp.tokenStream.Consume()
+
+		token := sp.tokenStream.Peek()
+		if token.Type != csslexer.IdentToken {
+			return nil, errors.New("invalid page selector: expected page pseudo-class name")
+		}
+
+		name := strings.ToLower(token.Value)
+		pseudoType, ok := pagePseudoTypeMap[name]
+		if !ok {
+			return nil, errors.New("invalid page selector: unknown page pseudo-class")
+		}
+		sp.tokenStream.Consume()
+
+		simple := &css.SimpleSelector{
+			Match:    css.SelectorMatchPagePseudoClass,
+			Relation: css.SelectorRelationSubSelector,
+			Data:     css.NewSelectorDataPseudo(name, pseudoType),
+		}
+		simple.Span = css.Span{Start: pseudoStart, End: sp.tokenStream.Position()}
+		selector.Append(simple)
+	}
+
+	if len(selector.Selectors) == 0 {
+		return nil, errors.New("invalid page selector: expected page name or pseudo-class")
+	}
+
+	setSelectorSpan(selector, start)
+
+	return selector, nil
+}
@@ -0,0 +1,98 @@
+package selector
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumePageSelectorList(t *testing.T) {
+	testcases := []struct {
+		name              string
+		input             string
+		expectedError     bool
+		expectedSelectors []*css.Selector
+	}{
+		{
+			name:              "empty",
+			input:             "{ }",
+			expectedSelectors: []*css.Selector{},
+		},
+		{
+			name:  "page name",
+			input: "chapter {",
+			expectedSelectors: []*css.Selector{
+				{Selectors: []*css.SimpleSelector{
+					{Match: css.SelectorMatchTag, Data: css.NewSelectorDataTag("", "chapter"), Relation: css.SelectorRelationSubSelector},
+				}},
+			},
+		},
+		{
+			name:  "pseudo-classes",
+			input: ":FIRST:left, toc:right",
+			expectedSelectors: []*css.Selector{
+				{Selectors: []*css.SimpleSelector{
+					{Match: css.SelectorMatchPagePseudoClass, Data: css.NewSelectorDataPseudo("first", css.SelectorPseudoFirstPage), Relation: css.SelectorRelationSubSelector},
+					{Match: css.SelectorMatchPagePseudoClass, Data: css.NewSelectorDataPseudo("left", css.SelectorPseudoLeftPage), Relation: css.SelectorRelationSubSelector},
+				}},
+				{Selectors: []*css.SimpleSelector{
+					{Match: css.SelectorMatchTag, Data: css.NewSelectorDataTag("", "toc"), Relation: css.SelectorRelationSubSelector},
+					{Match: css.SelectorMatchPagePseudoClass, Data: css.NewSelectorDataPseudo("right", css.SelectorPseudoRightPage), Relation: css.SelectorRelationSubSelector},
+				}},
+			},
+		},
+		{
+			name:          "unknown pseudo-class",
+			input:         ":hover {",
+			expectedError: true,
+		},
+		{
+			name:          "whitespace before pseudo-class",
+			input:         "chapter :first {",
+			expectedError: true,
+		},
+		{
+			name:          "trailing comma",
+			input:         "chapter, {",
+			expectedError: true,
+		},
+		{
+			name:          "class selector",
+			input:         ".chapter {",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			selectors, err := ConsumePageSelectorList(ts)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(selectors) != len(tc.expectedSelectors) {
+				t.Fatalf("expected %d selectors, got %d", len(tc.expectedSelectors), len(selectors))
+			}
+
+			for i, sel := range selectors {
+				if !sel.Equals(tc.expectedSelectors[i]) {
+					t.Errorf("selector %d: expected %q, got %q", i, tc.expectedSelectors[i], sel)
+				}
+			}
+		})
+	}
+}
@@ -13,11 +13,18 @@ func (sp *SelectorParser) consumeComplexSelector(
 	nestingType nesting.NestingTypeType,
 	firstInComplexSelector bool,
 ) (*css.Selector, error) {
+	start := sp.tokenStream.PeekSpan().Start
+
 	if nestingType != nesting.NestingTypeNone && sp.peekIsCombinator() {
 		// Nested selectors that start with a combinator are to be
 		// interpreted as relative selectors (with the anchor being
 		// the parent selector, i.e., &).
-		return sp.consumeNestedRelativeSelector(nestingType)
+		sel, err := sp.consumeNestedRelativeSelector(nestingType)
+		if err != nil {
+			return nil, err
+		}
+		setSelectorSpan(sel, start)
+		return sel, nil
 	}
 
 	sel := &css.Selector{}
@@ -44,6 +51,8 @@ func (sp *SelectorParser) consumeComplexSelector(
 	// if nestingType != nesting.NestingTypeNone {
 	// }
 
+	setSelectorSpan(sel, start)
+
 	return sel, nil
 }
 
@@ -88,15 +97,42 @@ func (sp *SelectorParser) consumeCompoundSelector(nestingType nesting.NestingTyp
 	// be ignored (like if we have a universal selector and don't need it;
 	// e.g. *:hover is the same as :hover). Thus, we just keep its data around
 	// and prepend it if needed.
-	name, namespace, hasQName := sp.consumeName()
+	tss := sp.tokenStream.State()
+	nameStart := sp.tokenStream.PeekSpan().Start
+	qname, hasQName := sp.consumeName()
+
+	var tagSpan css.Span
+	if hasQName {
+		tagSpan = css.Span{Start: nameStart, End: sp.tokenStream.Position()}
+	}
+
+	var tag *css.SelectorDataTag
+	if hasQName {
+		namespaceURI, ok := sp.resolveNamespace(qname, true)
+		if !ok {
+			// A selector with an undeclared namespace prefix is invalid.
+			tss.Restore()
+			return nil, 0
+		}
+
+		tag = css.NewSelectorDataTag(qname.namespace, qname.name)
+		tag.NamespaceURI = namespaceURI
+	} else if namespaceURI, ok := sp.namespaces[""]; ok {
+		// A declared default namespace applies to compound selectors
+		// without a type selector, as if "*" was written.
+		tag = css.NewSelectorDataTag("", "")
+		tag.NamespaceURI = namespaceURI
+	}
 
 	// TODO: A tag name is not valid following a pseudo-element.
 
 	for {
+		start := sp.tokenStream.PeekSpan().Start
 		selector, selectorFlags, err := sp.consumeSimpleSelector()
 		if err != nil {
 			break
 		}
+		selector.Span = css.Span{Start: start, End: sp.tokenStream.Position()}
 
 		// TODO: handle pseudo-elements
 
@@ -105,87 +141,170 @@ func (sp *SelectorParser) consumeCompoundSelector(nestingType nesting.NestingTyp
 		selectors = append(selectors, selector)
 	}
 
-	selectors = prependTypeSelectorIfNeeded(selectors, name, namespace, hasQName)
+	if !hasQName && len(selectors) == 0 {
+		// Nothing was consumed, the implicit universal selector of the
+		// default namespace must not stand on its own.
+		return selectors, flags
+	}
+
+	selectors = prependTypeSelectorIfNeeded(selectors, tag, tagSpan)
 
 	return selectors, flags
 }
 
+// qualifiedName is a name with an optional namespace prefix, as consumed by
+// consumeName.
+//
+// https://www.w3.org/TR/selectors-4/#typedef-wq-name
+type qualifiedName struct {
+	name         string // The local name, empty for the universal selector
+	namespace    string // The namespace prefix, "*" for any namespace
+	hasNamespace bool   // Whether a namespace prefix was written, e.g. "|div" or "ns|div"
+}
+
 // consumeName consumes a name token and returns the name and its namespace if applicable.
 //
 // Returns:
-//   - The name as a string.
-//   - The namespace as a string (empty if not applicable).
+//   - The qualified name, with an empty name for the universal selector.
 //   - Whether the name was successfully consumed.
-func (sp *SelectorParser) consumeName() (string, string, bool) {
-	var name, namespace string
+func (sp *SelectorParser) consumeName() (qualifiedName, bool) {
+	var qname qualifiedName
 
 	first := sp.tokenStream.Peek()
 	switch first.Type {
 	case csslexer.IdentToken:
-		name = first.Value
+		qname.name = first.Value
 		sp.tokenStream.Consume()
 
 	case csslexer.DelimiterToken:
 		switch first.Value {
 		case "*":
-			name = "" // Universal selector, no name
+			qname.name = "*" // Universal selector, or the any namespace prefix
 			sp.tokenStream.Consume()
 
 		case "|":
 			// This is an empty namespace, no name.
-			name = ""
 
 		default:
-			return "", "", false // Invalid name
+			return qualifiedName{}, false // Invalid name
 		}
 
 	default:
-		return "", "", false // Invalid name
+		return qualifiedName{}, false // Invalid name
 	}
 
 	second := sp.tokenStream.Peek()
 	if second.Type != csslexer.DelimiterToken || second.Value != "|" {
 		// No namespace, just a name.
-		return name, "", true
+		if qname.name == "*" {
+			qname.name = ""
+		}
+		return qname, true
 	}
 
 	tss := sp.tokenStream.State()
 	sp.tokenStream.Consume() // Consume the '|'
 
-	if name == "" {
-		namespace = "*" // Universal selector with namespace
-	} else {
-		namespace = name // Use the name as the namespace
-		name = ""        // Reset name to indicate that we are now looking for a namespace
-	}
+	// What was consumed so far is the namespace prefix, now look for the name.
+	qname.namespace = qname.name
+	qname.name = ""
+	qname.hasNamespace = true
 
 	third := sp.tokenStream.Peek()
 	switch third.Type {
 	case csslexer.IdentToken:
-		name = third.Value
+		qname.name = third.Value
 		sp.tokenStream.Consume()
 
 	case csslexer.DelimiterToken:
 		if third.Value == "*" {
-			name = "" // Universal selector, no name
+			// Universal selector, no name
 			sp.tokenStream.Consume()
 		} else {
 			// Invalid name after namespace delimiter
 			tss.Restore()
-			return "", "", false
+			return qualifiedName{}, false
 		}
 
 	default:
 		// Invalid token after namespace delimiter
 		tss.Restore()
-		return "", "", false
+		return qualifiedName{}, false
 	}
 
-	return name, namespace, true
+	return qname, true
 }
 
+// resolveNamespace returns the namespace URI of a qualified name, using the
+// namespaces declared by @namespace rules. Names without a namespace prefix
+// are in the default namespace if they are type selectors, and in no
+// namespace if they are attribute names.
+//
+// Returns false if the namespace prefix was not declared.
+//
+// https://www.w3.org/TR/selectors-4/#type-nmsp
+func (sp *SelectorParser) resolveNamespace(qname qualifiedName, isTypeSelector bool) (string, bool) {
+	if !qname.hasNamespace {
+		if !isTypeSelector {
+			return "", true
+		}
+
+		if namespaceURI, ok := sp.namespaces[""]; ok {
+			return namespaceURI, true
+		}
+
+		return css.AnyNamespace, true
+	}
+
+	switch qname.namespace {
+	case "*":
+		return css.AnyNamespace, true
+	case "":
+		return "", true
+	}
+
+	if sp.namespaces == nil {
+		// Without a style sheet to declare them, e.g. when parsing a single
+		// selector, every prefix is accepted and matches any namespace.
+		return css.AnyNamespace, true
+	}
+
+	namespaceURI, ok := sp.namespaces[qname.namespace]
+	return namespaceURI, ok
+}
+
+// consumeNestedRelativeSelector consumes a nested selector starting with a
+// combinator, e.g. "> .child". Such a selector is relative to the nesting
+// anchor, which is made explicit: '&' in style rules and ':scope' in @scope
+// rules, e.g. "& > .child".
+//
+// https://www.w3.org/TR/css-nesting-1/#syntax
 func (sp *SelectorParser) consumeNestedRelativeSelector(nestingType nesting.NestingTypeType) (*css.Selector, error) {
-	return nil, errors.New("not implemented: SelectorParser.consumeNestedRelativeSelector")
+	anchor := &css.SimpleSelector{
+		Match:    css.SelectorMatchPseudoClass,
+		Relation: css.SelectorRelationSubSelector,
+	}
+	if nestingType == nesting.NestingTypeScope {
+		anchor.Data = css.NewSelectorDataPseudo("scope", css.SelectorPseudoScope)
+	} else {
+		anchor.Data = css.NewSelectorDataPseudo("parent", css.SelectorPseudoParent)
+	}
+
+	combinator := sp.consumeCombinator()
+	rest, flags, err := sp.consumePartialComplexSelector(nestingType, combinator)
+	if err != nil {
+		return nil, err
+	}
+
+	sel := &css.Selector{}
+	sel.Flag.Set(css.SelectorFlagContainsPseudo)
+	sel.Flag.Set(css.SelectorFlagContainsScopeOrParent)
+	sel.Flag.Set(css.SelectorFlagContainsComplexSelector)
+	sel.Flag.Set(flags)
+	sel.Append(anchor)
+	sel.Append(rest...)
+
+	return sel, nil
 }
 
 func (sp *SelectorParser) consumeCombinator() css.SelectorRelationType {
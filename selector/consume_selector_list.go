@@ -14,7 +14,7 @@ func (sp *SelectorParser) consumeComplexSelectorList(nestingType nesting.Nesting
 	firstInComplexSelectorList := true
 
 	for {
-		sel, err := sp.consumeComplexSelector(nesting.NestingTypeNone, firstInComplexSelectorList)
+		sel, err := sp.consumeComplexSelector(nestingType, firstInComplexSelectorList)
 		if err != nil || !sp.atEndOfSelector() {
 			sp.tokenStream.SkipUntil(csslexer.LeftBraceToken, csslexer.CommaToken)
 
@@ -69,6 +69,7 @@ func (sp *SelectorParser) consumeCompoundSelectorList() ([]*css.Selector, error)
 
 // consumeCompoundSelectorAsComplexSelector wraps a compound selector as a complex selector
 func (sp *SelectorParser) consumeCompoundSelectorAsComplexSelector() (*css.Selector, error) {
+	start := sp.tokenStream.PeekSpan().Start
 	compoundSelectors, flags := sp.consumeCompoundSelector(nesting.NestingTypeNone)
 	if len(compoundSelectors) == 0 {
 		return nil, errors.New("expected compound selector")
@@ -77,6 +78,7 @@ func (sp *SelectorParser) consumeCompoundSelectorAsComplexSelector() (*css.Selec
 	sel := &css.Selector{}
 	sel.Flag.Set(flags)
 	sel.Append(compoundSelectors...)
+	setSelectorSpan(sel, start)
 
 	return sel, nil
 }
@@ -139,6 +141,7 @@ func (sp *SelectorParser) consumeRelativeSelectorList() ([]*css.Selector, error)
 
 // consumeRelativeSelector parses a single relative selector
 func (sp *SelectorParser) consumeRelativeSelector() (*css.Selector, error) {
+	start := sp.tokenStream.PeekSpan().Start
 	sel := &css.Selector{}
 
 	// Create implicit relative anchor
@@ -159,6 +162,7 @@ func (sp *SelectorParser) consumeRelativeSelector() (*css.Selector, error) {
 
 	sel.Flag.Set(flags)
 	sel.Append(rest...)
+	setSelectorSpan(sel, start)
 
 	return sel, nil
 }
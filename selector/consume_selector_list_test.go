@@ -1,6 +1,7 @@
 package selector
 
 import (
+	"strings"
 	"testing"
 
 	"go.baoshuo.dev/csslexer"
@@ -250,7 +251,7 @@ func Test_SelectorParser_ConsumeComplexSelectorList(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			selectors, err := sp.consumeComplexSelectorList(tc.nestingType)
 
@@ -342,7 +343,7 @@ func Test_ConsumeSelector(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
 
-			selectors, err := ConsumeSelector(ts, tc.nestingType, nil)
+			selectors, err := ConsumeSelector(ts, tc.nestingType, nil, nil, false)
 
 			if tc.expectedError {
 				if err == nil {
@@ -368,3 +369,160 @@ func Test_ConsumeSelector(t *testing.T) {
 		})
 	}
 }
+
+func Test_ConsumeSelector_Namespaces(t *testing.T) {
+	namespaces := css.NamespaceMap{
+		"":    "http://www.w3.org/1999/xhtml",
+		"svg": "http://www.w3.org/2000/svg",
+	}
+
+	testcases := []struct {
+		name          string
+		input         string
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:     "declared prefix",
+			input:    "svg|rect",
+			expected: "svg|rect",
+		},
+		{
+			name:     "declared prefix on universal selector",
+			input:    "svg|*.icon",
+			expected: "svg|*.icon",
+		},
+		{
+			name:     "declared prefix on attribute",
+			input:    "[svg|href]",
+			expected: "[svg|href]",
+		},
+		{
+			name:          "undeclared prefix",
+			input:         "math|mi",
+			expectedError: true,
+		},
+		{
+			name:          "undeclared prefix after combinator",
+			input:         "div > math|mi",
+			expectedError: true,
+		},
+		{
+			name:          "undeclared prefix on attribute",
+			input:         "[xlink|href]",
+			expectedError: true,
+		},
+		{
+			name:          "undeclared prefix in :not()",
+			input:         ":not(math|mi)",
+			expectedError: true,
+		},
+		{
+			name:     "any and no namespace",
+			input:    "*|rect, |rect",
+			expected: "*|rect, |rect",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(in)
+
+			selectors, err := ConsumeSelector(ts, nesting.NestingTypeNone, nil, namespaces, false)
+
+			if !tc.expectedError && err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			if tc.expectedError {
+				if err == nil && ts.AtEnd() {
+					t.Errorf("expected error but got %v", selectors)
+				}
+				return
+			}
+
+			strs := make([]string, 0, len(selectors))
+			for _, sel := range selectors {
+				strs = append(strs, sel.String())
+			}
+			if result := strings.Join(strs, ", "); result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func Test_ConsumeSelector_NestedRelative(t *testing.T) {
+	testcases := []struct {
+		name          string
+		input         string
+		nestingType   nesting.NestingTypeType
+		expected      string
+		expectedError bool
+	}{
+		{
+			name:        "child combinator in style rule",
+			input:       "> .child",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    "& > .child",
+		},
+		{
+			name:        "sibling combinators in style rule",
+			input:       "+ a, ~ b",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    "& + a, & ~ b",
+		},
+		{
+			name:        "child combinator in @scope",
+			input:       "> .child .grandchild",
+			nestingType: nesting.NestingTypeScope,
+			expected:    ":scope > .child .grandchild",
+		},
+		{
+			name:          "combinator without compound selector",
+			input:         ">",
+			nestingType:   nesting.NestingTypeNesting,
+			expectedError: true,
+		},
+		{
+			name:          "not nested",
+			input:         "> .child",
+			nestingType:   nesting.NestingTypeNone,
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			in := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(in)
+
+			selectors, err := ConsumeSelector(ts, tc.nestingType, nil, nil, false)
+
+			if tc.expectedError {
+				if err == nil {
+					t.Errorf("expected error but got %v", selectors)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+
+			strs := make([]string, 0, len(selectors))
+			for _, sel := range selectors {
+				if !sel.Flag.Has(css.SelectorFlagContainsScopeOrParent) {
+					t.Errorf("expected selector %q to contain the nesting anchor", sel.String())
+				}
+				strs = append(strs, sel.String())
+			}
+			if result := strings.Join(strs, ", "); result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
@@ -71,7 +71,7 @@ func Test_SelectorParser_ConsumeName(t *testing.T) {
 			"empty namespace",
 			"|div",
 			[]rune("div"),
-			[]rune("*"),
+			nil,
 			true,
 			csslexer.Token{Type: csslexer.EOFToken, Value: "", Raw: nil},
 		},
@@ -105,9 +105,10 @@ func Test_SelectorParser_ConsumeName(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
-			name, namespace, success := sp.consumeName()
+			qname, success := sp.consumeName()
+			name, namespace := qname.name, qname.namespace
 
 			if string(name) != string(tc.expectedName) {
 				t.Errorf("expected name %q, got %q", tc.expectedName, name)
@@ -290,7 +291,7 @@ func Test_SelectorParser_ConsumeCompoundSelector(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			selectors, _ := sp.consumeCompoundSelector(nesting.NestingTypeNone)
 
@@ -512,7 +513,7 @@ func Test_SelectorParser_ConsumeComplexSelector(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			selector, err := sp.consumeComplexSelector(nesting.NestingTypeNone, true)
 
@@ -582,7 +583,7 @@ func Test_SelectorParser_ConsumeCombinator(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			combinator := sp.consumeCombinator()
 
@@ -100,27 +100,32 @@ func (sp *SelectorParser) consumeAttribute() (*css.SimpleSelector, error) {
 		// consume the whitespace before the attribute selector
 		sp.tokenStream.ConsumeWhitespace()
 
-		name, namespace, hasQName := sp.consumeName()
+		qname, hasQName := sp.consumeName()
 
 		if !hasQName {
 			return errors.New("invalid attribute selector: missing name")
 		}
 
-		if name == "" {
+		if qname.name == "" {
 			return errors.New("invalid attribute selector: name cannot be empty")
 		}
 
-		// TODO: Handle namespace uri
-		// FIXME: when serializing, the "|" will be wrongly serialized as "\|"
-		nameStr := name
-		if namespace != "" {
-			nameStr = namespace + "|" + name
+		namespaceURI, ok := sp.resolveNamespace(qname, false)
+		if !ok {
+			return errors.New("invalid attribute selector: undeclared namespace prefix")
+		}
+
+		newAttrData := func(value string, match css.SelectorAttrMatchType) *css.SelectorDataAttr {
+			data := css.NewSelectorDataAttr(qname.name, value, match)
+			data.AttrNamespace = qname.namespace
+			data.AttrNamespaceURI = namespaceURI
+			return data
 		}
 
 		if sp.tokenStream.AtEnd() {
 			sel = &css.SimpleSelector{
 				Match: css.SelectorMatchAttributeSet,
-				Data:  css.NewSelectorDataAttr(nameStr, "", css.SelectorAttrMatchCaseSensitive),
+				Data:  newAttrData("", css.SelectorAttrMatchCaseSensitive),
 			}
 
 			return nil
@@ -145,7 +150,7 @@ func (sp *SelectorParser) consumeAttribute() (*css.SimpleSelector, error) {
 
 		sel = &css.SimpleSelector{
 			Match: matchType,
-			Data:  css.NewSelectorDataAttr(nameStr, valueToken.Value, attrMatchType),
+			Data:  newAttrData(valueToken.Value, attrMatchType),
 		}
 		return nil
 	})
@@ -44,7 +44,13 @@ func Test_SelectorParser_ConsumeSimpleSelector(t *testing.T) {
 			"[ns|attr=value]",
 			&css.SimpleSelector{
 				Match: css.SelectorMatchAttributeExact,
-				Data:  css.NewSelectorDataAttr("ns|attr", "value", css.SelectorAttrMatchCaseSensitive),
+				Data: &css.SelectorDataAttr{
+					AttrNamespace:    "ns",
+					AttrNamespaceURI: css.AnyNamespace,
+					AttrName:         "attr",
+					AttrValue:        "value",
+					AttrMatch:        css.SelectorAttrMatchCaseSensitive,
+				},
 			},
 		},
 		{
@@ -133,7 +139,7 @@ func Test_SelectorParser_ConsumeSimpleSelector(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			sel, _, err := sp.consumeSimpleSelector()
 
@@ -193,7 +199,7 @@ func Test_SelectorParser_ConsumeId(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			sel, err := sp.consumeId()
 
@@ -267,7 +273,7 @@ func Test_SelectorParser_ConsumeClass(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			sel, err := sp.consumeClass()
 
@@ -375,7 +381,7 @@ func Test_SelectorParser_ConsumeAttribute(t *testing.T) {
 			"attribute with namespace",
 			"[xml|lang=\"en\"]",
 			css.SelectorMatchAttributeExact,
-			"xml|lang",
+			"lang",
 			"en",
 			css.SelectorAttrMatchCaseSensitive,
 			false,
@@ -404,7 +410,7 @@ func Test_SelectorParser_ConsumeAttribute(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			sel, err := sp.consumeAttribute()
 
@@ -700,7 +706,7 @@ func Test_SelectorParser_ConsumePseudo(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			sel, flags, err := sp.consumePseudo()
 
@@ -846,7 +852,7 @@ func Test_SelectorParser_ConsumePseudo_Is_Where(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, flags, err := sp.consumeSimpleSelector()
 
@@ -964,7 +970,7 @@ func Test_SelectorParser_ConsumePseudo_Has(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, flags, err := sp.consumeSimpleSelector()
 
@@ -1058,7 +1064,7 @@ func Test_SelectorParser_ConsumePseudo_Not(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, flags, err := sp.consumeSimpleSelector()
 
@@ -1140,7 +1146,7 @@ func Test_SelectorParser_ConsumePseudo_Slotted(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, flags, err := sp.consumeSimpleSelector()
 
@@ -1280,7 +1286,7 @@ func Test_SelectorParser_ConsumePseudo_NthChild(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, flags, err := sp.consumeSimpleSelector()
 
@@ -1331,7 +1337,7 @@ func Test_SelectorParser_ConsumePseudo_NestingParent(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, flags, err := sp.consumeSimpleSelector()
 
@@ -1425,7 +1431,7 @@ func Test_SelectorParser_ConsumePseudo_Host(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, flags, err := sp.consumeSimpleSelector()
 
@@ -1614,7 +1620,7 @@ func Test_SelectorParser_ConsumeANPlusB(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			a, b, err := sp.consumeANPlusB()
 
@@ -1696,7 +1702,7 @@ func Test_SelectorParser_ConsumePseudo_NthSelectors_All(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, _, err := sp.consumeSimpleSelector()
 
@@ -1845,7 +1851,7 @@ func Test_SelectorParser_ConsumePseudo_NthChild_WithOf(t *testing.T) {
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, _, err := sp.consumeSimpleSelector()
 
@@ -1942,7 +1948,7 @@ func Test_SelectorParser_ConsumeSimpleSelector_FunctionalPseudo_IsWhere(t *testi
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, _, err := sp.consumeSimpleSelector()
 
@@ -2021,7 +2027,7 @@ func Test_SelectorParser_ConsumeSimpleSelector_FunctionalPseudo_LangAndDir(t *te
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, _, err := sp.consumeSimpleSelector()
 
@@ -2087,7 +2093,7 @@ func Test_SelectorParser_ConsumeSimpleSelector_FunctionalPseudo_Part(t *testing.
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, _, err := sp.consumeSimpleSelector()
 
@@ -2152,7 +2158,7 @@ func Test_SelectorParser_ConsumeSimpleSelector_PseudoElementValidation_WebKitSpe
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			result, _, err := sp.consumeSimpleSelector()
 
@@ -2211,7 +2217,7 @@ func Test_SelectorParser_ConsumeSimpleSelector_ANPlusB_ValidCases(t *testing.T)
 		t.Run(tc.name, func(t *testing.T) {
 			in := csslexer.NewInput(tc.input)
 			ts := token_stream.NewTokenStream(in)
-			sp := NewSelectorParser(ts, nil)
+			sp := NewSelectorParser(ts, nil, nil)
 
 			a, b, err := sp.consumeANPlusB()
 
@@ -0,0 +1,248 @@
+package selector
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// ExpandNestingParent replaces the '&' of each selector by the parent
+// selectors, so that the selectors can be matched without their parent
+// rule. If relative is true, selectors without '&' are relative to the
+// parent, as if they started with "& ".
+//
+// '&' is replaced by the parent selector itself when this keeps the
+// meaning and the specificity of the selector, e.g. "&:hover" becomes
+// ".a:hover" for the parent ".a", and by ":is(<parent>)" otherwise, e.g.
+// "& .c" becomes ":is(.a, .b) .c" for the parents ".a" and ".b".
+//
+// https://www.w3.org/TR/css-nesting-1/#nest-selector
+func ExpandNestingParent(selectors, parent []*css.Selector, relative bool) []*css.Selector {
+	result := make([]*css.Selector, 0, len(selectors))
+	for _, sel := range selectors {
+		if relative && !containsParent(sel) {
+			sel = prependParent(sel)
+		}
+		result = append(result, expandSelector(sel, parent))
+	}
+	return result
+}
+
+// expandSelector replaces the '&' of a selector, including the ones in
+// the arguments of pseudo-classes like :not(), by the parent selectors.
+func expandSelector(sel *css.Selector, parent []*css.Selector) *css.Selector {
+	inline := canInline(sel, parent)
+
+	result := &css.Selector{Flag: sel.Flag}
+	result.Span = sel.Span
+	for _, compound := range splitCompounds(sel.Selectors) {
+		if !compoundContainsParent(compound) {
+			for _, simple := range compound {
+				result.Append(expandPseudoArguments(simple, parent))
+			}
+			continue
+		}
+
+		for _, p := range parent {
+			result.Flag.Set(p.Flag)
+		}
+
+		if !inline {
+			result.Flag.Set(css.SelectorFlagContainsPseudo)
+			for _, simple := range compound {
+				if isParentSelector(simple) {
+					is := newIsSelector(parent, simple.Relation)
+					is.Span = simple.Span
+					result.Append(is)
+				} else {
+					result.Append(expandPseudoArguments(simple, parent))
+				}
+			}
+			continue
+		}
+
+		// The parent selector takes the place of the compound selector,
+		// followed by the other simple selectors of the compound.
+		first := *parent[0].Selectors[0]
+		first.Relation = compound[0].Relation
+		result.Append(&first)
+		result.Append(parent[0].Selectors[1:]...)
+
+		for _, simple := range compound {
+			if isParentSelector(simple) {
+				continue
+			}
+			resolved := *expandPseudoArguments(simple, parent)
+			resolved.Relation = css.SelectorRelationSubSelector
+			result.Append(&resolved)
+		}
+	}
+
+	return result
+}
+
+// canInline checks if the '&' of a selector can be replaced by the parent
+// selector itself, instead of ":is(<parent>)", without changing what the
+// selector matches or its specificity.
+//
+// This requires a single parent selector without pseudo-elements, which
+// :is() can't match, and compound selectors holding a single '&' without a
+// type selector, since it must come first. "&&" must keep the specificity
+// of the parent twice, which ".a" alone doesn't. A complex parent selector
+// can only be written in the leftmost compound selector, and only once:
+// ".a .b" in ".x &" would match a ".b" inside an ".x" inside an ".a", while
+// ":is(.a .b)" also matches one inside an ".a" inside an ".x".
+func canInline(sel *css.Selector, parent []*css.Selector) bool {
+	if len(parent) != 1 {
+		return false
+	}
+
+	for _, simple := range parent[0].Selectors {
+		if simple.Match == css.SelectorMatchPseudoElement {
+			return false
+		}
+	}
+
+	complexParent := len(splitCompounds(parent[0].Selectors)) > 1
+
+	count := 0
+	for i, compound := range splitCompounds(sel.Selectors) {
+		if !compoundContainsParent(compound) {
+			continue
+		}
+		if complexParent && i > 0 {
+			return false
+		}
+
+		compoundCount := 0
+		for _, simple := range compound {
+			switch {
+			case isParentSelector(simple):
+				compoundCount++
+			case simple.Match == css.SelectorMatchTag || simple.Match == css.SelectorMatchUniversalTag:
+				return false
+			}
+		}
+		if compoundCount > 1 {
+			return false
+		}
+		count += compoundCount
+	}
+
+	return !complexParent || count == 1
+}
+
+// expandPseudoArguments returns the simple selector with the '&' in the
+// selector list argument of a pseudo-class, like :not(&), replaced by the
+// parent selectors.
+func expandPseudoArguments(simple *css.SimpleSelector, parent []*css.Selector) *css.SimpleSelector {
+	data, ok := simple.Data.(*css.SelectorDataPseudo)
+	if !ok || !selectorListContainsParent(data.SelectorList) {
+		return simple
+	}
+
+	resolved := *data
+	resolved.SelectorList = ExpandNestingParent(data.SelectorList, parent, false)
+
+	expanded := &css.SimpleSelector{
+		Match:    simple.Match,
+		Relation: simple.Relation,
+		Data:     &resolved,
+	}
+	expanded.Span = simple.Span
+
+	return expanded
+}
+
+// prependParent returns the selector relative to the parent rule, i.e.
+// "& <selector>".
+func prependParent(sel *css.Selector) *css.Selector {
+	first := *sel.Selectors[0]
+	first.Relation = css.SelectorRelationDescendant
+
+	result := &css.Selector{Flag: sel.Flag}
+	result.Span = sel.Span
+	result.Flag.Set(css.SelectorFlagContainsPseudo)
+	result.Flag.Set(css.SelectorFlagContainsScopeOrParent)
+	result.Flag.Set(css.SelectorFlagContainsComplexSelector)
+	result.Append(&css.SimpleSelector{
+		Match:    css.SelectorMatchPseudoClass,
+		Relation: css.SelectorRelationSubSelector,
+		Data:     css.NewSelectorDataPseudo("parent", css.SelectorPseudoParent),
+	})
+	result.Append(&first)
+	result.Append(sel.Selectors[1:]...)
+
+	return result
+}
+
+// newIsSelector returns an ":is()" simple selector with the given
+// selectors as its argument.
+func newIsSelector(selectors []*css.Selector, relation css.SelectorRelationType) *css.SimpleSelector {
+	data := css.NewSelectorDataPseudo("is", css.SelectorPseudoIs)
+	data.SelectorList = selectors
+
+	return &css.SimpleSelector{
+		Match:    css.SelectorMatchPseudoClass,
+		Relation: relation,
+		Data:     data,
+	}
+}
+
+// splitCompounds splits the simple selectors of a complex selector into its
+// compound selectors.
+func splitCompounds(selectors []*css.SimpleSelector) [][]*css.SimpleSelector {
+	var compounds [][]*css.SimpleSelector
+
+	start := 0
+	for i, simple := range selectors {
+		if i > start && simple.Relation != css.SelectorRelationSubSelector {
+			compounds = append(compounds, selectors[start:i])
+			start = i
+		}
+	}
+	if start < len(selectors) {
+		compounds = append(compounds, selectors[start:])
+	}
+
+	return compounds
+}
+
+// isParentSelector checks if a simple selector is '&'.
+func isParentSelector(simple *css.SimpleSelector) bool {
+	data, ok := simple.Data.(*css.SelectorDataPseudo)
+	return ok && simple.Match == css.SelectorMatchPseudoClass && data.PseudoType == css.SelectorPseudoParent
+}
+
+// compoundContainsParent checks if a compound selector holds '&' itself,
+// not in the argument of a pseudo-class.
+func compoundContainsParent(compound []*css.SimpleSelector) bool {
+	for _, simple := range compound {
+		if isParentSelector(simple) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsParent checks if a selector contains '&', including in the
+// arguments of pseudo-classes.
+func containsParent(sel *css.Selector) bool {
+	for _, simple := range sel.Selectors {
+		if isParentSelector(simple) {
+			return true
+		}
+		if data, ok := simple.Data.(*css.SelectorDataPseudo); ok && selectorListContainsParent(data.SelectorList) {
+			return true
+		}
+	}
+	return false
+}
+
+// selectorListContainsParent checks if any of the selectors contains '&'.
+func selectorListContainsParent(selectors []*css.Selector) bool {
+	for _, sel := range selectors {
+		if containsParent(sel) {
+			return true
+		}
+	}
+	return false
+}
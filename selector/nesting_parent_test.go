@@ -0,0 +1,170 @@
+package selector
+
+import (
+	"strings"
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeSelector_ExpandNestingParent(t *testing.T) {
+	testcases := []struct {
+		name        string
+		parent      string
+		input       string
+		nestingType nesting.NestingTypeType
+		expected    string
+	}{
+		{
+			name:        "implicit parent",
+			parent:      ".a",
+			input:       ".b",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ".a .b",
+		},
+		{
+			name:        "compound with parent",
+			parent:      ".a",
+			input:       "&:hover, &.b",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ".a:hover, .a.b",
+		},
+		{
+			name:        "relative selector",
+			parent:      ".a",
+			input:       "> .b",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ".a > .b",
+		},
+		{
+			name:        "parent after a combinator",
+			parent:      "div",
+			input:       ".x &, & + &",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ".x div, div + div",
+		},
+		{
+			name:        "parent repeated in a compound",
+			parent:      ".a",
+			input:       "&&, &.b&",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ":is(.a):is(.a), :is(.a).b:is(.a)",
+		},
+		{
+			name:        "type selector next to the parent",
+			parent:      ".a",
+			input:       "div&",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    "div:is(.a)",
+		},
+		{
+			name:        "parent selector list",
+			parent:      ".a, #b",
+			input:       "& .c",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ":is(.a, #b) .c",
+		},
+		{
+			name:        "complex parent",
+			parent:      ".a > .b",
+			input:       "&.x, .x &",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ".a > .b.x, .x :is(.a > .b)",
+		},
+		{
+			name:        "parent in a pseudo-class argument",
+			parent:      ".a",
+			input:       ":not(&) .b",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ":not(.a) .b",
+		},
+		{
+			name:        "parent with a pseudo-element",
+			parent:      ".a::before",
+			input:       "&:hover",
+			nestingType: nesting.NestingTypeNesting,
+			expected:    ":is(.a::before):hover",
+		},
+		{
+			name:        "scope keeps selectors without parent",
+			parent:      ".a",
+			input:       ".b, & .c",
+			nestingType: nesting.NestingTypeScope,
+			expected:    ".b, .a .c",
+		},
+		{
+			name:        "not nested",
+			parent:      ".a",
+			input:       "&",
+			nestingType: nesting.NestingTypeNone,
+			expected:    "&",
+		},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			parentSelectors, err := ConsumeSelector(
+				token_stream.NewTokenStream(csslexer.NewInput(tc.parent)),
+				nesting.NestingTypeNone, nil, nil, false,
+			)
+			if err != nil {
+				t.Fatalf("unexpected error in parent selector: %v", err)
+			}
+			parent := &css.StyleRule{Type: css.StyleRuleTypeQualifiedRule, Selectors: parentSelectors}
+
+			in := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(in)
+
+			selectors, err := ConsumeSelector(ts, tc.nestingType, parent, nil, true)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			strs := make([]string, 0, len(selectors))
+			for _, sel := range selectors {
+				strs = append(strs, sel.String())
+			}
+			if result := strings.Join(strs, ", "); result != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, result)
+			}
+		})
+	}
+}
+
+func Test_ConsumeSelector_ExpandNestingParentWithoutParent(t *testing.T) {
+	ts := token_stream.NewTokenStream(csslexer.NewInput("& .b, .c"))
+
+	selectors, err := ConsumeSelector(ts, nesting.NestingTypeNesting, nil, nil, true)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Without a parent rule, e.g. in a @mixin, the selectors are kept.
+	if len(selectors) != 2 || selectors[0].String() != "& .b" || selectors[1].String() != ".c" {
+		t.Errorf("expected the selectors to be kept, got %v", selectors)
+	}
+}
+
+func Test_ExpandNestingParent_KeepsInput(t *testing.T) {
+	parent, err := ConsumeSelector(token_stream.NewTokenStream(csslexer.NewInput(".a")), nesting.NestingTypeNone, nil, nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	selectors, err := ConsumeSelector(token_stream.NewTokenStream(csslexer.NewInput("&:hover :is(&) .b")), nesting.NestingTypeNesting, nil, nil, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expanded := ExpandNestingParent(selectors, parent, true)
+
+	if result := expanded[0].String(); result != ".a:hover :is(.a) .b" {
+		t.Errorf("expected %q, got %q", ".a:hover :is(.a) .b", result)
+	}
+	if result := selectors[0].String(); result != "&:hover :is(&) .b" {
+		t.Errorf("expected the selector to be unchanged, got %q", result)
+	}
+}
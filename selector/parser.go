@@ -9,23 +9,69 @@ import (
 type SelectorParser struct {
 	tokenStream          *token_stream.TokenStream
 	parentRuleForNesting *css.StyleRule
+	namespaces           css.NamespaceMap
 }
 
 func NewSelectorParser(
 	tokenStream *token_stream.TokenStream,
 	parentRuleForNesting *css.StyleRule,
+	namespaces css.NamespaceMap,
 ) *SelectorParser {
 	return &SelectorParser{
 		tokenStream:          tokenStream,
 		parentRuleForNesting: parentRuleForNesting,
+		namespaces:           namespaces,
 	}
 }
 
+// ConsumeSelector consumes a selector list. The namespace prefixes used in
+// it are resolved with namespaces, which holds the namespaces declared by
+// @namespace rules. If namespaces is nil, every prefix is accepted.
+//
+// If expandNestingParent is true, '&' is replaced by the selectors of the
+// parent rule, see ExpandNestingParent, and in style rules the selectors
+// without '&' are made relative to the parent rule. The selectors can then
+// be matched without their parent rule. Otherwise, '&' is kept as is.
 func ConsumeSelector(
 	tokenStream *token_stream.TokenStream,
 	nestingType nesting.NestingTypeType,
 	parentRuleForNesting *css.StyleRule,
+	namespaces css.NamespaceMap,
+	expandNestingParent bool,
 ) ([]*css.Selector, error) {
 	tokenStream.ConsumeWhitespace()
-	return NewSelectorParser(tokenStream, parentRuleForNesting).consumeComplexSelectorList(nestingType)
+
+	sp := NewSelectorParser(tokenStream, parentRuleForNesting, namespaces)
+	selectors, err := sp.consumeComplexSelectorList(nestingType)
+	if err != nil || !expandNestingParent {
+		return selectors, err
+	}
+
+	return sp.expandNestingParent(selectors, nestingType), nil
+}
+
+// expandNestingParent replaces the '&' of the selectors by the selectors
+// of the parent rule. Without a parent rule, e.g. at the top level, '&' is
+// kept and matches like ':scope'.
+//
+// In a @scope rule, selectors without '&' are relative to the scoping root
+// rather than to the parent rule, so only the '&' written is replaced.
+func (sp *SelectorParser) expandNestingParent(
+	selectors []*css.Selector,
+	nestingType nesting.NestingTypeType,
+) []*css.Selector {
+	if sp.parentRuleForNesting == nil || nestingType == nesting.NestingTypeNone {
+		return selectors
+	}
+
+	return ExpandNestingParent(selectors, sp.parentRuleForNesting.Selectors, nestingType == nesting.NestingTypeNesting)
+}
+
+// ConsumePageSelectorList consumes the comma-separated page selectors of a
+// @page rule, stopping before the '{' of its block. The list may be empty.
+//
+// https://www.w3.org/TR/css-page-3/#syntax-page-selector
+func ConsumePageSelectorList(tokenStream *token_stream.TokenStream) ([]*css.Selector, error) {
+	tokenStream.ConsumeWhitespace()
+	return NewSelectorParser(tokenStream, nil, nil).consumePageSelectorList()
 }
@@ -158,3 +158,13 @@ var PseudoTypeWithArgumentsMap = map[string]css.SelectorPseudoType{
 	"view-transition-old":            css.SelectorPseudoViewTransitionOld,
 	"where":                          css.SelectorPseudoWhere,
 }
+
+// pagePseudoTypeMap maps the names of the page pseudo-classes, which are
+// only valid in page selectors, to their types.
+//
+// https://www.w3.org/TR/css-page-3/#page-selectors
+var pagePseudoTypeMap = map[string]css.SelectorPseudoType{
+	"first": css.SelectorPseudoFirstPage,
+	"left":  css.SelectorPseudoLeftPage,
+	"right": css.SelectorPseudoRightPage,
+}
@@ -6,41 +6,57 @@ import (
 	"go.baoshuo.dev/cssparser/css"
 )
 
-func prependTypeSelectorIfNeeded(selectors []*css.SimpleSelector, name, namespace string, hasQName bool) []*css.SimpleSelector {
-	if !hasQName {
+// prependTypeSelectorIfNeeded prepends the type selector or the universal
+// selector of a compound selector, written at the given span, when it
+// can't be omitted.
+func prependTypeSelectorIfNeeded(selectors []*css.SimpleSelector, tag *css.SelectorDataTag, span css.Span) []*css.SimpleSelector {
+	if tag == nil {
 		// If we don't have a qualified name, we don't need to prepend a type selector.
 		return selectors
 	}
 
 	// TODO: Check if has :host
 
-	if name != "" {
+	if tag.TagName != "" {
 		sel := &css.SimpleSelector{
 			Match:    css.SelectorMatchTag,
-			Data:     css.NewSelectorDataTag(namespace, name),
+			Data:     tag,
 			Relation: css.SelectorRelationSubSelector,
 		}
+		sel.Span = span
 		selectors = append([]*css.SimpleSelector{sel}, selectors...) // Prepend the type selector
-	} else if namespace != "" {
+	} else if tag.Namespace != "" || tag.NamespaceURI != css.AnyNamespace || len(selectors) == 0 {
+		// The universal selector can only be dropped if it matches elements
+		// in any namespace, e.g. "*:hover" is the same as ":hover", but
+		// "ns|*:hover" is not. If we only have a universal selector, we
+		// still need to return it.
 		sel := &css.SimpleSelector{
 			Match:    css.SelectorMatchUniversalTag,
-			Data:     css.NewSelectorDataTag(namespace, ""),
-			Relation: css.SelectorRelationSubSelector,
-		}
-		selectors = append([]*css.SimpleSelector{sel}, selectors...) // Prepend the universal selector with namespace
-	} else if len(selectors) == 0 {
-		// If we only have a universal selector, we still need to return it.
-		sel := &css.SimpleSelector{
-			Match:    css.SelectorMatchUniversalTag,
-			Data:     css.NewSelectorDataTag("", ""),
+			Data:     tag,
 			Relation: css.SelectorRelationSubSelector,
 		}
+		sel.Span = span
 		selectors = append([]*css.SimpleSelector{sel}, selectors...) // Prepend the universal selector
 	}
 
 	return selectors
 }
 
+// setSelectorSpan sets the span of a selector, from the given start
+// position to the end of its last simple selector written in the source.
+// Implicit simple selectors, like the anchor of a relative selector, have
+// no span.
+func setSelectorSpan(sel *css.Selector, start css.Position) {
+	end := start
+	for _, simple := range sel.Selectors {
+		if simple.Span.IsValid() {
+			end = simple.Span.End
+		}
+	}
+
+	sel.Span = css.Span{Start: start, End: end}
+}
+
 func parsePseudoType(name string, hasArguments bool) css.SelectorPseudoType {
 	if hasArguments {
 		pseudoType, ok := PseudoTypeWithArgumentsMap[name]
@@ -0,0 +1,291 @@
+package supports
+
+import (
+	"errors"
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/fontface"
+	"go.baoshuo.dev/cssparser/selector"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+// consumeSupportsCondition consumes a @supports condition.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-supports-condition
+func (sp *SupportsParser) consumeSupportsCondition() (*css.SupportsCondition, error) {
+	start := sp.tokenStream.PeekSpan().Start
+
+	if sp.peekIsKeyword("not") {
+		sp.tokenStream.ConsumeIncludingWhitespace()
+
+		operand, err := sp.consumeSupportsInParens()
+		if err != nil {
+			return nil, err
+		}
+
+		condition := &css.SupportsCondition{
+			Type:     css.SupportsConditionNot,
+			Children: []*css.SupportsCondition{operand},
+		}
+		condition.SetSpan(sp.spanFrom(start))
+
+		return condition, nil
+	}
+
+	first, err := sp.consumeSupportsInParens()
+	if err != nil {
+		return nil, err
+	}
+
+	// Only peek past the whitespace, so that it is left untouched if the
+	// condition ends here.
+	state := sp.tokenStream.State()
+	sp.tokenStream.ConsumeWhitespace()
+
+	var conditionType css.SupportsConditionType
+	var keyword string
+	switch {
+	case sp.peekIsKeyword("and"):
+		conditionType = css.SupportsConditionAnd
+		keyword = "and"
+	case sp.peekIsKeyword("or"):
+		conditionType = css.SupportsConditionOr
+		keyword = "or"
+	default:
+		state.Restore()
+		return first, nil
+	}
+
+	condition := &css.SupportsCondition{
+		Type:     conditionType,
+		Children: []*css.SupportsCondition{first},
+	}
+
+	for sp.peekIsKeyword(keyword) {
+		sp.tokenStream.ConsumeIncludingWhitespace()
+
+		operand, err := sp.consumeSupportsInParens()
+		if err != nil {
+			return nil, err
+		}
+		condition.Children = append(condition.Children, operand)
+
+		state = sp.tokenStream.State()
+		sp.tokenStream.ConsumeWhitespace()
+	}
+	state.Restore()
+	condition.SetSpan(sp.spanFrom(start))
+
+	return condition, nil
+}
+
+// consumeSupportsInParens consumes a parenthesized condition, a supports
+// feature or a general-enclosed value.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-supports-in-parens
+func (sp *SupportsParser) consumeSupportsInParens() (*css.SupportsCondition, error) {
+	token := sp.tokenStream.Peek()
+	start := sp.tokenStream.PeekSpan().Start
+
+	switch token.Type {
+	case csslexer.LeftParenthesisToken:
+		state := sp.tokenStream.State()
+
+		// ( <supports-condition> )
+		var condition *css.SupportsCondition
+		err := sp.tokenStream.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+			ts.ConsumeWhitespace()
+
+			c, err := sp.consumeSupportsCondition()
+			if err != nil {
+				return err
+			}
+
+			ts.ConsumeWhitespace()
+			if !ts.AtEnd() {
+				return errors.New("invalid supports condition: unexpected tokens after condition")
+			}
+
+			condition = c
+			return nil
+		})
+		if err == nil {
+			// The span includes the parentheses
+			condition.SetSpan(sp.spanFrom(start))
+			return condition, nil
+		}
+		state.Restore()
+
+		// <supports-decl>
+		var declaration *css.Declaration
+		err = sp.tokenStream.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+			ts.ConsumeWhitespace()
+
+			d, err := sp.consumeDeclaration()
+			if err != nil {
+				return err
+			}
+
+			declaration = d
+			return nil
+		})
+		if err == nil {
+			condition := &css.SupportsCondition{
+				Type:        css.SupportsConditionDeclaration,
+				Declaration: declaration,
+			}
+			condition.SetSpan(sp.spanFrom(start))
+			return condition, nil
+		}
+		state.Restore()
+
+	case csslexer.FunctionToken:
+		state := sp.tokenStream.State()
+
+		condition, err := sp.consumeSupportsFunction()
+		if err == nil {
+			condition.SetSpan(sp.spanFrom(start))
+			return condition, nil
+		}
+		state.Restore()
+
+	default:
+		return nil, errors.New("invalid supports condition: expected '(' or function")
+	}
+
+	// <general-enclosed>
+	condition := &css.SupportsCondition{
+		Type:            css.SupportsConditionGeneralEnclosed,
+		GeneralEnclosed: sp.tokenStream.ConsumeComponentValue(),
+	}
+	condition.SetSpan(sp.spanFrom(start))
+
+	return condition, nil
+}
+
+// consumeSupportsFunction consumes the selector(), font-tech() and
+// font-format() supports features.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-supports-feature
+func (sp *SupportsParser) consumeSupportsFunction() (*css.SupportsCondition, error) {
+	condition := &css.SupportsCondition{}
+
+	switch strings.ToLower(sp.tokenStream.Peek().Value) {
+	case "selector":
+		condition.Type = css.SupportsConditionSelector
+	case "font-tech":
+		condition.Type = css.SupportsConditionFontTech
+	case "font-format":
+		condition.Type = css.SupportsConditionFontFormat
+	default:
+		return nil, errors.New("invalid supports condition: unknown function")
+	}
+
+	err := sp.tokenStream.ConsumeBlock(func(ts *token_stream.TokenStream) error {
+		ts.ConsumeWhitespace()
+
+		if condition.Type == css.SupportsConditionSelector {
+			selectors, err := selector.ConsumeSelector(ts, sp.nestingType, sp.parentRuleForNesting, sp.namespaces, false)
+			if err != nil {
+				return err
+			}
+			if len(selectors) != 1 || !ts.AtEnd() {
+				return errors.New("invalid supports condition: expected a single complex selector")
+			}
+
+			condition.Selector = selectors[0]
+			return nil
+		}
+
+		token := ts.Peek()
+		if token.Type != csslexer.IdentToken {
+			return errors.New("invalid supports condition: expected keyword")
+		}
+
+		keyword := strings.ToLower(token.Value)
+		if condition.Type == css.SupportsConditionFontTech && !fontface.IsFontTech(keyword) ||
+			condition.Type == css.SupportsConditionFontFormat && !fontface.IsFontFormat(keyword) {
+			return errors.New("invalid supports condition: unknown keyword")
+		}
+		ts.ConsumeIncludingWhitespace()
+
+		if !ts.AtEnd() {
+			return errors.New("invalid supports condition: unexpected tokens after keyword")
+		}
+
+		condition.Keyword = keyword
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return condition, nil
+}
+
+// consumeDeclaration consumes the contents of a <supports-decl>, i.e. what
+// is inside the parentheses.
+//
+// https://www.w3.org/TR/css-conditional-5/#typedef-supports-decl
+func (sp *SupportsParser) consumeDeclaration() (*css.Declaration, error) {
+	token := sp.tokenStream.Peek()
+	if token.Type != csslexer.IdentToken {
+		return nil, errors.New("invalid supports declaration: expected property name")
+	}
+	propertySpan := sp.tokenStream.PeekSpan()
+	sp.tokenStream.ConsumeIncludingWhitespace()
+
+	if sp.tokenStream.Peek().Type != csslexer.ColonToken {
+		return nil, errors.New("invalid supports declaration: expected ':' after property name")
+	}
+	sp.tokenStream.Consume()
+
+	declaration := &css.Declaration{
+		Property:     token.Value,
+		PropertySpan: propertySpan,
+	}
+
+	// The spans of the component values, to find the spans of the value
+	// and of the "!important" once they are known
+	var values []*css.ComponentValue
+	spans := make(map[*css.ComponentValue]css.Span)
+	for !sp.tokenStream.AtEnd() {
+		start := sp.tokenStream.PeekSpan().Start
+		value := sp.tokenStream.ConsumeComponentValue()
+		values = append(values, value)
+		spans[value] = css.Span{Start: start, End: sp.tokenStream.Position()}
+	}
+	values = css.TrimComponentValueList(values)
+
+	end := propertySpan.End
+	if n := len(values); n > 0 {
+		end = spans[values[n-1]].End
+	}
+
+	// Check for a trailing !important
+	if n := len(values); n >= 2 {
+		last := values[n-1]
+		rest := css.TrimComponentValueList(values[:n-1])
+
+		if last.Token.Type == csslexer.IdentToken && strings.EqualFold(last.Token.Value, "important") &&
+			len(rest) > 0 && rest[len(rest)-1].Token.Type == csslexer.DelimiterToken && rest[len(rest)-1].Token.Value == "!" {
+			declaration.Important = true
+			declaration.ImportantSpan = css.Span{Start: spans[rest[len(rest)-1]].Start, End: spans[last].End}
+			values = css.TrimComponentValueList(rest[:len(rest)-1])
+		}
+	}
+
+	if len(values) == 0 && !declaration.IsCustomProperty() {
+		return nil, errors.New("invalid supports declaration: empty value")
+	}
+	declaration.Value = css.SerializeComponentValueList(values)
+	if n := len(values); n > 0 {
+		declaration.ValueSpan = css.Span{Start: spans[values[0]].Start, End: spans[values[n-1]].End}
+	}
+	declaration.SetSpan(css.Span{Start: propertySpan.Start, End: end})
+
+	return declaration, nil
+}
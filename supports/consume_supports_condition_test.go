@@ -0,0 +1,78 @@
+package supports
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+func Test_ConsumeSupportsCondition(t *testing.T) {
+	testcases := []struct {
+		name              string
+		input             string
+		expectError       bool
+		expectedType      css.SupportsConditionType
+		expected          string
+		expectedNextToken csslexer.TokenType
+	}{
+		{"declaration", "(display: grid)", false, css.SupportsConditionDeclaration, "(display: grid)", csslexer.EOFToken},
+		{"declaration without spaces", "(display:grid)", false, css.SupportsConditionDeclaration, "(display: grid)", csslexer.EOFToken},
+		{"declaration with important", "(color: red !important)", false, css.SupportsConditionDeclaration, "(color: red !important)", csslexer.EOFToken},
+		{"declaration with function", "(width: calc(1px + 2em))", false, css.SupportsConditionDeclaration, "(width: calc(1px + 2em))", csslexer.EOFToken},
+		{"custom property with empty value", "(--foo:)", false, css.SupportsConditionDeclaration, "(--foo: )", csslexer.EOFToken},
+		{"not", "not (display: grid)", false, css.SupportsConditionNot, "not (display: grid)", csslexer.EOFToken},
+		{"and", "(display: grid) and (gap: 1em)", false, css.SupportsConditionAnd, "(display: grid) and (gap: 1em)", csslexer.EOFToken},
+		{"or", "(display: grid) or (display: flex) or (float: left)", false, css.SupportsConditionOr, "(display: grid) or (display: flex) or (float: left)", csslexer.EOFToken},
+		{"nested condition", "(not (display: grid)) and ((a: b) or (c: d))", false, css.SupportsConditionAnd, "(not (display: grid)) and ((a: b) or (c: d))", csslexer.EOFToken},
+		{"selector", "selector(a > b)", false, css.SupportsConditionSelector, "selector(a > b)", csslexer.EOFToken},
+		{"selector with pseudo-class", "selector(:focus-visible)", false, css.SupportsConditionSelector, "selector(:focus-visible)", csslexer.EOFToken},
+		{"font-tech", "font-tech(COLOR-COLRv1)", false, css.SupportsConditionFontTech, "font-tech(color-colrv1)", csslexer.EOFToken},
+		{"font-format", "font-format(woff2)", false, css.SupportsConditionFontFormat, "font-format(woff2)", csslexer.EOFToken},
+		{"unknown font-tech", "font-tech(foo)", false, css.SupportsConditionGeneralEnclosed, "font-tech(foo)", csslexer.EOFToken},
+		{"selector list", "selector(a, b)", false, css.SupportsConditionGeneralEnclosed, "selector(a, b)", csslexer.EOFToken},
+		{"unknown function", "foo(bar)", false, css.SupportsConditionGeneralEnclosed, "foo(bar)", csslexer.EOFToken},
+		{"general enclosed block", "(unknown thing)", false, css.SupportsConditionGeneralEnclosed, "(unknown thing)", csslexer.EOFToken},
+		{"empty declaration value", "(color:)", false, css.SupportsConditionGeneralEnclosed, "(color:)", csslexer.EOFToken},
+		{"stops before block", "(display: grid) { }", false, css.SupportsConditionDeclaration, "(display: grid)", csslexer.WhitespaceToken},
+		{"stops before mixed operator", "(a: b) and (c: d) or (e: f)", false, css.SupportsConditionAnd, "(a: b) and (c: d)", csslexer.WhitespaceToken},
+		{"bare declaration", "display: grid", true, 0, "", 0},
+		{"not without operand", "not", true, 0, "", 0},
+		{"and without operand", "(a: b) and", true, 0, "", 0},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			condition, err := ConsumeSupportsCondition(ts, nesting.NestingTypeNone, nil, nil)
+
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("expected error but got none")
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if condition.Type != tc.expectedType {
+				t.Errorf("expected type %v, got %v", tc.expectedType, condition.Type)
+			}
+
+			if condition.String() != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, condition.String())
+			}
+
+			if next := ts.Peek(); next.Type != tc.expectedNextToken {
+				t.Errorf("expected next token %v, got %v", tc.expectedNextToken, next.Type)
+			}
+		})
+	}
+}
@@ -0,0 +1,67 @@
+package supports
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// Oracle decides whether the features tested by @supports conditions are
+// supported, e.g. by a set of target browsers.
+type Oracle interface {
+	// SupportsDeclaration reports whether the property and its value are
+	// supported.
+	SupportsDeclaration(declaration *css.Declaration) bool
+
+	// SupportsSelector reports whether the complex selector is supported.
+	SupportsSelector(selector *css.Selector) bool
+
+	// SupportsFontTech reports whether the lowercased font technology is
+	// supported.
+	SupportsFontTech(tech string) bool
+
+	// SupportsFontFormat reports whether the lowercased font format is
+	// supported.
+	SupportsFontFormat(format string) bool
+}
+
+// Evaluate evaluates a @supports condition with the oracle.
+//
+// General-enclosed conditions always evaluate to false.
+//
+// https://www.w3.org/TR/css-conditional-5/#evaluate-a-supports-condition
+func Evaluate(condition *css.SupportsCondition, oracle Oracle) bool {
+	switch condition.Type {
+	case css.SupportsConditionDeclaration:
+		return oracle.SupportsDeclaration(condition.Declaration)
+
+	case css.SupportsConditionSelector:
+		return oracle.SupportsSelector(condition.Selector)
+
+	case css.SupportsConditionFontTech:
+		return oracle.SupportsFontTech(condition.Keyword)
+
+	case css.SupportsConditionFontFormat:
+		return oracle.SupportsFontFormat(condition.Keyword)
+
+	case css.SupportsConditionNot:
+		return !Evaluate(condition.Children[0], oracle)
+
+	case css.SupportsConditionAnd:
+		for _, child := range condition.Children {
+			if !Evaluate(child, oracle) {
+				return false
+			}
+		}
+		return true
+
+	case css.SupportsConditionOr:
+		for _, child := range condition.Children {
+			if Evaluate(child, oracle) {
+				return true
+			}
+		}
+		return false
+
+	default:
+		return false
+	}
+}
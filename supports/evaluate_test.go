@@ -0,0 +1,71 @@
+package supports
+
+import (
+	"strings"
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+type testOracle struct{}
+
+func (testOracle) SupportsDeclaration(declaration *css.Declaration) bool {
+	return declaration.Property == "display" && declaration.Value != "masonry"
+}
+
+func (testOracle) SupportsSelector(selector *css.Selector) bool {
+	return !strings.Contains(selector.String(), ":has")
+}
+
+func (testOracle) SupportsFontTech(tech string) bool {
+	return tech == "variations"
+}
+
+func (testOracle) SupportsFontFormat(format string) bool {
+	return format == "woff2"
+}
+
+func Test_Evaluate(t *testing.T) {
+	testcases := []struct {
+		name     string
+		input    string
+		expected bool
+	}{
+		{"supported declaration", "(display: grid)", true},
+		{"unsupported value", "(display: masonry)", false},
+		{"unsupported property", "(float: left)", false},
+		{"supported selector", "selector(a > b)", true},
+		{"unsupported selector", "selector(a:has(b))", false},
+		{"supported font-tech", "font-tech(variations)", true},
+		{"unsupported font-tech", "font-tech(color-svg)", false},
+		{"supported font-format", "font-format(woff2)", true},
+		{"unsupported font-format", "font-format(woff)", false},
+		{"not", "not (display: masonry)", true},
+		{"and", "(display: grid) and font-format(woff2)", true},
+		{"and with unsupported", "(display: grid) and (float: left)", false},
+		{"or", "(float: left) or (display: grid)", true},
+		{"or with unsupported", "(float: left) or (display: masonry)", false},
+		{"general enclosed", "foo(bar)", false},
+		{"not general enclosed", "not foo(bar)", true},
+	}
+
+	for _, tc := range testcases {
+		t.Run(tc.name, func(t *testing.T) {
+			input := csslexer.NewInput(tc.input)
+			ts := token_stream.NewTokenStream(input)
+
+			condition, err := ConsumeSupportsCondition(ts, nesting.NestingTypeNone, nil, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result := Evaluate(condition, testOracle{}); result != tc.expected {
+				t.Errorf("expected %v, got %v", tc.expected, result)
+			}
+		})
+	}
+}
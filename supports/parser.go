@@ -0,0 +1,77 @@
+package supports
+
+import (
+	"go.baoshuo.dev/cssparser/css"
+	"go.baoshuo.dev/cssparser/nesting"
+	"go.baoshuo.dev/cssparser/token_stream"
+)
+
+type SupportsParser struct {
+	tokenStream          *token_stream.TokenStream
+	nestingType          nesting.NestingTypeType
+	parentRuleForNesting *css.StyleRule
+	namespaces           css.NamespaceMap
+}
+
+func NewSupportsParser(
+	tokenStream *token_stream.TokenStream,
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	namespaces css.NamespaceMap,
+) *SupportsParser {
+	return &SupportsParser{
+		tokenStream:          tokenStream,
+		nestingType:          nestingType,
+		parentRuleForNesting: parentRuleForNesting,
+		namespaces:           namespaces,
+	}
+}
+
+// ConsumeSupportsCondition consumes a @supports condition, stopping at the
+// first token which cannot be part of it.
+//
+// The nesting type, the parent rule and the declared namespaces are used to
+// parse the selectors of selector() conditions.
+func ConsumeSupportsCondition(
+	tokenStream *token_stream.TokenStream,
+	nestingType nesting.NestingTypeType,
+	parentRuleForNesting *css.StyleRule,
+	namespaces css.NamespaceMap,
+) (*css.SupportsCondition, error) {
+	tokenStream.ConsumeWhitespace()
+	return NewSupportsParser(tokenStream, nestingType, parentRuleForNesting, namespaces).consumeSupportsCondition()
+}
+
+// ConsumeImportSupportsCondition consumes the contents of the supports()
+// modifier of an @import rule, which is either a supports condition or a
+// bare declaration. A bare declaration is returned as a declaration
+// condition.
+//
+// https://www.w3.org/TR/css-cascade-5/#typedef-import-conditions
+func ConsumeImportSupportsCondition(tokenStream *token_stream.TokenStream) (*css.SupportsCondition, error) {
+	tokenStream.ConsumeWhitespace()
+	sp := NewSupportsParser(tokenStream, nesting.NestingTypeNone, nil, nil)
+
+	state := tokenStream.State()
+	condition, err := sp.consumeSupportsCondition()
+	if err == nil {
+		tokenStream.ConsumeWhitespace()
+		if tokenStream.AtEnd() {
+			return condition, nil
+		}
+	}
+	state.Restore()
+
+	declaration, err := sp.consumeDeclaration()
+	if err != nil {
+		return nil, err
+	}
+
+	condition = &css.SupportsCondition{
+		Type:        css.SupportsConditionDeclaration,
+		Declaration: declaration,
+	}
+	condition.SetSpan(declaration.Span)
+
+	return condition, nil
+}
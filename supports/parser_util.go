@@ -0,0 +1,22 @@
+package supports
+
+import (
+	"strings"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+// peekIsKeyword checks if the next token is the given identifier, ignoring
+// ASCII case.
+func (sp *SupportsParser) peekIsKeyword(keyword string) bool {
+	t := sp.tokenStream.Peek()
+	return t.Type == csslexer.IdentToken && strings.EqualFold(t.Value, keyword)
+}
+
+// spanFrom returns the span of a node which starts at the given position
+// and ends with the last consumed token, not counting whitespace.
+func (sp *SupportsParser) spanFrom(start css.Position) css.Span {
+	return css.Span{Start: start, End: sp.tokenStream.PositionBeforeWhitespace()}
+}
@@ -30,6 +30,17 @@ func (ts *TokenStream) SetBoundary(tokenType csslexer.TokenType, enable bool) {
 func (ts *TokenStream) ConsumeBlockToEndWithRestoring(
 	endTokenType csslexer.TokenType,
 	blockConsumer func(ts *TokenStream) (commit bool, err error),
+) error {
+	return ts.consumeBlockToEnd(endTokenType, false, blockConsumer)
+}
+
+// consumeBlockToEnd implements ConsumeBlockToEndWithRestoring. If
+// closedAtEOF is true, EOF also ends the block, as if the end token was
+// there.
+func (ts *TokenStream) consumeBlockToEnd(
+	endTokenType csslexer.TokenType,
+	closedAtEOF bool,
+	blockConsumer func(ts *TokenStream) (commit bool, err error),
 ) error {
 	initialState := ts.State()
 	ob := maps.Clone(ts.b) // Clone the current boundaries to restore later if needed
@@ -54,6 +65,9 @@ func (ts *TokenStream) ConsumeBlockToEndWithRestoring(
 
 	ts.SkipUntil(endTokenType)
 	endToken := ts.Peek()
+	if endToken.Type == csslexer.EOFToken && closedAtEOF {
+		return nil
+	}
 	if endToken.Type != endTokenType {
 		initialState.Restore() // Restore the initial state if the end token does not match
 		return fmt.Errorf("expected end token %s, got %s", endTokenType, endToken.Type)
@@ -109,3 +123,21 @@ func (ts *TokenStream) ConsumeBlockToEnd(
 		return true, blockConsumer(ts)
 	})
 }
+
+// ConsumeRuleBlock consumes the {}-block of a rule, allowing the caller to
+// process the tokens within the block using the provided blockConsumer
+// function.
+//
+// Unlike ConsumeBlock, a block which is not closed before EOF is closed by
+// EOF, so that a stylesheet missing its last '}' keeps its last rule.
+//
+// https://drafts.csswg.org/css-syntax/#consume-block
+func (ts *TokenStream) ConsumeRuleBlock(blockConsumer func(ts *TokenStream) error) error {
+	if startToken := ts.Peek(); startToken.Type != csslexer.LeftBraceToken {
+		return fmt.Errorf("expected a block start token, got %s", startToken.Type)
+	}
+
+	return ts.consumeBlockToEnd(csslexer.RightBraceToken, true, func(ts *TokenStream) (commit bool, err error) {
+		return true, blockConsumer(ts)
+	})
+}
@@ -69,3 +69,40 @@ func Test_TokenStream_ConsumeBlock(t *testing.T) {
 		})
 	}
 }
+
+func Test_TokenStream_ConsumeRuleBlock(t *testing.T) {
+	tests := []struct {
+		name         string
+		input        string
+		expectError  bool
+		expectedType csslexer.TokenType
+	}{
+		{"closed block", `{ foo: bar; } a`, false, csslexer.WhitespaceToken},
+		{"block closed by EOF", `{ foo: bar;`, false, csslexer.EOFToken},
+		{"not a block", `( foo )`, true, csslexer.LeftParenthesisToken},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ts := NewTokenStream(csslexer.NewInput(tt.input))
+
+			var content []csslexer.TokenType
+			err := ts.ConsumeRuleBlock(func(ts *TokenStream) error {
+				for !ts.AtEnd() {
+					content = append(content, ts.Consume().Type)
+				}
+				return nil
+			})
+			if (err != nil) != tt.expectError {
+				t.Errorf("ConsumeRuleBlock() error = %v, expected error: %v", err, tt.expectError)
+			}
+			if !tt.expectError && len(content) == 0 {
+				t.Errorf("expected the block contents to be consumed")
+			}
+
+			if endToken := ts.Peek(); endToken.Type != tt.expectedType {
+				t.Errorf("Expected end token type %s, got %s", tt.expectedType, endToken.Type)
+			}
+		})
+	}
+}
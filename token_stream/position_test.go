@@ -0,0 +1,86 @@
+package token_stream
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
+)
+
+func TestPeekSpan(t *testing.T) {
+	ts := NewTokenStream(csslexer.NewInput("a /* x */\n  bc"))
+
+	expected := css.Span{
+		Start: css.Position{Offset: 0, Line: 1, Column: 1},
+		End:   css.Position{Offset: 1, Line: 1, Column: 2},
+	}
+	if span := ts.PeekSpan(); span != expected {
+		t.Errorf("expected %v, got %v", expected, span)
+	}
+
+	ts.Consume() // 'a'
+	ts.Consume() // ' '
+
+	// The comment is skipped, but still moves the position
+	expected = css.Span{
+		Start: css.Position{Offset: 9, Line: 1, Column: 10},
+		End:   css.Position{Offset: 12, Line: 2, Column: 3},
+	}
+	if span := ts.PeekSpan(); span != expected {
+		t.Errorf("expected %v, got %v", expected, span)
+	}
+
+	ts.Consume() // '\n  '
+	expected = css.Span{
+		Start: css.Position{Offset: 12, Line: 2, Column: 3},
+		End:   css.Position{Offset: 14, Line: 2, Column: 5},
+	}
+	if span := ts.PeekSpan(); span != expected {
+		t.Errorf("expected %v, got %v", expected, span)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	ts := NewTokenStream(csslexer.NewInput("a { b } c"))
+
+	if pos := ts.Position(); pos != css.StartPosition {
+		t.Errorf("expected %v, got %v", css.StartPosition, pos)
+	}
+
+	ts.Consume() // 'a'
+	ts.Peek()    // Peeking doesn't move the position
+	if pos := ts.Position(); pos.Offset != 1 {
+		t.Errorf("expected offset 1, got %d", pos.Offset)
+	}
+
+	ts.ConsumeWhitespace()
+	ts.ConsumeComponentValue() // '{ b }'
+	if pos := ts.Position(); pos.Offset != 7 {
+		t.Errorf("expected offset 7, got %d", pos.Offset)
+	}
+
+	state := ts.State()
+	ts.ConsumeWhitespace()
+	if pos := ts.PositionBeforeWhitespace(); pos.Offset != 7 {
+		t.Errorf("expected offset 7 before whitespace, got %d", pos.Offset)
+	}
+	ts.Consume() // 'c'
+	if pos := ts.Position(); pos.Offset != 9 {
+		t.Errorf("expected offset 9, got %d", pos.Offset)
+	}
+	if pos := ts.PositionBeforeWhitespace(); pos.Offset != 9 {
+		t.Errorf("expected offset 9 before whitespace, got %d", pos.Offset)
+	}
+
+	state.Restore()
+	if pos := ts.Position(); pos.Offset != 7 {
+		t.Errorf("expected offset 7 after restore, got %d", pos.Offset)
+	}
+	if pos := ts.PositionBeforeWhitespace(); pos.Offset != 7 {
+		t.Errorf("expected offset 7 before whitespace after restore, got %d", pos.Offset)
+	}
+	if span := ts.PeekSpan(); span.Start.Offset != 7 {
+		t.Errorf("expected the peeked token at offset 7 after restore, got %d", span.Start.Offset)
+	}
+}
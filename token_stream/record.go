@@ -0,0 +1,34 @@
+package token_stream
+
+// StartRecording starts recording the raw text of the tokens consumed from
+// now on, and returns a mark to pass to StopRecording. Recordings may be
+// nested.
+func (ts *TokenStream) StartRecording() int {
+	ts.n++
+	return len(ts.r)
+}
+
+// StopRecording stops the recording started with the given mark, and
+// returns the raw text of the tokens consumed since then. Comments are
+// skipped by the token stream, so they are not part of the text.
+func (ts *TokenStream) StopRecording(mark int) string {
+	var text string
+	if mark < len(ts.r) {
+		text = string(ts.r[mark:])
+	}
+
+	ts.n--
+	if ts.n == 0 {
+		ts.r = ts.r[:0]
+	}
+
+	return text
+}
+
+// record appends the raw text of a consumed token to the recorded text if
+// a recording is active.
+func (ts *TokenStream) record(raw []rune) {
+	if ts.n > 0 {
+		ts.r = append(ts.r, raw...)
+	}
+}
@@ -0,0 +1,51 @@
+package token_stream
+
+import (
+	"testing"
+
+	"go.baoshuo.dev/csslexer"
+)
+
+func TestRecording(t *testing.T) {
+	ts := NewTokenStream(csslexer.NewInput("a /* comment */ b { c } d"))
+
+	ts.Consume() // 'a'
+	outer := ts.StartRecording()
+	ts.ConsumeWhitespace()
+	ts.Consume() // 'b'
+
+	inner := ts.StartRecording()
+	ts.ConsumeWhitespace()
+	ts.ConsumeComponentValue() // '{ c }'
+	if text := ts.StopRecording(inner); text != " { c }" {
+		t.Errorf("expected %q, got %q", " { c }", text)
+	}
+
+	ts.Consume() // whitespace
+	if text := ts.StopRecording(outer); text != "  b { c } " {
+		t.Errorf("expected %q, got %q", "  b { c } ", text)
+	}
+
+	// Nothing is recorded once all recordings are stopped
+	ts.Consume() // 'd'
+	if len(ts.r) != 0 {
+		t.Errorf("expected no recorded text, got %q", string(ts.r))
+	}
+}
+
+func TestRecordingRestore(t *testing.T) {
+	ts := NewTokenStream(csslexer.NewInput("a b c"))
+
+	mark := ts.StartRecording()
+	ts.Consume() // 'a'
+	state := ts.State()
+	ts.Consume() // whitespace
+	ts.Consume() // 'b'
+	state.Restore()
+	ts.ConsumeWhitespace()
+
+	// The tokens consumed again are recorded once
+	if text := ts.StopRecording(mark); text != "a " {
+		t.Errorf("expected %q, got %q", "a ", text)
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"maps"
 
 	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
 )
 
 // TokenStreamState captures the state of a TokenStream at a specific point in time.
@@ -14,6 +16,12 @@ type TokenStreamState struct {
 	inputState  csslexer.InputState         // The state of the input.
 	peekedToken *csslexer.Token             // The token that was peeked.
 	boundaries  map[csslexer.TokenType]bool // Boundary tokens.
+	recorded    int                         // The length of the recorded text.
+
+	pos         css.Position // The position after the last token read from the lexer.
+	peekedSpan  css.Span     // The span of the token that was peeked.
+	consumedEnd css.Position // The position after the last consumed token.
+	contentEnd  css.Position // The position after the last consumed token which is not whitespace.
 }
 
 // State captures the current state of the TokenStream and returns it as a TokenStreamState.
@@ -26,6 +34,12 @@ func (ts *TokenStream) State() TokenStreamState {
 		inputState:  ts.z.State(),
 		peekedToken: nil,
 		boundaries:  maps.Clone(ts.b),
+		recorded:    len(ts.r),
+
+		pos:         ts.pos,
+		peekedSpan:  ts.ps,
+		consumedEnd: ts.end,
+		contentEnd:  ts.cnt,
 	}
 
 	if ts.p != nil {
@@ -58,4 +72,15 @@ func (tss TokenStreamState) Restore() {
 
 	// Restore the boundaries.
 	tss.tokenStream.b = maps.Clone(tss.boundaries)
+
+	// Restore the positions.
+	tss.tokenStream.pos = tss.pos
+	tss.tokenStream.ps = tss.peekedSpan
+	tss.tokenStream.end = tss.consumedEnd
+	tss.tokenStream.cnt = tss.contentEnd
+
+	// Forget the text recorded for the tokens which will be consumed again.
+	if len(tss.tokenStream.r) > tss.recorded {
+		tss.tokenStream.r = tss.tokenStream.r[:tss.recorded]
+	}
 }
@@ -2,6 +2,8 @@ package token_stream
 
 import (
 	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
 )
 
 // ConsumeWhitespace consumes all upcoming whitespace tokens
@@ -77,3 +79,54 @@ func (ts *TokenStream) SkipUntil(types ...csslexer.TokenType) {
 		}
 	}
 }
+
+// ConsumeComponentValue consumes a component value from the token stream.
+//
+// Functions and simple blocks are consumed up to their matching end token,
+// or up to EOF if the block is not closed, ignoring any boundary set on
+// the token stream.
+//
+// https://www.w3.org/TR/2021/CRD-css-syntax-3-20211224/#consume-component-value
+func (ts *TokenStream) ConsumeComponentValue() *css.ComponentValue {
+	token := ts.Consume()
+
+	value := &css.ComponentValue{Token: token}
+
+	switch token.Type {
+	case csslexer.LeftBraceToken, csslexer.LeftBracketToken, csslexer.LeftParenthesisToken:
+		value.Type = css.ComponentValueTypeSimpleBlock
+	case csslexer.FunctionToken:
+		value.Type = css.ComponentValueTypeFunction
+	default:
+		value.Type = css.ComponentValueTypePreservedToken
+		return value
+	}
+
+	endTokenType := getMatchingBlockEndToken(token.Type)
+	for {
+		next := ts.Peek()
+		if next.Type == csslexer.EOFToken {
+			// Parse error, but the block is returned as is.
+			break
+		}
+		if next.Type == endTokenType {
+			ts.Consume()
+			break
+		}
+		value.Children = append(value.Children, ts.ConsumeComponentValue())
+	}
+
+	return value
+}
+
+// ConsumeComponentValueList consumes component values until the end of
+// the token stream (EOF or a boundary token) is reached.
+func (ts *TokenStream) ConsumeComponentValueList() []*css.ComponentValue {
+	var values []*css.ComponentValue
+
+	for !ts.AtEnd() {
+		values = append(values, ts.ConsumeComponentValue())
+	}
+
+	return values
+}
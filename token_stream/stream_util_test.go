@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
 )
 
 func TestConsumeWhitespace(t *testing.T) {
@@ -187,4 +189,100 @@ func TestSkipUntilWithMismatchedBlocks(t *testing.T) {
 	if token.Type != csslexer.SemicolonToken {
 		t.Errorf("Expected SemicolonToken, got %v", token.Type)
 	}
-}
\ No newline at end of file
+}
+
+func TestConsumeComponentValue(t *testing.T) {
+	tests := []struct {
+		name             string
+		input            string
+		expectedType     css.ComponentValueType
+		expectedString   string
+		expectedChildren int
+		expectedNext     csslexer.TokenType
+	}{
+		{
+			name:             "preserved token",
+			input:            "screen and",
+			expectedType:     css.ComponentValueTypePreservedToken,
+			expectedString:   "screen",
+			expectedChildren: 0,
+			expectedNext:     csslexer.WhitespaceToken,
+		},
+		{
+			name:             "function",
+			input:            "calc(1px + 2px) foo",
+			expectedType:     css.ComponentValueTypeFunction,
+			expectedString:   "calc(1px + 2px)",
+			expectedChildren: 5,
+			expectedNext:     csslexer.WhitespaceToken,
+		},
+		{
+			name:             "nested blocks",
+			input:            "(a [b] {c})",
+			expectedType:     css.ComponentValueTypeSimpleBlock,
+			expectedString:   "(a [b] {c})",
+			expectedChildren: 5,
+			expectedNext:     csslexer.EOFToken,
+		},
+		{
+			name:             "mismatched end tokens are kept",
+			input:            "[a } b]",
+			expectedType:     css.ComponentValueTypeSimpleBlock,
+			expectedString:   "[a } b]",
+			expectedChildren: 5,
+			expectedNext:     csslexer.EOFToken,
+		},
+		{
+			name:             "unclosed block",
+			input:            "{ color: red",
+			expectedType:     css.ComponentValueTypeSimpleBlock,
+			expectedString:   "{ color: red}",
+			expectedChildren: 5,
+			expectedNext:     csslexer.EOFToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			input := csslexer.NewInput(tt.input)
+			ts := NewTokenStream(input)
+
+			value := ts.ConsumeComponentValue()
+
+			if value.Type != tt.expectedType {
+				t.Errorf("Expected type %v, got %v", tt.expectedType, value.Type)
+			}
+
+			if value.String() != tt.expectedString {
+				t.Errorf("Expected %q, got %q", tt.expectedString, value.String())
+			}
+
+			if len(value.Children) != tt.expectedChildren {
+				t.Errorf("Expected %d children, got %d", tt.expectedChildren, len(value.Children))
+			}
+
+			if next := ts.Peek(); next.Type != tt.expectedNext {
+				t.Errorf("Expected next token %v, got %v", tt.expectedNext, next.Type)
+			}
+		})
+	}
+}
+
+func TestConsumeComponentValueList(t *testing.T) {
+	input := csslexer.NewInput("{ a (b; c) ; d }")
+	ts := NewTokenStream(input)
+
+	var values []*css.ComponentValue
+	err := ts.ConsumeBlock(func(ts *TokenStream) error {
+		ts.SetBoundary(csslexer.SemicolonToken, true)
+		values = ts.ConsumeComponentValueList()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if got := css.SerializeComponentValueList(values); got != " a (b; c) " {
+		t.Errorf("Expected %q, got %q", " a (b; c) ", got)
+	}
+}
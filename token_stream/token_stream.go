@@ -2,6 +2,8 @@ package token_stream
 
 import (
 	"go.baoshuo.dev/csslexer"
+
+	"go.baoshuo.dev/cssparser/css"
 )
 
 type TokenStream struct {
@@ -9,6 +11,13 @@ type TokenStream struct {
 	l *csslexer.Lexer             // The lexer that reads from the input.
 	p *csslexer.Token             // The current token being processed.
 	b map[csslexer.TokenType]bool // Boundary tokens, used to determine if the current token is a boundary token.
+	r []rune                      // The raw text of the tokens consumed while recording.
+	n int                         // The number of active recordings.
+
+	pos css.Position // The position after the last token read from the lexer.
+	ps  css.Span     // The span of the current token being processed.
+	end css.Position // The position after the last consumed token.
+	cnt css.Position // The position after the last consumed token which is not whitespace.
 }
 
 // NewTokenStream creates a new TokenStream from the given input.
@@ -18,6 +27,10 @@ func NewTokenStream(input *csslexer.Input) *TokenStream {
 		l: csslexer.NewLexer(input),
 		p: nil,
 		b: make(map[csslexer.TokenType]bool),
+
+		pos: css.StartPosition,
+		end: css.StartPosition,
+		cnt: css.StartPosition,
 	}
 }
 
@@ -26,8 +39,9 @@ func (s *TokenStream) Peek() csslexer.Token {
 	if s.p == nil {
 		// Skip comment tokens automatically
 		for {
-			token := s.l.Next()
+			token, span := s.next()
 			if token.Type != csslexer.CommentToken {
+				s.ps = span
 				p := tokenPool.Get().(*csslexer.Token)
 				p.Type, p.Value, p.Raw = token.Type, token.Value, token.Raw
 				s.p = p
@@ -49,6 +63,11 @@ func (s *TokenStream) Consume() csslexer.Token {
 		tt, value, raw := s.p.Type, s.p.Value, s.p.Raw
 		tokenPool.Put(s.p)
 		s.p = nil
+		s.record(raw)
+		s.end = s.ps.End
+		if tt != csslexer.WhitespaceToken {
+			s.cnt = s.end
+		}
 
 		return csslexer.Token{
 			Type:  tt,
@@ -58,14 +77,49 @@ func (s *TokenStream) Consume() csslexer.Token {
 	} else {
 		// Skip comment tokens automatically
 		for {
-			token := s.l.Next()
+			token, span := s.next()
 			if token.Type != csslexer.CommentToken {
+				s.record(token.Raw)
+				s.end = span.End
+				if token.Type != csslexer.WhitespaceToken {
+					s.cnt = s.end
+				}
 				return token
 			}
 		}
 	}
 }
 
+// PeekSpan returns the span of the current token in the source text,
+// without consuming it.
+func (s *TokenStream) PeekSpan() css.Span {
+	s.Peek()
+	return s.ps
+}
+
+// Position returns the position after the last consumed token, which is
+// the start of the source text if no token was consumed yet.
+func (s *TokenStream) Position() css.Position {
+	return s.end
+}
+
+// PositionBeforeWhitespace returns the position after the last consumed
+// token which is not whitespace, i.e. the end of a node followed by
+// whitespace which was consumed along with it.
+func (s *TokenStream) PositionBeforeWhitespace() css.Position {
+	return s.cnt
+}
+
+// next reads the next token from the lexer, along with its span.
+func (s *TokenStream) next() (csslexer.Token, css.Span) {
+	token := s.l.Next()
+
+	start := s.pos
+	s.pos = s.pos.Advance(token.Raw)
+
+	return token, css.Span{Start: start, End: s.pos}
+}
+
 // AtEnd returns true if the current token is an EOF token or a boundary token.
 func (ts *TokenStream) AtEnd() bool {
 	token := ts.Peek()
@@ -0,0 +1,62 @@
+package cssparser
+
+import "go.baoshuo.dev/cssparser/css"
+
+// newScopeSelector returns the ":scope" selector.
+func newScopeSelector() *css.Selector {
+	sel := &css.Selector{}
+	sel.Flag.Set(css.SelectorFlagContainsPseudo)
+	sel.Flag.Set(css.SelectorFlagContainsScopeOrParent)
+	sel.Append(&css.SimpleSelector{
+		Match:    css.SelectorMatchPseudoClass,
+		Relation: css.SelectorRelationSubSelector,
+		Data:     css.NewSelectorDataPseudo("scope", css.SelectorPseudoScope),
+	})
+	return sel
+}
+
+// newParentSelector returns the "&" selector.
+func newParentSelector() *css.Selector {
+	sel := &css.Selector{}
+	sel.Flag.Set(css.SelectorFlagContainsPseudo)
+	sel.Flag.Set(css.SelectorFlagContainsScopeOrParent)
+	sel.Append(&css.SimpleSelector{
+		Match:    css.SelectorMatchPseudoClass,
+		Relation: css.SelectorRelationSubSelector,
+		Data:     css.NewSelectorDataPseudo("parent", css.SelectorPseudoParent),
+	})
+	return sel
+}
+
+// newWhereSelector returns a ":where()" selector with the given selectors
+// as its argument.
+func newWhereSelector(selectors ...*css.Selector) *css.Selector {
+	data := css.NewSelectorDataPseudo("where", css.SelectorPseudoWhere)
+	data.SelectorList = selectors
+
+	sel := &css.Selector{}
+	sel.Flag.Set(css.SelectorFlagContainsPseudo)
+	for _, s := range selectors {
+		sel.Flag.Set(s.Flag)
+	}
+	sel.Append(&css.SimpleSelector{
+		Match:    css.SelectorMatchPseudoClass,
+		Relation: css.SelectorRelationSubSelector,
+		Data:     data,
+	})
+	return sel
+}
+
+// spanSetter is implemented by the nodes which embed css.Node, like every
+// rule built by the parser.
+type spanSetter interface {
+	SetSpan(span css.Span)
+}
+
+// setSpan sets the span of a rule which starts at the given position and
+// ends with the last consumed token.
+func (p *Parser) setSpan(rule css.Rule, start css.Position) {
+	if node, ok := rule.(spanSetter); ok {
+		node.SetSpan(css.Span{Start: start, End: p.s.Position()})
+	}
+}